@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/inquiryproj/inquiry/internal/executor"
+	"github.com/inquiryproj/inquiry/internal/executor/importer"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// curlCommand converts a curl command line to a step, or exports the steps of a scenario as curl command lines.
+// It returns whether the command line was converted or the steps were exported.
+func curlCommand(logger *slog.Logger, args []string) bool {
+	flags := flag.NewFlagSet("curl", flag.ExitOnError)
+	name := flags.String("name", "", "the name of the imported step")
+	export := flags.Bool("export", false, "print the curl command lines of the steps of a scenario")
+	fileName := flags.String("file", "", "the file name of the exported test scenario")
+	environment := flags.String("env", "", "the name of the environment of the environments file the scenario is exported for")
+	environmentsFile := flags.String("environments", "", "the file name of the environments, defaults to environments.yaml next to the scenario")
+	_ = flags.Parse(args)
+
+	if *export {
+		err := exportCurl(logger, *fileName, *environment, *environmentsFile)
+		if err != nil {
+			logger.Error("unable to export curl commands", slog.String("error", err.Error()))
+			return false
+		}
+		return true
+	}
+	step, err := importCurl(*name, flags.Args())
+	if err == nil {
+		err = printStep(step)
+	}
+	if err != nil {
+		logger.Error("unable to import curl command", slog.String("error", err.Error()))
+		return false
+	}
+	return true
+}
+
+// importCurl converts the curl command line of the arguments, or else read from stdin, to a step.
+func importCurl(name string, args []string) (*yaml.Step, error) {
+	if len(args) > 0 {
+		return importer.ImportCurlArgs(name, args)
+	}
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	return importer.ImportCurl(name, string(b))
+}
+
+// printStep prints the step, such that it can be added to the steps of a scenario.
+func printStep(step *yaml.Step) error {
+	b, err := yaml.MarshalSteps([]*yaml.Step{step})
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(b)
+	return err
+}
+
+// exportCurl prints the curl command lines of all steps of a scenario, which is read as it is
+// played, i.e. with its fragments expanded and its placeholders replaced.
+func exportCurl(logger *slog.Logger, fileName, environment, environmentsFile string) error {
+	if fileName == "" {
+		return fmt.Errorf("file flag is required, provide as --file <file.yaml>")
+	}
+	opts, err := executorOptions(logger, fileName, nil, "")
+	if err != nil {
+		return err
+	}
+	variables, err := environmentVariables(fileName, environment, environmentsFile)
+	if err != nil {
+		return err
+	}
+	scenarios, err := executor.Load(fileName, append(opts, executor.WithVariables(variables))...)
+	if err != nil {
+		return err
+	}
+	for _, scenario := range scenarios {
+		for _, steps := range [][]*yaml.Step{scenario.Setup, scenario.Steps, scenario.Teardown} {
+			for _, step := range steps {
+				if step.Request == nil {
+					continue
+				}
+				fmt.Printf("# %s\n%s\n\n", step.Name, step.Request.Curl())
+			}
+		}
+	}
+	return nil
+}
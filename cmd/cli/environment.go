@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultEnvironmentsFile is the name of the environments file in the directory of the scenario.
+const defaultEnvironmentsFile = "environments.yaml"
+
+// environmentVariables returns the variables of the environment with the given name, or nil if no
+// environment is given. The environments file maps the names of environments to their variables,
+// it defaults to environments.yaml in the directory of the scenario:
+//
+//	staging:
+//	  base_url: https://staging.example.com
+//	production:
+//	  base_url: https://example.com
+func environmentVariables(scenarioName, environment, environmentsFile string) (map[string]string, error) {
+	if environment == "" {
+		return nil, nil
+	}
+	if environmentsFile == "" {
+		environmentsFile = filepath.Join(filepath.Dir(scenarioName), defaultEnvironmentsFile)
+	}
+	data, err := os.ReadFile(environmentsFile)
+	if err != nil {
+		return nil, err
+	}
+	environments := map[string]map[string]string{}
+	err = yaml.Unmarshal(data, &environments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid environments file %s: %w", environmentsFile, err)
+	}
+	variables, ok := environments[environment]
+	if !ok {
+		return nil, fmt.Errorf("environment %s not found in %s", environment, environmentsFile)
+	}
+	return variables, nil
+}
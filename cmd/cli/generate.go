@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/inquiryproj/inquiry/internal/executor/openapi"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// generate writes a test scenario for every operation, or every tag, of an OpenAPI 3 document.
+// It returns whether all scenarios were written.
+func generate(logger *slog.Logger, args []string) bool {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	specPath := flags.String("openapi", "", "the file name of the OpenAPI 3 document")
+	outDir := flags.String("out", ".", "the directory the generated scenarios are written to")
+	group := flags.String("group", string(openapi.GroupByOperation), "generate a scenario per operation or per tag")
+	_ = flags.Parse(args)
+	if *specPath == "" {
+		logger.Error("openapi flag is required, provide as --openapi <api-spec.yaml>")
+		return false
+	}
+
+	scenarios, err := generateScenarios(*specPath, *outDir, openapi.Grouping(*group))
+	if err != nil {
+		logger.Error("unable to generate test scenarios", slog.String("error", err.Error()))
+		return false
+	}
+	for _, scenario := range scenarios {
+		err = writeScenario(logger, *outDir, scenario.Name, scenario.TestSpec, scenario.Scenario)
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// generateScenarios generates the scenarios of the document, which reference the
+// document relative to the output directory for contract validation.
+func generateScenarios(specPath, outDir string, grouping openapi.Grouping) ([]*openapi.GeneratedScenario, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	name, err := relativePath(specPath, outDir)
+	if err != nil {
+		return nil, err
+	}
+	document, err := openapi.NewDocument(name, data)
+	if err != nil {
+		return nil, err
+	}
+	return document.Generate(grouping)
+}
+
+func relativePath(specPath, outDir string) (string, error) {
+	absSpecPath, err := filepath.Abs(specPath)
+	if err != nil {
+		return "", err
+	}
+	absOutDir, err := filepath.Abs(outDir)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absOutDir, absSpecPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// writeScenario writes the test scenario to <outDir>/<name>.yaml.
+func writeScenario(logger *slog.Logger, outDir, name string, testSpec *yaml.TestSpec, scenario *yaml.Scenario) error {
+	fileName := filepath.Join(outDir, name+".yaml")
+	b, err := yaml.MarshalTestDefinition(testSpec, scenario)
+	if err == nil {
+		err = os.MkdirAll(outDir, 0o750)
+	}
+	if err == nil {
+		err = os.WriteFile(fileName, b, 0o600)
+	}
+	if err != nil {
+		logger.Error("unable to write test scenario", slog.String("file", fileName), slog.String("error", err.Error()))
+		return err
+	}
+	logger.Info("test scenario written", slog.String("file", fileName), slog.Int("steps", len(scenario.Steps)))
+	return nil
+}
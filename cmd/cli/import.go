@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/inquiryproj/inquiry/internal/executor/importer"
+)
+
+// importScenarios writes a test scenario for every page of a HAR file or every folder of a Postman collection.
+// It returns whether all scenarios were written.
+func importScenarios(logger *slog.Logger, args []string) bool {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "the format of the imported file, har or postman")
+	fileName := flags.String("file", "", "the file name of the HAR file or Postman collection")
+	outDir := flags.String("out", ".", "the directory the imported scenarios are written to")
+	_ = flags.Parse(args)
+	if *fileName == "" {
+		logger.Error("file flag is required, provide as --file <collection.json>")
+		return false
+	}
+
+	data, err := os.ReadFile(*fileName)
+	if err != nil {
+		logger.Error("unable to read file", slog.String("error", err.Error()))
+		return false
+	}
+	scenarios, err := importer.Import(importer.Format(*format), data)
+	if err != nil {
+		logger.Error("unable to import test scenarios", slog.String("error", err.Error()))
+		return false
+	}
+	for _, scenario := range scenarios {
+		err = writeScenario(logger, *outDir, scenario.Name, scenario.TestSpec, scenario.Scenario)
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// lint validates a scenario, or a fragment, without executing it and prints its issues.
+// It returns whether the scenario was read and has no issues other than warnings.
+func lint(logger *slog.Logger, args []string) bool {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	fileName := flags.String("file", "", "the file name of the test scenario")
+	fragment := flags.Bool("fragment", false, "lint the file as a fragment of reusable steps")
+	_ = flags.Parse(args)
+	if *fileName == "" {
+		logger.Error("file flag is required, provide as --file <file.yaml>")
+		return false
+	}
+
+	data, err := os.ReadFile(*fileName)
+	if err != nil {
+		logger.Error("unable to read test scenario", slog.String("error", err.Error()))
+		return false
+	}
+	issues := yaml.Lint(data)
+	if *fragment {
+		issues = yaml.LintFragment(data)
+	}
+	for _, issue := range issues {
+		fmt.Printf("%s:%s\n", *fileName, issue)
+	}
+	return len(yaml.Errors(issues)) == 0
+}
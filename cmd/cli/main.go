@@ -1,10 +1,56 @@
 // Package main is the entrypoint for the CLI executing test scenarios.
+//
+// The generate subcommand generates test scenarios from an OpenAPI 3 document:
+//
+//	cli generate --openapi api-spec.yml --out scenarios --group tag
+//
+// The import subcommand converts a HAR file or a Postman collection to test scenarios:
+//
+//	cli import --format postman --file collection.json --out scenarios
+//
+// The curl subcommand converts a curl command line, read from the arguments or stdin, to a
+// step and, with --export, prints the curl command lines of the steps of a scenario, which is
+// read as it is played, optionally with the variables of an environment:
+//
+//	cli curl --name create_project curl -X POST http://localhost:3000/v1/projects -d '{"name": "p"}'
+//	cli curl --export --file scenario.yaml --env staging
+//
+// The lint subcommand validates a scenario, or with --fragment a fragment, without executing it
+// and prints its issues with their line and column, it exits with status 1 if there are issues
+// other than warnings, e.g. variables which are not declared but may be provided by an environment.
+// All subcommands exit with status 1 if they fail:
+//
+//	cli lint --file scenario.yaml
+//
+// The schema subcommand prints the JSON Schema of the YAML format of scenarios, which editors use
+// for completion and validation, e.g. with # yaml-language-server: $schema=scenario-schema.json:
+//
+//	cli schema > scenario-schema.json
+//
+// Without subcommand, the scenario of --file is executed, the CLI exits with status 1 if the
+// scenario fails or can not be executed. With --env, the variables of the scenario are overridden
+// by the variables of an environment of the environments file:
+//
+//	cli --file scenario.yaml --env staging --environments environments.yaml
+//
+// With --openapi, every request and response is validated against an OpenAPI 3 document,
+// unless the scenario references another document with openapi:
+//
+//	cli --file scenario.yaml --openapi api-spec.yml
+//
+// Resources of the scenario, such as datasets and the fragments of its use steps, are read
+// from files relative to the directory of the scenario, e.g. use: login.yaml.
 package main
 
 import (
+	"context"
 	"flag"
+	"io"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"github.com/inquiryproj/inquiry/internal/executor"
 )
@@ -14,12 +60,42 @@ func main() {
 		Level: slog.LevelInfo,
 	}))
 
+	if !runCommand(logger) {
+		os.Exit(1)
+	}
+}
+
+// runCommand runs the subcommand of the arguments, or else executes a scenario.
+// It returns whether the subcommand succeeded, or the scenario was executed successfully.
+func runCommand(logger *slog.Logger) bool {
+	subcommands := map[string]func(args []string) bool{
+		"generate": func(args []string) bool { return generate(logger, args) },
+		"import":   func(args []string) bool { return importScenarios(logger, args) },
+		"lint":     func(args []string) bool { return lint(logger, args) },
+		"schema":   func([]string) bool { return schema(logger) },
+		"curl":     func(args []string) bool { return curlCommand(logger, args) },
+	}
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			return subcommand(os.Args[2:])
+		}
+	}
+	return run(logger)
+}
+
+// run executes the scenario of the flags, it returns whether the scenario was executed successfully.
+func run(logger *slog.Logger) bool {
 	wordPtr := flag.String("file", "", "the file name of your test scenario")
 	v := flag.Bool("v", false, "verbose logging")
+	printCurl := flag.Bool("curl", false, "print the curl command lines of the requests of failing steps")
+	clientFile := flag.String("client", "", "the file name of a YAML client configuration used as defaults, e.g. TLS settings")
+	environment := flag.String("env", "", "the name of the environment of the environments file to run the scenario in")
+	environmentsFile := flag.String("environments", "", "the file name of the environments, defaults to environments.yaml next to the scenario")
+	openAPIFile := flag.String("openapi", "", "the file name of an OpenAPI 3 document the requests are validated against, unless the scenario references one")
 	flag.Parse()
 	if *wordPtr == "" {
 		logger.Error("file flag is required, provide as --flag <file.yaml>")
-		return
+		return false
 	}
 	if *v {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
@@ -28,24 +104,75 @@ func main() {
 	}
 
 	scenarioName := *wordPtr
-	f, err := os.Open(scenarioName)
+	var curlWriter io.Writer
+	if *printCurl {
+		curlWriter = os.Stderr
+	}
+	opts, err := executorOptions(logger, scenarioName, curlWriter, *clientFile)
+	if err != nil {
+		logger.Error("unable to open file", slog.String("error", err.Error()))
+		return false
+	}
+	variables, err := environmentVariables(scenarioName, *environment, *environmentsFile)
 	if err != nil {
-		logger.Error("unable to open file", err)
-		return
+		logger.Error("unable to read environment", slog.String("error", err.Error()))
+		return false
 	}
+	if *openAPIFile != "" {
+		contract, err := os.ReadFile(*openAPIFile)
+		if err != nil {
+			logger.Error("unable to read OpenAPI document", slog.String("error", err.Error()))
+			return false
+		}
+		opts = append(opts, executor.WithContract(*openAPIFile, contract))
+	}
+	return play(logger, scenarioName, append(opts, executor.WithVariables(variables)))
+}
 
-	executorApp, err := executor.New(scenarioName,
-		executor.WithReader(f),
-		executor.WithLogger(logger),
-	)
+// play executes the scenario until it completes or the process is interrupted.
+// It returns whether the scenario was executed successfully.
+func play(logger *slog.Logger, scenarioName string, opts []executor.Opts) bool {
+	executorApp, err := executor.New(scenarioName, opts...)
 	if err != nil {
-		logger.Error("unable to create test scenario executor", err)
-		return
+		logger.Error("unable to create test scenario executor", slog.String("error", err.Error()))
+		return false
 	}
-	_, err = executorApp.Play()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result, err := executorApp.Play(ctx)
 	if err != nil {
-		logger.Error(err.Error())
-		return
+		logger.Error("unable to execute test scenario", slog.String("error", err.Error()))
+		return false
+	}
+	if !result.Success {
+		logger.Error("scenario failed", slog.Bool("timed_out", result.TimedOut))
+		return false
 	}
 	logger.Info("scenario executed successfully")
+	return true
+}
+
+// executorOptions returns the options of the executor for the scenario file and the client configuration file.
+func executorOptions(logger *slog.Logger, scenarioName string, curlWriter io.Writer, clientFile string) ([]executor.Opts, error) {
+	f, err := os.Open(scenarioName)
+	if err != nil {
+		return nil, err
+	}
+	opts := []executor.Opts{
+		executor.WithReader(f),
+		executor.WithLogger(logger),
+		executor.WithResourceReader(executor.NewFileResourceReader(filepath.Dir(scenarioName))),
+		executor.WithEnvLookup(os.LookupEnv),
+	}
+	if curlWriter != nil {
+		opts = append(opts, executor.WithCurlWriter(curlWriter))
+	}
+	if clientFile == "" {
+		return opts, nil
+	}
+	clientDefaults, err := os.ReadFile(clientFile)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, executor.WithClientDefaults(clientDefaults)), nil
 }
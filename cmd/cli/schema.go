@@ -0,0 +1,21 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// schema prints the JSON Schema of the YAML format of scenarios, it returns whether the schema was printed.
+func schema(logger *slog.Logger) bool {
+	b, err := yaml.JSONSchema()
+	if err == nil {
+		_, err = os.Stdout.Write(b)
+	}
+	if err != nil {
+		logger.Error("unable to generate JSON Schema", slog.String("error", err.Error()))
+		return false
+	}
+	return true
+}
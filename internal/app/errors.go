@@ -13,3 +13,24 @@ var ErrScenarioAlreadyExists = fmt.Errorf("scenario already exists")
 
 // ErrInvalidScenarioSpecType is returned when an invalid scenario spec type is provided.
 var ErrInvalidScenarioSpecType = fmt.Errorf("invalid scenario spec type")
+
+// ErrInvalidScenarioSpec is returned when the spec of a scenario is invalid.
+var ErrInvalidScenarioSpec = fmt.Errorf("invalid scenario spec")
+
+// ErrInvalidImport is returned when imported data can not be converted to scenarios.
+var ErrInvalidImport = fmt.Errorf("invalid import")
+
+// ErrEnvironmentAlreadyExists is returned when an environment already exists.
+var ErrEnvironmentAlreadyExists = fmt.Errorf("environment already exists")
+
+// ErrEnvironmentNotFound is returned when an environment is not found.
+var ErrEnvironmentNotFound = fmt.Errorf("environment not found")
+
+// ErrSecretAlreadyExists is returned when a secret already exists.
+var ErrSecretAlreadyExists = fmt.Errorf("secret already exists")
+
+// ErrSecretNotFound is returned when a secret is not found.
+var ErrSecretNotFound = fmt.Errorf("secret not found")
+
+// ErrSecretsDisabled is returned when secrets are used without a master key being configured.
+var ErrSecretsDisabled = fmt.Errorf("secrets are disabled")
@@ -8,13 +8,17 @@ import (
 )
 
 // RunProjectRequest requests model for running a project.
+// Environment is the name of the environment of the project to run the scenarios in, if any.
 type RunProjectRequest struct {
-	ProjectID uuid.UUID
+	ProjectID   uuid.UUID
+	Environment string
 }
 
 // RunProjectByNameRequest requests model for running a project for a given name.
+// Environment is the name of the environment of the project to run the scenarios in, if any.
 type RunProjectByNameRequest struct {
 	ProjectName string
+	Environment string
 }
 
 // RunState is the state of a run.
@@ -35,27 +39,56 @@ type ProjectRunOutput struct {
 	ProjectID          uuid.UUID
 	Success            bool
 	State              RunState
+	Environment        string
 	ScenarioRunDetails []*ScenarioRunDetails
 }
 
 // ScenarioRunDetails is the output of a scenario run.
 type ScenarioRunDetails struct {
-	Name       string
-	Duration   time.Duration
-	Assertions int
-	Steps      []*StepRunDetails
-	Success    bool
+	Name             string
+	Duration         time.Duration
+	Assertions       int
+	AssertionResults []*AssertionResult
+	SetupSteps       []*StepRunDetails
+	Steps            []*StepRunDetails
+	TeardownSteps    []*StepRunDetails
+	SubScenarios     []*ScenarioRunDetails
+	TimedOut         bool
+	Success          bool
 }
 
 // StepRunDetails is the output of a step run.
 type StepRunDetails struct {
-	Name            string
-	Assertions      int
-	URL             string
+	Name             string
+	Assertions       int
+	AssertionResults []*AssertionResult
+	URL              string
+	RequestDuration  time.Duration
+	Duration         time.Duration
+	Retries          int
+	Attempts         []*AttemptResult
+	Skipped          bool
+	Success          bool
+}
+
+// AssertionResult is the outcome of a single assertion of a step run.
+type AssertionResult struct {
+	Type      string
+	Key       string
+	Assertion string
+	Expected  string
+	Actual    string
+	Success   bool
+	Message   string
+}
+
+// AttemptResult is the outcome of a single attempt of a step run.
+type AttemptResult struct {
+	Status          int
 	RequestDuration time.Duration
-	Duration        time.Duration
-	Retries         int
+	Delay           time.Duration
 	Success         bool
+	Error           string
 }
 
 // ListRunsForProjectRequest requests model for getting runs for a project.
@@ -13,16 +13,36 @@ func (s ScenarioSpecType) String() string {
 // ScenarioSpecType constants.
 const (
 	ScenarioSpecTypeYAML ScenarioSpecType = "yaml"
+	ScenarioSpecTypeCSV  ScenarioSpecType = "csv"
+	ScenarioSpecTypeJSON ScenarioSpecType = "json"
+	// ScenarioSpecTypeOpenAPI is an OpenAPI 3 document in either YAML or JSON format. The only
+	// OpenAPI document of a project is the contract of the scenarios which do not reference one.
+	ScenarioSpecTypeOpenAPI ScenarioSpecType = "openapi"
+	// ScenarioSpecTypeAttachment is a file attached to requests, such as a file of a multipart body.
+	ScenarioSpecTypeAttachment ScenarioSpecType = "attachment"
+	// ScenarioSpecTypeClient is the YAML client configuration of a project, such as TLS and redirects,
+	// which is used for the settings the client configuration of a scenario does not set.
+	ScenarioSpecTypeClient ScenarioSpecType = "client"
+	// ScenarioSpecTypeFragment is a YAML fragment of reusable steps, which scenarios include with use steps.
+	ScenarioSpecTypeFragment ScenarioSpecType = "fragment"
 )
 
 // ScenarioSpecTypeFromString returns the scenario spec type from string or an error if unknown.
 func ScenarioSpecTypeFromString(s string) (ScenarioSpecType, error) {
-	switch s {
-	case "yaml":
-		return ScenarioSpecTypeYAML, nil
-	default:
-		return "", ErrInvalidScenarioSpecType
+	for _, specType := range []ScenarioSpecType{
+		ScenarioSpecTypeYAML,
+		ScenarioSpecTypeCSV,
+		ScenarioSpecTypeJSON,
+		ScenarioSpecTypeOpenAPI,
+		ScenarioSpecTypeAttachment,
+		ScenarioSpecTypeClient,
+		ScenarioSpecTypeFragment,
+	} {
+		if s == string(specType) {
+			return specType, nil
+		}
 	}
+	return "", ErrInvalidScenarioSpecType
 }
 
 // Scenario is the scenario domain model.
@@ -42,6 +62,40 @@ type CreateScenarioRequest struct {
 	ProjectID uuid.UUID
 }
 
+// ImportFormat is the format of imported scenarios.
+type ImportFormat string
+
+// ImportFormat constants.
+const (
+	// ImportFormatHAR is a HAR file, as exported by the developer tools of browsers.
+	ImportFormatHAR ImportFormat = "har"
+	// ImportFormatPostman is a Postman collection (v2.1).
+	ImportFormatPostman ImportFormat = "postman"
+)
+
+// ImportScenariosRequest requests model for importing the requests of a HAR file or
+// a Postman collection as yaml scenarios of a project.
+type ImportScenariosRequest struct {
+	ProjectID uuid.UUID
+	Format    ImportFormat
+	Data      []byte
+}
+
+// ValidateScenarioRequest requests model for validating the spec of a scenario.
+type ValidateScenarioRequest struct {
+	SpecType ScenarioSpecType
+	Spec     string
+}
+
+// ScenarioIssue is an issue of the spec of a scenario at a position of its YAML document.
+// Column is 0 if the issue applies to the whole line. Warnings do not prevent creating the scenario.
+type ScenarioIssue struct {
+	Line    int
+	Column  int
+	Message string
+	Warning bool
+}
+
 // GetScenariosForProjectRequest requests model for retrieving scenarios for a project.
 type GetScenariosForProjectRequest struct {
 	ProjectID uuid.UUID
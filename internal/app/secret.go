@@ -0,0 +1,37 @@
+package app
+
+import "github.com/google/uuid"
+
+// Secret is the secret domain model, the value of a secret is never returned.
+type Secret struct {
+	ID        uuid.UUID
+	Name      string
+	ProjectID uuid.UUID
+}
+
+// CreateSecretRequest requests model for creating a secret.
+type CreateSecretRequest struct {
+	Name      string
+	Value     string
+	ProjectID uuid.UUID
+}
+
+// UpdateSecretRequest requests model for updating the value of a secret.
+type UpdateSecretRequest struct {
+	Name      string
+	Value     string
+	ProjectID uuid.UUID
+}
+
+// DeleteSecretRequest requests model for deleting a secret.
+type DeleteSecretRequest struct {
+	Name      string
+	ProjectID uuid.UUID
+}
+
+// ListSecretsRequest requests model for retrieving secrets for a given project.
+type ListSecretsRequest struct {
+	Limit     int
+	Offset    int
+	ProjectID uuid.UUID
+}
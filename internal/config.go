@@ -33,6 +33,8 @@ type Config struct {
 
 	RepositoryConfig RepositoryConfig
 	ServerConfig     ServerConfig
+	RunsConfig       RunsConfig
+	SecretsConfig    SecretsConfig
 
 	NotifiersConfig NotifiersConfig
 }
@@ -64,6 +66,18 @@ type ServerConfig struct {
 	APIKey        string        `env:"API_KEY" envDefault:""`
 }
 
+// RunsConfig is the configuration for processing runs.
+type RunsConfig struct {
+	RunTimeout time.Duration `env:"RUN_TIMEOUT" envDefault:"30m"`
+}
+
+// SecretsConfig is the configuration for the secrets of projects.
+type SecretsConfig struct {
+	// MasterKey is the key the values of secrets are encrypted with, it must be
+	// 16, 24 or 32 bytes long. Secrets are disabled if no master key is provided.
+	MasterKey string `env:"SECRETS_MASTER_KEY" envDefault:""`
+}
+
 // NotifiersConfig is the configuration for the notifiers.
 type NotifiersConfig struct {
 	SlackConfig SlackConfig
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -15,30 +16,101 @@ import (
 	"github.com/inquiryproj/inquiry/internal/executor/http"
 	"github.com/inquiryproj/inquiry/internal/repository"
 	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
 )
 
+// scenarioPageSize is the number of scenarios fetched at once when reading all scenarios of a project.
+const scenarioPageSize = 100
+
 // Processor processes runs.
 type Processor interface {
 	// Process processes a run.
 	Process(runID uuid.UUID) (uuid.UUID, error)
 }
 
+// ProcessorOptions represents the options for the run processor.
+type ProcessorOptions struct {
+	// Context is the parent context of all runs, runs which are in flight
+	// are cancelled once the context is done.
+	Context context.Context
+	// RunTimeout is the maximum duration of a run, scenarios which are
+	// in flight once it is exceeded are marked as timed out.
+	RunTimeout time.Duration
+	// SecretsCipher decrypts the secrets of projects, runs of projects
+	// with secrets fail if it is nil.
+	SecretsCipher crypto.AESCipher
+}
+
+func defaultProcessorOptions() *ProcessorOptions {
+	return &ProcessorOptions{
+		Context:    context.Background(),
+		RunTimeout: 30 * time.Minute,
+	}
+}
+
+// ProcessorOpts represents a function that modifies the processor options.
+type ProcessorOpts func(*ProcessorOptions)
+
+// WithContext sets the parent context of all runs.
+func WithContext(ctx context.Context) ProcessorOpts {
+	return func(o *ProcessorOptions) {
+		o.Context = ctx
+	}
+}
+
+// WithRunTimeout sets the maximum duration of a run.
+func WithRunTimeout(timeout time.Duration) ProcessorOpts {
+	return func(o *ProcessorOptions) {
+		o.RunTimeout = timeout
+	}
+}
+
+// WithSecretsCipher sets the cipher the secrets of projects are decrypted with.
+func WithSecretsCipher(secretsCipher crypto.AESCipher) ProcessorOpts {
+	return func(o *ProcessorOptions) {
+		o.SecretsCipher = secretsCipher
+	}
+}
+
 type processor struct {
 	completionsProducer events.Producer[uuid.UUID]
 
-	scenarioRepository repository.Scenario
-	runRepository      repository.Run
+	scenarioRepository    repository.Scenario
+	environmentRepository repository.Environment
+	secretRepository      repository.Secret
+	runRepository         repository.Run
+
+	ctx           context.Context
+	runTimeout    time.Duration
+	secretsCipher crypto.AESCipher
 
 	logger *slog.Logger
 }
 
 // NewProcessor creates a new run processor.
-func NewProcessor(completionsProducer events.Producer[uuid.UUID], scenarioRepository repository.Scenario, runRepository repository.Run) Processor {
+func NewProcessor(
+	completionsProducer events.Producer[uuid.UUID],
+	scenarioRepository repository.Scenario,
+	environmentRepository repository.Environment,
+	secretRepository repository.Secret,
+	runRepository repository.Run,
+	opts ...ProcessorOpts,
+) Processor {
+	options := defaultProcessorOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
 	return &processor{
 		completionsProducer: completionsProducer,
 
-		scenarioRepository: scenarioRepository,
-		runRepository:      runRepository,
+		scenarioRepository:    scenarioRepository,
+		environmentRepository: environmentRepository,
+		secretRepository:      secretRepository,
+		runRepository:         runRepository,
+
+		ctx:           options.Context,
+		runTimeout:    options.RunTimeout,
+		secretsCipher: options.SecretsCipher,
 
 		logger: slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{})),
 	}
@@ -57,7 +129,9 @@ func (p *processor) Process(runID uuid.UUID) (uuid.UUID, error) {
 
 	p.logger.Info("processing project", slog.String("project_id", run.ProjectID.String()), slog.String("run_id", runID.String()))
 
-	scenarioResults, err := p.processProject(ctx, run.ProjectID)
+	runCtx, cancel := context.WithTimeout(p.ctx, p.runTimeout)
+	defer cancel()
+	scenarioResults, err := p.processProject(runCtx, run)
 	if err != nil {
 		p.logger.Error("project failed", slog.String("project_id", run.ProjectID.String()), slog.String("run_id", runID.String()), slog.String("error", err.Error()))
 
@@ -80,7 +154,7 @@ func (p *processor) Process(runID uuid.UUID) (uuid.UUID, error) {
 
 	_, err = p.runRepository.Update(ctx, &domain.UpdateRunRequest{
 		ID:                 runID,
-		State:              domain.RunStateCompleted,
+		State:              p.completedRunState(),
 		Success:            success,
 		ScenarioRunDetails: executeResultsToScenarioRunDetails(scenarioResults),
 	})
@@ -93,6 +167,15 @@ func (p *processor) Process(runID uuid.UUID) (uuid.UUID, error) {
 	return runID, err
 }
 
+// completedRunState returns the state of a run of which all scenarios are processed.
+// Runs are cancelled if the processor is shut down while they are in flight.
+func (p *processor) completedRunState() domain.RunState {
+	if p.ctx.Err() != nil {
+		return domain.RunstateCancelled
+	}
+	return domain.RunStateCompleted
+}
+
 func executeResultsToScenarioRunDetails(executeResults []*http.ExecuteResult) []*domain.ScenarioRunDetails {
 	scenarioRunDetails := []*domain.ScenarioRunDetails{}
 	for _, executeResult := range executeResults {
@@ -103,11 +186,16 @@ func executeResultsToScenarioRunDetails(executeResults []*http.ExecuteResult) []
 
 func executeResultToScenarioRunDetails(executeResult *http.ExecuteResult) *domain.ScenarioRunDetails {
 	return &domain.ScenarioRunDetails{
-		Name:       executeResult.Name,
-		Duration:   executeResult.TotalExecutionTime,
-		Assertions: executeResult.TotalAssertions,
-		Steps:      executeStepResultsToStepRunDetails(executeResult.StepResults),
-		Success:    executeResult.Success,
+		Name:             executeResult.Name,
+		Duration:         executeResult.TotalExecutionTime,
+		Assertions:       executeResult.TotalAssertions,
+		AssertionResults: assertionResultsToDomainAssertionResults(executeResult.AssertionResults),
+		SetupSteps:       executeStepResultsToStepRunDetails(executeResult.SetupResults),
+		Steps:            executeStepResultsToStepRunDetails(executeResult.StepResults),
+		TeardownSteps:    executeStepResultsToStepRunDetails(executeResult.TeardownResults),
+		SubScenarios:     executeResultsToScenarioRunDetails(executeResult.SubResults),
+		TimedOut:         executeResult.TimedOut,
+		Success:          executeResult.Success,
 	}
 }
 
@@ -121,42 +209,236 @@ func executeStepResultsToStepRunDetails(executeStepResult []*http.ExecuteStepRes
 
 func executeStepResultToStepRunDetails(executeStepResult *http.ExecuteStepResult) *domain.StepRunDetails {
 	return &domain.StepRunDetails{
-		Name:            executeStepResult.Name,
-		Assertions:      executeStepResult.Assertions,
-		URL:             executeStepResult.URL,
-		RequestDuration: executeStepResult.RequestDuration,
-		Duration:        executeStepResult.Duration,
-		Retries:         executeStepResult.Retries,
-		Success:         executeStepResult.Success,
+		Name:             executeStepResult.Name,
+		Assertions:       executeStepResult.Assertions,
+		AssertionResults: assertionResultsToDomainAssertionResults(executeStepResult.AssertionResults),
+		URL:              executeStepResult.URL,
+		RequestDuration:  executeStepResult.RequestDuration,
+		Duration:         executeStepResult.Duration,
+		Retries:          executeStepResult.Retries,
+		Attempts:         attemptResultsToDomainAttemptResults(executeStepResult.Attempts),
+		Skipped:          executeStepResult.Skipped,
+		Success:          executeStepResult.Success,
 	}
 }
 
-func (p *processor) processProject(ctx context.Context, projectID uuid.UUID) ([]*http.ExecuteResult, error) {
-	scenarios, err := p.scenarioRepository.GetForProject(ctx, &domain.GetScenariosForProjectRequest{
-		ProjectID: projectID,
-	})
+func attemptResultsToDomainAttemptResults(attemptResults []*http.AttemptResult) []*domain.AttemptResult {
+	result := []*domain.AttemptResult{}
+	for _, attemptResult := range attemptResults {
+		result = append(result, &domain.AttemptResult{
+			Status:          attemptResult.Status,
+			RequestDuration: attemptResult.RequestDuration,
+			Delay:           attemptResult.Delay,
+			Success:         attemptResult.Success,
+			Error:           attemptResult.Error,
+		})
+	}
+	return result
+}
+
+func assertionResultsToDomainAssertionResults(assertionResults []*http.AssertionResult) []*domain.AssertionResult {
+	result := []*domain.AssertionResult{}
+	for _, assertionResult := range assertionResults {
+		result = append(result, &domain.AssertionResult{
+			Type:      string(assertionResult.Type),
+			Key:       assertionResult.Key,
+			Assertion: string(assertionResult.Assertion),
+			Expected:  assertionResult.Expected,
+			Actual:    assertionResult.Actual,
+			Success:   assertionResult.Success,
+			Message:   assertionResult.Message,
+		})
+	}
+	return result
+}
+
+func (p *processor) processProject(ctx context.Context, run *domain.Run) ([]*http.ExecuteResult, error) {
+	scenarios, err := p.projectScenarios(ctx, run.ProjectID)
 	if err != nil {
 		return nil, err
 	}
 	scenarioResults := []*http.ExecuteResult{}
+	opts, redactor, err := p.executorOptions(ctx, run, scenarios)
+	if err != nil {
+		return nil, err
+	}
 	for _, scenario := range scenarios {
-		p.logger.Info("processing scenario", slog.String("scenario_id", scenario.ID.String()))
-		b, err := base64.StdEncoding.DecodeString(scenario.Spec)
+		// csv, json, openapi, attachment and fragment scenarios are resources of yaml scenarios, such as datasets,
+		// the client scenario configures the HTTP client of the yaml scenarios.
+		if scenario.SpecType != domain.ScenarioSpecTypeYAML {
+			continue
+		}
+		if ctx.Err() != nil {
+			p.logger.Warn("run timed out, skipping scenario", slog.String("scenario_id", scenario.ID.String()))
+			scenarioResults = append(scenarioResults, timedOutExecuteResult(scenario.Name))
+			continue
+		}
+		executeResult, err := p.processScenario(ctx, scenario, opts...)
 		if err != nil {
-			return nil, err
+			return nil, redactor.redactError(fmt.Errorf("scenario %s failed: %w", scenario.Name, err))
 		}
-		runExecutor, err := executor.New(scenario.Name,
-			executor.WithReader(bytes.NewBuffer(b)),
-			executor.WithLogger(p.logger))
+		redactor.redactExecuteResult(executeResult)
+		scenarioResults = append(scenarioResults, executeResult)
+	}
+
+	return scenarioResults, nil
+}
+
+// projectScenarios returns all scenarios of a project, including the resources
+// of its yaml scenarios, which are paged through as a run needs all of them.
+func (p *processor) projectScenarios(ctx context.Context, projectID uuid.UUID) ([]*domain.Scenario, error) {
+	result := []*domain.Scenario{}
+	for page := 0; ; page++ {
+		scenarios, err := p.scenarioRepository.GetForProject(ctx, &domain.GetScenariosForProjectRequest{
+			Limit:     scenarioPageSize,
+			Offset:    page,
+			ProjectID: projectID,
+		})
 		if err != nil {
 			return nil, err
 		}
-		executeResult, err := runExecutor.Play()
+		result = append(result, scenarios...)
+		if len(scenarios) < scenarioPageSize {
+			return result, nil
+		}
+	}
+}
+
+// executorOptions returns the options of the executors of the scenarios of a run, which read
+// resources from the scenarios of the project and use its client configuration, environment
+// and secrets, as well as the redactor of the secrets.
+func (p *processor) executorOptions(ctx context.Context, run *domain.Run, scenarios []*domain.Scenario) ([]executor.Opts, *redactor, error) {
+	clientDefaults, err := projectClientDefaults(scenarios)
+	if err != nil {
+		return nil, nil, err
+	}
+	variables, err := p.environmentVariables(ctx, run)
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets, err := p.projectSecrets(ctx, run)
+	if err != nil {
+		return nil, nil, err
+	}
+	redactor := newRedactor(secrets)
+	opts := []executor.Opts{
+		executor.WithResourceReader(scenarioResourceReader(scenarios)),
+		executor.WithClientDefaults(clientDefaults),
+		executor.WithVariables(variables),
+		executor.WithSecrets(secrets),
+		executor.WithLogger(redactor.logger(p.logger)),
+	}
+	if contract := projectContract(scenarios); contract != nil {
+		data, err := base64.StdEncoding.DecodeString(contract.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, executor.WithContract(contract.Name, data))
+	}
+	return opts, redactor, nil
+}
+
+func (p *processor) processScenario(ctx context.Context, scenario *domain.Scenario, opts ...executor.Opts) (*http.ExecuteResult, error) {
+	p.logger.Info("processing scenario", slog.String("scenario_id", scenario.ID.String()))
+	b, err := base64.StdEncoding.DecodeString(scenario.Spec)
+	if err != nil {
+		return nil, err
+	}
+	runExecutor, err := executor.New(scenario.Name,
+		append([]executor.Opts{
+			executor.WithReader(bytes.NewBuffer(b)),
+		}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return runExecutor.Play(ctx)
+}
+
+func timedOutExecuteResult(name string) *http.ExecuteResult {
+	return &http.ExecuteResult{
+		Name:             name,
+		AssertionResults: []*http.AssertionResult{},
+		SetupResults:     []*http.ExecuteStepResult{},
+		StepResults:      []*http.ExecuteStepResult{},
+		TeardownResults:  []*http.ExecuteStepResult{},
+		SubResults:       []*http.ExecuteResult{},
+		TimedOut:         true,
+	}
+}
+
+// environmentVariables returns the variables of the environment of the run, or nil if the run has no environment.
+func (p *processor) environmentVariables(ctx context.Context, run *domain.Run) (map[string]string, error) {
+	if run.Environment == "" {
+		return nil, nil
+	}
+	environment, err := p.environmentRepository.GetByName(ctx, &domain.GetEnvironmentByNameRequest{
+		Name:      run.Environment,
+		ProjectID: run.ProjectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get environment %s: %w", run.Environment, err)
+	}
+	return environment.Variables, nil
+}
+
+// projectSecrets returns the decrypted secrets of the project of the run.
+func (p *processor) projectSecrets(ctx context.Context, run *domain.Run) (map[string]string, error) {
+	secrets, err := p.secretRepository.GetAllForProject(ctx, run.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) > 0 && p.secretsCipher == nil {
+		return nil, fmt.Errorf("unable to decrypt secrets of project, no master key configured")
+	}
+	result := map[string]string{}
+	for _, secret := range secrets {
+		value, err := p.secretsCipher.Decrypt(secret.EncryptedValue)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("unable to decrypt secret %s: %w", secret.Name, err)
 		}
-		scenarioResults = append(scenarioResults, executeResult)
+		result[secret.Name] = value
 	}
+	return result, nil
+}
 
-	return scenarioResults, nil
+// projectClientDefaults returns the client configuration of the project, which is the spec
+// of its client scenario, or nil if the project has no client scenario.
+func projectClientDefaults(scenarios []*domain.Scenario) ([]byte, error) {
+	for _, scenario := range scenarios {
+		if scenario.SpecType == domain.ScenarioSpecTypeClient {
+			return base64.StdEncoding.DecodeString(scenario.Spec)
+		}
+	}
+	return nil, nil
+}
+
+// projectContract returns the OpenAPI document of the project, which is the contract of the
+// scenarios of the project, or nil if the project has none or more than one OpenAPI document.
+// Scenarios reference the document they are validated against with openapi in the latter case.
+func projectContract(scenarios []*domain.Scenario) *domain.Scenario {
+	var contract *domain.Scenario
+	for _, scenario := range scenarios {
+		if scenario.SpecType != domain.ScenarioSpecTypeOpenAPI {
+			continue
+		}
+		if contract != nil {
+			return nil
+		}
+		contract = scenario
+	}
+	return contract
+}
+
+// scenarioResourceReader reads the resources referenced by scenarios, such as
+// datasets, JSON Schemas, OpenAPI documents, attachments and fragments, from the
+// csv, json, openapi, attachment and fragment scenarios of the project.
+func scenarioResourceReader(scenarios []*domain.Scenario) executor.ResourceReader {
+	return func(name string) ([]byte, error) {
+		for _, scenario := range scenarios {
+			if scenario.Name == name && scenario.SpecType != domain.ScenarioSpecTypeYAML {
+				return base64.StdEncoding.DecodeString(scenario.Spec)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", executor.ErrResourceNotFound, name)
+	}
 }
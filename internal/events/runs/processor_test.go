@@ -0,0 +1,124 @@
+package runs
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	eventsMocks "github.com/inquiryproj/inquiry/internal/events/mocks"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	repositoryMocks "github.com/inquiryproj/inquiry/internal/repository/mocks"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
+)
+
+func scenarioPage(projectID uuid.UUID, page int) *domain.GetScenariosForProjectRequest {
+	return &domain.GetScenariosForProjectRequest{Limit: scenarioPageSize, Offset: page, ProjectID: projectID}
+}
+
+// TestProcessProjectPages checks that the resources of scenarios are found on any page of the
+// scenarios of the project, here the dataset of a matrix is on the second page.
+func TestProcessProjectPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	projectID := uuid.New()
+	spec := fmt.Sprintf(`version: v1
+type: http
+matrix:
+  dataset: users.csv
+steps:
+  - name: user
+    request:
+      method: GET
+      url: %s/users/${variables.id}
+    validation:
+      status:
+        assertion: equal
+        value: "200"
+`, server.URL)
+	firstPage := []*domain.Scenario{{ID: uuid.New(), Name: "users", SpecType: domain.ScenarioSpecTypeYAML, Spec: base64.StdEncoding.EncodeToString([]byte(spec))}}
+	for len(firstPage) < scenarioPageSize {
+		firstPage = append(firstPage, &domain.Scenario{ID: uuid.New(), Name: uuid.NewString(), SpecType: domain.ScenarioSpecTypeAttachment})
+	}
+	secondPage := []*domain.Scenario{{ID: uuid.New(), Name: "users.csv", SpecType: domain.ScenarioSpecTypeCSV, Spec: base64.StdEncoding.EncodeToString([]byte("id\n1\n2\n"))}}
+
+	scenarioRepositoryMock := repositoryMocks.NewScenario(t)
+	scenarioRepositoryMock.On("GetForProject", mock.Anything, scenarioPage(projectID, 0)).Return(firstPage, nil)
+	scenarioRepositoryMock.On("GetForProject", mock.Anything, scenarioPage(projectID, 1)).Return(secondPage, nil)
+	secretRepositoryMock := repositoryMocks.NewSecret(t)
+	secretRepositoryMock.On("GetAllForProject", mock.Anything, projectID).Return([]*domain.Secret{}, nil)
+
+	p := NewProcessor(
+		eventsMocks.NewProducer[uuid.UUID](t),
+		scenarioRepositoryMock,
+		repositoryMocks.NewEnvironment(t),
+		secretRepositoryMock,
+		repositoryMocks.NewRun(t),
+	).(*processor)
+	results, err := p.processProject(context.Background(), &domain.Run{ProjectID: projectID})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.True(t, results[0].Success)
+		assert.Len(t, results[0].SubResults, 2)
+	}
+}
+
+func TestProjectSecrets(t *testing.T) {
+	projectID := uuid.New()
+	encryptedValue, err := crypto.NewAESCipher("0123456789abcdef0123456789abcdef").Encrypt("s3cr3t")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		name            string
+		secretsCipher   crypto.AESCipher
+		expectedSecrets map[string]string
+		expectedErr     string
+	}{
+		{
+			name:            "decrypted with the master key",
+			secretsCipher:   crypto.NewAESCipher("0123456789abcdef0123456789abcdef"),
+			expectedSecrets: map[string]string{"token": "s3cr3t"},
+		},
+		{
+			name:          "wrong master key",
+			secretsCipher: crypto.NewAESCipher("fedcba9876543210fedcba9876543210"),
+			expectedErr:   "unable to decrypt secret token: cipher: message authentication failed",
+		},
+		{
+			name:        "no master key",
+			expectedErr: "unable to decrypt secrets of project, no master key configured",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretRepositoryMock := repositoryMocks.NewSecret(t)
+			secretRepositoryMock.On("GetAllForProject", mock.Anything, projectID).
+				Return([]*domain.Secret{{Name: "token", EncryptedValue: encryptedValue, ProjectID: projectID}}, nil)
+
+			p := NewProcessor(
+				eventsMocks.NewProducer[uuid.UUID](t),
+				repositoryMocks.NewScenario(t),
+				repositoryMocks.NewEnvironment(t),
+				secretRepositoryMock,
+				repositoryMocks.NewRun(t),
+				WithSecretsCipher(tt.secretsCipher),
+			).(*processor)
+			secrets, err := p.projectSecrets(context.Background(), &domain.Run{ProjectID: projectID})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedSecrets, secrets)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package runs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/http"
+)
+
+const redacted = "[REDACTED]"
+
+// basicCredentials matches the credentials of basic authorization headers, which encode
+// the username and password as base64 of <username>:<password>.
+var basicCredentials = regexp.MustCompile(`(Basic )([A-Za-z0-9+/]+={0,2})`)
+
+// redactor replaces the values of the secrets of a project with a placeholder,
+// such that they are neither logged nor persisted with the results of a run.
+type redactor struct {
+	replacer *strings.Replacer
+}
+
+func newRedactor(secrets map[string]string) *redactor {
+	values := []string{}
+	for _, value := range secrets {
+		if value != "" {
+			values = append(values, encodings(value)...)
+		}
+	}
+	// longer values are replaced first, such that no part of a value remains if it contains another value.
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	oldnew := []string{}
+	for _, value := range values {
+		oldnew = append(oldnew, value, redacted)
+	}
+	return &redactor{
+		replacer: strings.NewReplacer(oldnew...),
+	}
+}
+
+// encodings returns the value of a secret and the forms it is encoded to in requests and responses.
+func encodings(value string) []string {
+	values := []string{value, base64.StdEncoding.EncodeToString([]byte(value))}
+	escaped, err := json.Marshal(value)
+	if err == nil && string(escaped[1:len(escaped)-1]) != value {
+		values = append(values, string(escaped[1:len(escaped)-1]))
+	}
+	return values
+}
+
+func (r *redactor) redact(s string) string {
+	return r.replacer.Replace(r.redactBasicCredentials(s))
+}
+
+// redactBasicCredentials redacts the credentials of basic authorization headers which contain a secret,
+// as the base64 encoding of <username>:<password> does not contain the encoding of the password.
+func (r *redactor) redactBasicCredentials(s string) string {
+	return basicCredentials.ReplaceAllStringFunc(s, func(match string) string {
+		credentials := basicCredentials.FindStringSubmatch(match)
+		decoded, err := base64.StdEncoding.DecodeString(credentials[2])
+		if err != nil || r.replacer.Replace(string(decoded)) == string(decoded) {
+			return match
+		}
+		return credentials[1] + redacted
+	})
+}
+
+func (r *redactor) redactError(err error) error {
+	redactedMessage := r.redact(err.Error())
+	if redactedMessage == err.Error() {
+		return err
+	}
+	return errors.New(redactedMessage)
+}
+
+// redactExecuteResult redacts the step URLs, assertions and attempt errors of the result of a scenario.
+func (r *redactor) redactExecuteResult(executeResult *http.ExecuteResult) {
+	r.redactAssertionResults(executeResult.AssertionResults)
+	for _, stepResults := range [][]*http.ExecuteStepResult{executeResult.SetupResults, executeResult.StepResults, executeResult.TeardownResults} {
+		for _, stepResult := range stepResults {
+			stepResult.URL = r.redact(stepResult.URL)
+			r.redactAssertionResults(stepResult.AssertionResults)
+			for _, attempt := range stepResult.Attempts {
+				attempt.Error = r.redact(attempt.Error)
+			}
+		}
+	}
+	for _, subResult := range executeResult.SubResults {
+		r.redactExecuteResult(subResult)
+	}
+}
+
+func (r *redactor) redactAssertionResults(assertionResults []*http.AssertionResult) {
+	for _, assertionResult := range assertionResults {
+		assertionResult.Key = r.redact(assertionResult.Key)
+		assertionResult.Expected = r.redact(assertionResult.Expected)
+		assertionResult.Actual = r.redact(assertionResult.Actual)
+		assertionResult.Message = r.redact(assertionResult.Message)
+	}
+}
+
+func (r *redactor) redactAttr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, r.redact(value.String()))
+	case slog.KindAny:
+		return r.redactAny(attr.Key, value.Any(), attr)
+	case slog.KindGroup:
+		attrs := value.Group()
+		redactedAttrs := make([]any, len(attrs))
+		for i, groupAttr := range attrs {
+			redactedAttrs[i] = r.redactAttr(groupAttr)
+		}
+		return slog.Group(attr.Key, redactedAttrs...)
+	default:
+		return attr
+	}
+}
+
+// redactAny redacts errors and stringers, which are logged with their string representation.
+func (r *redactor) redactAny(key string, value any, attr slog.Attr) slog.Attr {
+	switch v := value.(type) {
+	case error:
+		return slog.String(key, r.redact(v.Error()))
+	case fmt.Stringer:
+		return slog.String(key, r.redact(v.String()))
+	default:
+		return attr
+	}
+}
+
+// logger returns a logger which redacts the records of the given logger.
+func (r *redactor) logger(logger *slog.Logger) *slog.Logger {
+	return slog.New(&redactingHandler{
+		Handler:  logger.Handler(),
+		redactor: r,
+	})
+}
+
+// redactingHandler is a log handler which redacts the messages and the string, error and stringer
+// attributes of records.
+type redactingHandler struct {
+	slog.Handler
+	redactor *redactor
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redactedRecord := slog.NewRecord(record.Time, record.Level, h.redactor.redact(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redactedRecord.AddAttrs(h.redactor.redactAttr(attr))
+		return true
+	})
+	return h.Handler.Handle(ctx, redactedRecord)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redactedAttrs[i] = h.redactor.redactAttr(attr)
+	}
+	return &redactingHandler{
+		Handler:  h.Handler.WithAttrs(redactedAttrs),
+		redactor: h.redactor,
+	}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{
+		Handler:  h.Handler.WithGroup(name),
+		redactor: h.redactor,
+	}
+}
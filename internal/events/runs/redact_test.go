@@ -0,0 +1,159 @@
+package runs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inquiryproj/inquiry/internal/executor/http"
+)
+
+func TestRedact(t *testing.T) {
+	r := newRedactor(map[string]string{
+		"token":    "s3cr3t",
+		"password": `pa"ss\word`,
+		"empty":    "",
+	})
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "value",
+			value:    "Bearer s3cr3t",
+			expected: "Bearer [REDACTED]",
+		},
+		{
+			name:     "base64",
+			value:    "token=" + base64.StdEncoding.EncodeToString([]byte("s3cr3t")),
+			expected: "token=[REDACTED]",
+		},
+		{
+			name:     "basic credentials",
+			value:    "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("admin:s3cr3t")),
+			expected: "Authorization: Basic [REDACTED]",
+		},
+		{
+			name:     "basic credentials without secret",
+			value:    "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("admin:public")),
+			expected: "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("admin:public")),
+		},
+		{
+			name:     "json escaped",
+			value:    `{"password": "pa\"ss\\word"}`,
+			expected: `{"password": "[REDACTED]"}`,
+		},
+		{
+			name:     "without secret",
+			value:    "nothing to hide",
+			expected: "nothing to hide",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.redact(tt.value))
+		})
+	}
+}
+
+func TestRedactLogger(t *testing.T) {
+	r := newRedactor(map[string]string{"token": "s3cr3t"})
+
+	tests := []struct {
+		name     string
+		log      func(logger *slog.Logger)
+		expected string
+	}{
+		{
+			name: "message",
+			log: func(logger *slog.Logger) {
+				logger.Info("token s3cr3t")
+			},
+			expected: `level=INFO msg="token [REDACTED]"`,
+		},
+		{
+			name: "string",
+			log: func(logger *slog.Logger) {
+				logger.Info("request", slog.String("url", "http://api?token=s3cr3t"))
+			},
+			expected: `level=INFO msg=request url="http://api?token=[REDACTED]"`,
+		},
+		{
+			name: "group",
+			log: func(logger *slog.Logger) {
+				logger.Info("request", slog.Group("headers", slog.String("authorization", "Bearer s3cr3t"), slog.Int("count", 1)))
+			},
+			expected: `level=INFO msg=request headers.authorization="Bearer [REDACTED]" headers.count=1`,
+		},
+		{
+			name: "error",
+			log: func(logger *slog.Logger) {
+				logger.Error("request failed", slog.Any("error", fmt.Errorf("invalid token s3cr3t")))
+			},
+			expected: `level=ERROR msg="request failed" error="invalid token [REDACTED]"`,
+		},
+		{
+			name: "stringer",
+			log: func(logger *slog.Logger) {
+				logger.Info("request", slog.Any("url", &url.URL{Scheme: "http", Host: "api", RawQuery: "token=s3cr3t"}))
+			},
+			expected: `level=INFO msg=request url="http://api?token=[REDACTED]"`,
+		},
+		{
+			name: "attributes of the logger",
+			log: func(logger *slog.Logger) {
+				logger.With(slog.Any("error", fmt.Errorf("invalid token s3cr3t"))).Info("request")
+			},
+			expected: `level=INFO msg=request error="invalid token [REDACTED]"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			tt.log(r.logger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey && len(groups) == 0 {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))))
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestRedactExecuteResult(t *testing.T) {
+	r := newRedactor(map[string]string{"token": "s3cr3t"})
+	executeResult := &http.ExecuteResult{
+		AssertionResults: []*http.AssertionResult{{Actual: "s3cr3t"}},
+		StepResults: []*http.ExecuteStepResult{{
+			URL:              "http://api?token=s3cr3t",
+			AssertionResults: []*http.AssertionResult{{Key: "s3cr3t", Expected: "s3cr3t", Message: "s3cr3t"}},
+			Attempts:         []*http.AttemptResult{{Error: "invalid token s3cr3t"}},
+		}},
+		SubResults: []*http.ExecuteResult{{
+			TeardownResults: []*http.ExecuteStepResult{{URL: "http://api?token=s3cr3t"}},
+		}},
+	}
+
+	r.redactExecuteResult(executeResult)
+	assert.Equal(t, &http.ExecuteResult{
+		AssertionResults: []*http.AssertionResult{{Actual: redacted}},
+		StepResults: []*http.ExecuteStepResult{{
+			URL:              "http://api?token=[REDACTED]",
+			AssertionResults: []*http.AssertionResult{{Key: redacted, Expected: redacted, Message: redacted}},
+			Attempts:         []*http.AttemptResult{{Error: "invalid token [REDACTED]"}},
+		}},
+		SubResults: []*http.ExecuteResult{{
+			TeardownResults: []*http.ExecuteStepResult{{URL: "http://api?token=[REDACTED]"}},
+		}},
+	}, executeResult)
+}
@@ -0,0 +1,132 @@
+// Package condition provides the expressions used to conditionally execute steps.
+//
+// An expression compares operands with ==, !=, >, >=, < and <=, and combines
+// comparisons with &&, || and !, e.g.:
+//
+//	${vars.feature_enabled} == true && ${vars.status} != 404
+//
+// Operands are either quoted strings or words, which may contain placeholders.
+// An operand without comparison is true unless it is empty, false, 0 or null.
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidExpression is returned when an expression can not be parsed or evaluated.
+var ErrInvalidExpression = fmt.Errorf("invalid expression")
+
+// Resolver resolves the value of an operand, e.g. by replacing placeholders.
+type Resolver func(operand string) (string, error)
+
+// Expression is a parsed conditional expression.
+type Expression interface {
+	// Evaluate evaluates the expression, every operand is resolved with the given resolver.
+	Evaluate(resolve Resolver) (bool, error)
+}
+
+// Parse parses an expression.
+func Parse(expression string) (Expression, error) {
+	tokens, err := lex(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrInvalidExpression)
+	}
+	p := &parser{tokens: tokens}
+	parsed, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %s", ErrInvalidExpression, p.tokens[p.pos].value)
+	}
+	return parsed, nil
+}
+
+type logical struct {
+	left, right Expression
+	or          bool
+}
+
+func (l *logical) Evaluate(resolve Resolver) (bool, error) {
+	left, err := l.left.Evaluate(resolve)
+	if err != nil || left == l.or {
+		return left, err
+	}
+	return l.right.Evaluate(resolve)
+}
+
+type not struct {
+	expression Expression
+}
+
+func (n *not) Evaluate(resolve Resolver) (bool, error) {
+	result, err := n.expression.Evaluate(resolve)
+	return !result, err
+}
+
+type truthy struct {
+	operand string
+}
+
+func (t *truthy) Evaluate(resolve Resolver) (bool, error) {
+	value, err := resolve(t.operand)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(value) {
+	case "", "false", "0", "null":
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+type comparison struct {
+	left, operator, right string
+}
+
+func (c *comparison) Evaluate(resolve Resolver) (bool, error) {
+	left, err := resolve(c.left)
+	if err != nil {
+		return false, err
+	}
+	right, err := resolve(c.right)
+	if err != nil {
+		return false, err
+	}
+	leftNumber, leftErr := strconv.ParseFloat(left, 64)
+	rightNumber, rightErr := strconv.ParseFloat(right, 64)
+	if leftErr == nil && rightErr == nil {
+		return compareNumbers(leftNumber, c.operator, rightNumber), nil
+	}
+	switch c.operator {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	default:
+		return false, fmt.Errorf("%w: %s %s %s requires numeric operands", ErrInvalidExpression, left, c.operator, right)
+	}
+}
+
+func compareNumbers(left float64, operator string, right float64) bool {
+	switch operator {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "<":
+		return left < right
+	default:
+		return left <= right
+	}
+}
@@ -0,0 +1,179 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{
+			name:       "empty expression",
+			expression: " ",
+		},
+		{
+			name:       "unterminated string",
+			expression: `${vars.name} == "abc`,
+		},
+		{
+			name:       "missing closing parenthesis",
+			expression: "(a == b",
+		},
+		{
+			name:       "missing operand",
+			expression: "a ==",
+		},
+		{
+			name:       "missing operand of logical operator",
+			expression: "a &&",
+		},
+		{
+			name:       "unexpected token",
+			expression: "a == b c",
+		},
+		{
+			name:       "unexpected closing parenthesis",
+			expression: "a)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			assert.ErrorIs(t, err, ErrInvalidExpression)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	values := map[string]string{
+		"${steps.login.status}": "200",
+		"${vars.enabled}":       "true",
+		"${vars.disabled}":      "false",
+		"${vars.empty}":         "",
+		"${vars.name}":          "a && b",
+		"${vars.count}":         "10",
+	}
+	resolve := func(operand string) (string, error) {
+		if value, ok := values[operand]; ok {
+			return value, nil
+		}
+		return operand, nil
+	}
+	tests := []struct {
+		name       string
+		expression string
+		expected   bool
+		expectErr  bool
+	}{
+		{
+			name:       "equal numbers",
+			expression: "${steps.login.status} == 200",
+			expected:   true,
+		},
+		{
+			name:       "equal numbers with different formats",
+			expression: "${steps.login.status} == 200.0",
+			expected:   true,
+		},
+		{
+			name:       "not equal",
+			expression: "${steps.login.status} != 200",
+			expected:   false,
+		},
+		{
+			name:       "numeric comparison",
+			expression: "${vars.count} > 9 && ${vars.count} >= 10 && ${vars.count} < 11 && ${vars.count} <= 10",
+			expected:   true,
+		},
+		{
+			name:       "numeric comparison is not lexical",
+			expression: "${vars.count} > 9",
+			expected:   true,
+		},
+		{
+			name:       "equal strings",
+			expression: `${vars.enabled} == "true"`,
+			expected:   true,
+		},
+		{
+			name:       "ordering of strings",
+			expression: "${vars.enabled} > abc",
+			expectErr:  true,
+		},
+		{
+			name:       "placeholder containing operators",
+			expression: "${vars.name} == 'a && b'",
+			expected:   true,
+		},
+		{
+			name:       "and short circuits",
+			expression: "${vars.disabled} && ${vars.enabled} > abc",
+			expected:   false,
+		},
+		{
+			name:       "or short circuits",
+			expression: "${vars.enabled} || ${vars.enabled} > abc",
+			expected:   true,
+		},
+		{
+			name:       "and binds stronger than or",
+			expression: "${vars.enabled} || ${vars.disabled} && ${vars.disabled}",
+			expected:   true,
+		},
+		{
+			name:       "parentheses",
+			expression: "(${vars.enabled} || ${vars.disabled}) && ${vars.disabled}",
+			expected:   false,
+		},
+		{
+			name:       "not",
+			expression: "!${vars.disabled} && !(${steps.login.status} != 200)",
+			expected:   true,
+		},
+		{
+			name:       "truthy value",
+			expression: "${vars.enabled}",
+			expected:   true,
+		},
+		{
+			name:       "empty value is false",
+			expression: "${vars.empty}",
+			expected:   false,
+		},
+		{
+			name:       "zero is false",
+			expression: "0",
+			expected:   false,
+		},
+		{
+			name:       "null is false",
+			expression: "NULL",
+			expected:   false,
+		},
+		{
+			name:       "empty value is not equal to a number",
+			expression: "${vars.empty} == 200",
+			expected:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.expression)
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			result, err := expression.Evaluate(resolve)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidExpression)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package condition
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// operators ordered such that longer operators are matched first.
+func operators() []string {
+	return []string{"&&", "||", "==", "!=", ">=", "<=", ">", "<", "!", "(", ")"}
+}
+
+func comparisonOperators() []string {
+	return []string{"==", "!=", ">=", "<=", ">", "<"}
+}
+
+type token struct {
+	value   string
+	operand bool
+}
+
+func lex(expression string) ([]token, error) {
+	tokens := []token{}
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expression[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string %s", ErrInvalidExpression, expression[i:])
+			}
+			tokens = append(tokens, token{value: expression[i+1 : i+1+end], operand: true})
+			i += end + 2
+		case operatorAt(expression[i:]) != "":
+			operator := operatorAt(expression[i:])
+			tokens = append(tokens, token{value: operator})
+			i += len(operator)
+		default:
+			word := wordAt(expression[i:])
+			if word == "" {
+				return nil, fmt.Errorf("%w: unexpected character %c", ErrInvalidExpression, c)
+			}
+			tokens = append(tokens, token{value: word, operand: true})
+			i += len(word)
+		}
+	}
+	return tokens, nil
+}
+
+func operatorAt(s string) string {
+	for _, operator := range operators() {
+		if strings.HasPrefix(s, operator) {
+			return operator
+		}
+	}
+	return ""
+}
+
+// wordAt returns the unquoted operand at the start of s. Placeholders are
+// consumed as a whole, such that they may contain operator characters.
+func wordAt(s string) string {
+	i := 0
+	for i < len(s) {
+		if strings.HasPrefix(s[i:], "${") {
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return s
+			}
+			i += end + 1
+			continue
+		}
+		if strings.ContainsRune(" \t\n\"'()!=<>&|", rune(s[i])) {
+			break
+		}
+		i++
+	}
+	return s[:i]
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) accept(operator string) bool {
+	if p.pos < len(p.tokens) && !p.tokens[p.pos].operand && p.tokens[p.pos].value == operator {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{left: left, right: right, or: true}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logical{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expression, error) {
+	if p.accept("!") {
+		expression, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{expression: expression}, nil
+	}
+	if p.accept("(") {
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidExpression)
+		}
+		return expression, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expression, error) {
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].operand || !slices.Contains(comparisonOperators(), p.tokens[p.pos].value) {
+		return &truthy{operand: left}, nil
+	}
+	operator := p.tokens[p.pos].value
+	p.pos++
+	right, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return &comparison{left: left, operator: operator, right: right}, nil
+}
+
+func (p *parser) operand() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("%w: missing operand", ErrInvalidExpression)
+	}
+	t := p.tokens[p.pos]
+	if !t.operand {
+		return "", fmt.Errorf("%w: expected operand, found %s", ErrInvalidExpression, t.value)
+	}
+	p.pos++
+	return t.value, nil
+}
@@ -0,0 +1,96 @@
+// Package curl converts HTTP requests to and from curl command lines.
+//
+// The options of curl which define the request are supported, i.e. the method,
+// headers, data, basic authentication and cookies. Options which affect how curl
+// performs the request, e.g. -s or -L, are ignored.
+package curl
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// error definitions.
+var (
+	ErrInvalidCommand     = fmt.Errorf("invalid curl command")
+	ErrUnsupportedCommand = fmt.Errorf("unsupported curl command")
+)
+
+// Request is an HTTP request of a curl command. The body is either Body, or a
+// multipart body consisting of Parts.
+type Request struct {
+	Method  string
+	URL     string
+	Headers []*Header
+	Body    string
+	Parts   []*Part
+}
+
+// Part of a multipart body, which is either a text field with a value or a file.
+type Part struct {
+	Name        string
+	Value       string
+	File        string
+	FileName    string
+	ContentType string
+}
+
+// Header of a request.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Command returns the curl command line performing the request, e.g.:
+//
+//	curl -X POST 'http://localhost:3000/v1/projects' \
+//	  -H 'Content-Type: application/json' \
+//	  --data-raw '{"name": "project"}'
+func Command(request *Request) string {
+	parts := []string{}
+	hasBody := request.Body != "" || len(request.Parts) > 0
+	switch strings.ToUpper(request.Method) {
+	case "", http.MethodGet:
+		// As curl sends requests with data as POST requests, the method of GET requests with a body is set explicitly.
+		if hasBody {
+			parts = append(parts, "curl -X GET "+quote(request.URL))
+			break
+		}
+		parts = append(parts, "curl "+quote(request.URL))
+	case http.MethodHead:
+		parts = append(parts, "curl --head "+quote(request.URL))
+	default:
+		parts = append(parts, fmt.Sprintf("curl -X %s %s", strings.ToUpper(request.Method), quote(request.URL)))
+	}
+	for _, header := range request.Headers {
+		parts = append(parts, "-H "+quote(header.Name+": "+header.Value))
+	}
+	if request.Body != "" {
+		parts = append(parts, "--data-raw "+quote(request.Body))
+	}
+	for _, part := range request.Parts {
+		parts = append(parts, part.option())
+	}
+	return strings.Join(parts, " \\\n  ")
+}
+
+// option returns the form option of the part, e.g. -F 'file=@avatar.png;type=image/png'.
+func (p *Part) option() string {
+	if p.File == "" {
+		return "--form-string " + quote(p.Name+"="+p.Value)
+	}
+	value := p.Name + "=@" + p.File
+	if p.ContentType != "" {
+		value += ";type=" + p.ContentType
+	}
+	if p.FileName != "" {
+		value += ";filename=" + p.FileName
+	}
+	return "-F " + quote(value)
+}
+
+// quote quotes a value for POSIX shells.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
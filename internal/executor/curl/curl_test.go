@@ -0,0 +1,197 @@
+package curl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *Request
+		expected string
+	}{
+		{
+			name:     "get",
+			request:  &Request{Method: "GET", URL: "http://localhost/v1/projects"},
+			expected: "curl 'http://localhost/v1/projects'",
+		},
+		{
+			name:     "get with body",
+			request:  &Request{Method: "GET", URL: "http://localhost/search", Body: `{"q": "a"}`},
+			expected: "curl -X GET 'http://localhost/search' \\\n  --data-raw '{\"q\": \"a\"}'",
+		},
+		{
+			name:     "get with multipart body",
+			request:  &Request{URL: "http://localhost/search", Parts: []*Part{{Name: "q", Value: "a"}}},
+			expected: "curl -X GET 'http://localhost/search' \\\n  --form-string 'q=a'",
+		},
+		{
+			name:     "head",
+			request:  &Request{Method: "head", URL: "http://localhost"},
+			expected: "curl --head 'http://localhost'",
+		},
+		{
+			name: "post with headers and body",
+			request: &Request{
+				Method:  "post",
+				URL:     "http://localhost/v1/projects",
+				Headers: []*Header{{Name: "Content-Type", Value: "application/json"}},
+				Body:    `{"name": "it's"}`,
+			},
+			expected: "curl -X POST 'http://localhost/v1/projects' \\\n  -H 'Content-Type: application/json' \\\n  --data-raw '{\"name\": \"it'\\''s\"}'",
+		},
+		{
+			name: "multipart file",
+			request: &Request{
+				Method: "PUT",
+				URL:    "http://localhost/avatar",
+				Parts:  []*Part{{Name: "file", File: "avatar.png", ContentType: "image/png", FileName: "me.png"}},
+			},
+			expected: "curl -X PUT 'http://localhost/avatar' \\\n  -F 'file=@avatar.png;type=image/png;filename=me.png'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Command(tt.request))
+		})
+	}
+}
+
+func TestCommandRoundTrip(t *testing.T) {
+	requests := []*Request{
+		{Method: "GET", URL: "http://localhost/search", Headers: []*Header{{Name: "Content-Type", Value: "application/json"}}, Body: `{"q": "a"}`},
+		{Method: "GET", URL: "http://localhost/search", Headers: []*Header{}, Parts: []*Part{{Name: "q", Value: "a"}}},
+		{Method: "DELETE", URL: "http://localhost/v1/projects/1", Headers: []*Header{{Name: "Authorization", Value: "Bearer it's"}}},
+		{Method: "POST", URL: "http://localhost/v1/projects", Headers: []*Header{{Name: "Content-Type", Value: "text/plain"}}, Body: "line\nline"},
+	}
+	for _, request := range requests {
+		t.Run(request.Method, func(t *testing.T) {
+			parsed, err := Parse(Command(request))
+			assert.NoError(t, err)
+			assert.Equal(t, request, parsed)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		expected    *Request
+		expectedErr error
+	}{
+		{
+			name:     "get",
+			command:  "curl http://localhost",
+			expected: &Request{Method: "GET", URL: "http://localhost", Headers: []*Header{}},
+		},
+		{
+			name:    "data is posted as form",
+			command: "curl http://localhost -d a=1 --data b=2",
+			expected: &Request{
+				Method:  "POST",
+				URL:     "http://localhost",
+				Headers: []*Header{{Name: "Content-Type", Value: "application/x-www-form-urlencoded"}},
+				Body:    "a=1&b=2",
+			},
+		},
+		{
+			name:     "data appended to the URL with get",
+			command:  "curl -G 'http://localhost?a=1' --data-urlencode 'b=c d'",
+			expected: &Request{Method: "GET", URL: "http://localhost?a=1&b=c+d", Headers: []*Header{}},
+		},
+		{
+			name:    "json",
+			command: `curl -X put --url http://localhost --json '{"a": 1}'`,
+			expected: &Request{
+				Method: "PUT",
+				URL:    "http://localhost",
+				Headers: []*Header{
+					{Name: "Content-Type", Value: "application/json"},
+					{Name: "Accept", Value: "application/json"},
+				},
+				Body: `{"a": 1}`,
+			},
+		},
+		{
+			name:    "headers, user and cookies",
+			command: `curl -s -L -o /dev/null "http://localhost" -H 'X-Id: 1' -u user:pass -b 'a=b' -A agent`,
+			expected: &Request{
+				Method: "GET",
+				URL:    "http://localhost",
+				Headers: []*Header{
+					{Name: "X-Id", Value: "1"},
+					{Name: "Authorization", Value: "Basic dXNlcjpwYXNz"},
+					{Name: "Cookie", Value: "a=b"},
+					{Name: "User-Agent", Value: "agent"},
+				},
+			},
+		},
+		{
+			name:    "multiline command with ansi c quoting",
+			command: "curl 'http://localhost' \\\n  -XPOST \\\n  --data-raw $'a\\nb'",
+			expected: &Request{
+				Method:  "POST",
+				URL:     "http://localhost",
+				Headers: []*Header{{Name: "Content-Type", Value: "application/x-www-form-urlencoded"}},
+				Body:    "a\nb",
+			},
+		},
+		{
+			name:    "multipart form",
+			command: "curl http://localhost -F 'name=a' -F 'file=@avatar.png;type=image/png'",
+			expected: &Request{
+				Method:  "POST",
+				URL:     "http://localhost",
+				Headers: []*Header{},
+				Parts: []*Part{
+					{Name: "name", Value: "a"},
+					{Name: "file", File: "avatar.png", ContentType: "image/png"},
+				},
+			},
+		},
+		{
+			name:        "not a curl command",
+			command:     "wget http://localhost",
+			expectedErr: ErrInvalidCommand,
+		},
+		{
+			name:        "no URL",
+			command:     "curl -X GET",
+			expectedErr: ErrInvalidCommand,
+		},
+		{
+			name:        "missing option value",
+			command:     "curl http://localhost -H",
+			expectedErr: ErrInvalidCommand,
+		},
+		{
+			name:        "unterminated quote",
+			command:     "curl 'http://localhost",
+			expectedErr: ErrInvalidCommand,
+		},
+		{
+			name:        "data from file",
+			command:     "curl http://localhost -d @body.json",
+			expectedErr: ErrUnsupportedCommand,
+		},
+		{
+			name:        "upload",
+			command:     "curl http://localhost -T file.txt",
+			expectedErr: ErrUnsupportedCommand,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := Parse(tt.command)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, request)
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package curl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+type optionFunc func(p *parser, value string) error
+
+// optionFuncs returns the options of curl which take a value and define the request.
+func optionFuncs() map[string]optionFunc {
+	return map[string]optionFunc{
+		"-X": (*parser).method, "--request": (*parser).method,
+		"-H": (*parser).header, "--header": (*parser).header,
+		"-d": (*parser).data, "--data": (*parser).data, "--data-ascii": (*parser).data, "--data-binary": (*parser).data,
+		"--data-raw":       (*parser).dataRaw,
+		"--data-urlencode": (*parser).dataURLEncode,
+		"--json":           (*parser).json,
+		"-u":               (*parser).user, "--user": (*parser).user,
+		"-A": (*parser).userAgent, "--user-agent": (*parser).userAgent,
+		"-e": (*parser).referer, "--referer": (*parser).referer,
+		"-b": (*parser).cookie, "--cookie": (*parser).cookie,
+		"--url": (*parser).url,
+		"-F":    (*parser).form, "--form": (*parser).form,
+		"--form-string": (*parser).formString,
+		"-T":            (*parser).upload, "--upload-file": (*parser).upload,
+	}
+}
+
+// ignoredOptions returns the options of curl which take a value, but do not define the request.
+func ignoredOptions() []string {
+	return []string{
+		"-o", "--output", "-m", "--max-time", "--connect-timeout", "-w", "--write-out",
+		"--retry", "--retry-delay", "--retry-max-time", "-x", "--proxy", "-U", "--proxy-user",
+		"--cacert", "--capath", "-E", "--cert", "--key", "--cert-type", "--key-type",
+		"-c", "--cookie-jar", "--resolve", "--connect-to", "--max-redirs", "-r", "--range",
+		"--limit-rate", "-y", "--speed-time", "-Y", "--speed-limit", "--interface", "--unix-socket",
+	}
+}
+
+type parser struct {
+	request *Request
+	payload []string
+	get     bool
+}
+
+// Parse parses a curl command line, e.g. as copied from the developer tools of a browser.
+// Reading data from files and file uploads with -T are not supported, files of
+// multipart forms are referenced by their path.
+func Parse(command string) (*Request, error) {
+	args, err := split(command)
+	if err != nil {
+		return nil, err
+	}
+	return ParseArgs(args)
+}
+
+// ParseArgs parses the arguments of a curl command line, which are already split by a shell.
+func ParseArgs(args []string) (*Request, error) {
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, fmt.Errorf("%w: command must start with curl", ErrInvalidCommand)
+	}
+	p := &parser{request: &Request{Headers: []*Header{}}}
+	err := p.parseArgs(args[1:])
+	if err != nil {
+		return nil, err
+	}
+	return p.build()
+}
+
+func (p *parser) parseArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := splitOption(args[i])
+		if !strings.HasPrefix(name, "-") {
+			p.request.URL = args[i]
+			continue
+		}
+		option, ok := optionFuncs()[name]
+		if !ok {
+			i += p.flag(name, hasValue)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return fmt.Errorf("%w: option %s requires a value", ErrInvalidCommand, name)
+			}
+			i++
+			value = args[i]
+		}
+		err := option(p, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitOption splits an option from its value, for long options defined
+// as --option=value and short options defined as -Xvalue.
+func splitOption(arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, "--") {
+		return strings.Cut(arg, "=")
+	}
+	if strings.HasPrefix(arg, "-") && len(arg) > 2 {
+		if _, ok := optionFuncs()[arg[:2]]; ok {
+			return arg[:2], arg[2:], true
+		}
+	}
+	return arg, "", false
+}
+
+// flag handles options which do not define the request, it returns the number of
+// arguments to skip, i.e. 1 for options which take a separate value.
+func (p *parser) flag(name string, hasValue bool) int {
+	switch name {
+	case "-G", "--get":
+		p.get = true
+	case "-I", "--head":
+		p.request.Method = http.MethodHead
+	}
+	if slices.Contains(ignoredOptions(), name) && !hasValue {
+		return 1
+	}
+	return 0
+}
+
+func (p *parser) method(value string) error {
+	p.request.Method = strings.ToUpper(value)
+	return nil
+}
+
+func (p *parser) header(value string) error {
+	name, headerValue, ok := strings.Cut(value, ":")
+	if !ok {
+		return fmt.Errorf("%w: invalid header %s", ErrInvalidCommand, value)
+	}
+	p.setHeader(strings.TrimSpace(name), strings.TrimSpace(headerValue))
+	return nil
+}
+
+func (p *parser) data(value string) error {
+	if strings.HasPrefix(value, "@") {
+		return fmt.Errorf("%w: reading data from a file is not supported", ErrUnsupportedCommand)
+	}
+	p.payload = append(p.payload, value)
+	return nil
+}
+
+func (p *parser) dataRaw(value string) error {
+	p.payload = append(p.payload, value)
+	return nil
+}
+
+// dataURLEncode encodes data as curl's --data-urlencode option, i.e. either content,
+// =content or name=content, of which the content is URL encoded.
+func (p *parser) dataURLEncode(value string) error {
+	name, content, hasName := strings.Cut(value, "=")
+	switch {
+	case hasName && name != "":
+		p.payload = append(p.payload, name+"="+url.QueryEscape(content))
+	case hasName:
+		p.payload = append(p.payload, url.QueryEscape(content))
+	case strings.Contains(value, "@"):
+		return fmt.Errorf("%w: reading data from a file is not supported", ErrUnsupportedCommand)
+	default:
+		p.payload = append(p.payload, url.QueryEscape(value))
+	}
+	return nil
+}
+
+func (p *parser) json(value string) error {
+	p.payload = append(p.payload, value)
+	p.setHeader("Content-Type", "application/json")
+	p.setHeader("Accept", "application/json")
+	return nil
+}
+
+func (p *parser) user(value string) error {
+	p.setHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(value)))
+	return nil
+}
+
+func (p *parser) userAgent(value string) error {
+	p.setHeader("User-Agent", value)
+	return nil
+}
+
+func (p *parser) referer(value string) error {
+	p.setHeader("Referer", value)
+	return nil
+}
+
+func (p *parser) cookie(value string) error {
+	// Cookies without a name are read from a file.
+	if strings.Contains(value, "=") {
+		p.setHeader("Cookie", value)
+	}
+	return nil
+}
+
+func (p *parser) url(value string) error {
+	p.request.URL = value
+	return nil
+}
+
+// form adds a part to the multipart body, files are defined as name=@file;type=<content type>;filename=<name>.
+func (p *parser) form(value string) error {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("%w: invalid form field %s", ErrInvalidCommand, value)
+	}
+	switch {
+	case strings.HasPrefix(content, "<"):
+		return fmt.Errorf("%w: reading form fields from a file is not supported", ErrUnsupportedCommand)
+	case !strings.HasPrefix(content, "@"):
+		p.request.Parts = append(p.request.Parts, &Part{Name: name, Value: content})
+		return nil
+	}
+	attributes := strings.Split(strings.TrimPrefix(content, "@"), ";")
+	part := &Part{Name: name, File: attributes[0]}
+	for _, attribute := range attributes[1:] {
+		key, attributeValue, _ := strings.Cut(attribute, "=")
+		switch key {
+		case "type":
+			part.ContentType = attributeValue
+		case "filename":
+			part.FileName = strings.Trim(attributeValue, `"`)
+		}
+	}
+	p.request.Parts = append(p.request.Parts, part)
+	return nil
+}
+
+func (p *parser) formString(value string) error {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("%w: invalid form field %s", ErrInvalidCommand, value)
+	}
+	p.request.Parts = append(p.request.Parts, &Part{Name: name, Value: content})
+	return nil
+}
+
+func (p *parser) upload(_ string) error {
+	return fmt.Errorf("%w: file uploads are not supported", ErrUnsupportedCommand)
+}
+
+func (p *parser) setHeader(name, value string) {
+	for _, header := range p.request.Headers {
+		if strings.EqualFold(header.Name, name) {
+			header.Value = value
+			return
+		}
+	}
+	p.request.Headers = append(p.request.Headers, &Header{Name: name, Value: value})
+}
+
+func (p *parser) hasHeader(name string) bool {
+	for _, header := range p.request.Headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// build builds the request. As curl, data is sent as a form in the body of a POST request,
+// unless the method is set explicitly or the data is appended to the URL with -G.
+func (p *parser) build() (*Request, error) {
+	if p.request.URL == "" {
+		return nil, fmt.Errorf("%w: no URL", ErrInvalidCommand)
+	}
+	data := strings.Join(p.payload, "&")
+	switch {
+	case p.get && data != "":
+		separator := "?"
+		if strings.Contains(p.request.URL, "?") {
+			separator = "&"
+		}
+		p.request.URL += separator + data
+		p.request.Method = http.MethodGet
+	case data != "":
+		p.request.Body = data
+		if !p.hasHeader("Content-Type") {
+			p.setHeader("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+	if p.request.Method != "" {
+		return p.request, nil
+	}
+	p.request.Method = http.MethodGet
+	if p.request.Body != "" || len(p.request.Parts) > 0 {
+		p.request.Method = http.MethodPost
+	}
+	return p.request, nil
+}
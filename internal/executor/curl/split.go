@@ -0,0 +1,148 @@
+package curl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// splitter splits a command line into its arguments as a POSIX shell does,
+// supporting single quotes, double quotes, ANSI-C quotes ($'...') and escapes.
+type splitter struct {
+	command []rune
+	pos     int
+}
+
+func split(command string) ([]string, error) {
+	s := &splitter{command: []rune(command)}
+	args := []string{}
+	for {
+		s.skipWhitespace()
+		if s.pos >= len(s.command) {
+			return args, nil
+		}
+		arg, err := s.arg()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+}
+
+func (s *splitter) skipWhitespace() {
+	for s.pos < len(s.command) {
+		switch {
+		case strings.ContainsRune(" \t\r\n", s.command[s.pos]):
+			s.pos++
+		case s.hasPrefix("\\\n"):
+			s.pos += 2
+		case s.hasPrefix("\\\r\n"):
+			s.pos += 3
+		default:
+			return
+		}
+	}
+}
+
+func (s *splitter) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(string(s.command[s.pos:]), prefix)
+}
+
+// arg returns the argument starting at the current position.
+func (s *splitter) arg() (string, error) {
+	arg := &strings.Builder{}
+	for s.pos < len(s.command) && !strings.ContainsRune(" \t\r\n", s.command[s.pos]) {
+		var part string
+		var err error
+		switch {
+		case s.command[s.pos] == '\'':
+			part, err = s.quoted('\'', nil)
+		case s.command[s.pos] == '"':
+			part, err = s.quoted('"', doubleQuoteEscape)
+		case s.hasPrefix("$'"):
+			s.pos++
+			part, err = s.quoted('\'', ansiCEscape)
+		default:
+			part = s.unquoted()
+		}
+		if err != nil {
+			return "", err
+		}
+		arg.WriteString(part)
+	}
+	return arg.String(), nil
+}
+
+func (s *splitter) unquoted() string {
+	r := s.command[s.pos]
+	s.pos++
+	if r != '\\' || s.pos >= len(s.command) {
+		return string(r)
+	}
+	r = s.command[s.pos]
+	s.pos++
+	if r == '\n' {
+		return ""
+	}
+	return string(r)
+}
+
+// quoted returns the contents of a quoted part of an argument,
+// escape sequences are replaced by the escape function.
+func (s *splitter) quoted(quote rune, escape func(s *splitter) string) (string, error) {
+	s.pos++
+	part := &strings.Builder{}
+	for s.pos < len(s.command) {
+		r := s.command[s.pos]
+		switch {
+		case r == quote:
+			s.pos++
+			return part.String(), nil
+		case r == '\\' && escape != nil && s.pos+1 < len(s.command):
+			s.pos++
+			part.WriteString(escape(s))
+		default:
+			s.pos++
+			part.WriteRune(r)
+		}
+	}
+	return "", fmt.Errorf("%w: unterminated quote %c", ErrInvalidCommand, quote)
+}
+
+// doubleQuoteEscape replaces the escape sequences of double quoted strings.
+func doubleQuoteEscape(s *splitter) string {
+	r := s.command[s.pos]
+	s.pos++
+	switch r {
+	case '"', '\\', '$', '`':
+		return string(r)
+	case '\n':
+		return ""
+	default:
+		return "\\" + string(r)
+	}
+}
+
+// ansiCEscape replaces the escape sequences of ANSI-C quoted strings, e.g. \n and \x41.
+func ansiCEscape(s *splitter) string {
+	r := s.command[s.pos]
+	s.pos++
+	if replacement, ok := map[rune]string{
+		'n': "\n", 't': "\t", 'r': "\r", '\\': "\\", '\'': "'", '"': "\"", '?': "?", 'a': "\a", 'b': "\b", 'e': "\x1b", 'f': "\f", 'v': "\v",
+	}[r]; ok {
+		return replacement
+	}
+	digits := map[rune]int{'x': 2, 'u': 4, 'U': 8}[r]
+	if digits == 0 || s.pos+digits > len(s.command) {
+		return "\\" + string(r)
+	}
+	code, err := strconv.ParseUint(string(s.command[s.pos:s.pos+digits]), 16, 32)
+	if err != nil {
+		return "\\" + string(r)
+	}
+	s.pos += digits
+	if r == 'x' {
+		return string([]byte{byte(code)})
+	}
+	return string(rune(code))
+}
@@ -1,6 +1,8 @@
 package executor
 
 import (
+	"time"
+
 	"github.com/inquiryproj/inquiry/internal/executor/yaml"
 )
 
@@ -13,9 +15,14 @@ const (
 
 // TestSpec for a single test scenario.
 type TestSpec struct {
-	Version   string
-	Type      testType
-	Variables []*Variable
+	Version     string
+	Type        testType
+	Timeout     time.Duration
+	MaxDuration time.Duration
+	OpenAPI     string
+	Cookies     bool
+	Client      *yaml.Client
+	Variables   []*Variable
 }
 
 // Variable for a single test definition.
@@ -26,8 +33,13 @@ type Variable struct {
 
 func yamlTestSpecToTestSpec(testDefinition *yaml.TestSpec) *TestSpec {
 	return &TestSpec{
-		Version: testDefinition.Version,
-		Type:    testType(testDefinition.Type),
+		Version:     testDefinition.Version,
+		Type:        testType(testDefinition.Type),
+		Timeout:     testDefinition.Timeout,
+		MaxDuration: testDefinition.MaxDuration,
+		OpenAPI:     testDefinition.OpenAPI,
+		Cookies:     testDefinition.Cookies == nil || *testDefinition.Cookies,
+		Client:      testDefinition.Client,
 		Variables: func() []*Variable {
 			variables := []*Variable{}
 			for _, v := range testDefinition.Variables {
@@ -2,12 +2,15 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 
 	"github.com/inquiryproj/inquiry/internal/executor/http"
+	"github.com/inquiryproj/inquiry/internal/executor/jsonschema"
+	"github.com/inquiryproj/inquiry/internal/executor/openapi"
 	"github.com/inquiryproj/inquiry/internal/executor/replacer"
 	"github.com/inquiryproj/inquiry/internal/executor/yaml"
 )
@@ -19,17 +22,25 @@ var (
 
 // App is the interface for the test executor app.
 type App interface {
-	Play() (*http.ExecuteResult, error)
+	Play(ctx context.Context) (*http.ExecuteResult, error)
 }
 
 type options struct {
-	Reader io.Reader
-	Logger *slog.Logger
+	Reader         io.Reader
+	Logger         *slog.Logger
+	ResourceReader ResourceReader
+	CurlWriter     io.Writer
+	ClientDefaults []byte
+	Contract       *contract
+	LookupEnv      func(string) (string, bool)
+	Variables      map[string]string
+	Secrets        map[string]string
 }
 
 func defaultOptions() *options {
 	return &options{
-		Reader: os.Stdin,
+		Reader:         os.Stdin,
+		ResourceReader: noResourceReader,
 		Logger: slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelInfo,
 		})),
@@ -53,6 +64,66 @@ func WithReader(reader io.Reader) Opts {
 	}
 }
 
+// WithResourceReader sets the reader used to read resources referenced by the scenario,
+// such as the dataset of its matrix, JSON Schemas or files attached to multipart bodies.
+func WithResourceReader(resourceReader ResourceReader) Opts {
+	return func(o *options) {
+		o.ResourceReader = resourceReader
+	}
+}
+
+// WithCurlWriter sets the writer the curl command lines of the requests of failing steps are written to.
+func WithCurlWriter(w io.Writer) Opts {
+	return func(o *options) {
+		o.CurlWriter = w
+	}
+}
+
+// WithClientDefaults sets the YAML client configuration, which is used for the settings
+// the client configuration of the scenario does not set, e.g. the client configuration of a project.
+func WithClientDefaults(data []byte) Opts {
+	return func(o *options) {
+		o.ClientDefaults = data
+	}
+}
+
+// WithContract sets the OpenAPI 3 document which requests and responses are validated against,
+// e.g. the OpenAPI document of a project, unless the scenario references one with openapi.
+func WithContract(name string, data []byte) Opts {
+	return func(o *options) {
+		o.Contract = &contract{name: name, data: data}
+	}
+}
+
+// contract is a named OpenAPI 3 document.
+type contract struct {
+	name string
+	data []byte
+}
+
+// WithEnvLookup sets the lookup of environment variables for the env template function,
+// e.g. os.LookupEnv. Without lookup, environment variables are not available to scenarios.
+func WithEnvLookup(lookupEnv func(string) (string, bool)) Opts {
+	return func(o *options) {
+		o.LookupEnv = lookupEnv
+	}
+}
+
+// WithVariables sets the values of variables, e.g. of an environment, which override the values
+// defined by the scenario. The values of the rows of a matrix take precedence over these values.
+func WithVariables(variables map[string]string) Opts {
+	return func(o *options) {
+		o.Variables = variables
+	}
+}
+
+// WithSecrets sets the values of secrets, which replace ${secrets.<name>} placeholders.
+func WithSecrets(secrets map[string]string) Opts {
+	return func(o *options) {
+		o.Secrets = secrets
+	}
+}
+
 // New creates a new test executor app.
 func New(name string, opts ...Opts) (App, error) {
 	o := defaultOptions()
@@ -60,27 +131,99 @@ func New(name string, opts ...Opts) (App, error) {
 		opt(o)
 	}
 
+	matrix, definitions, err := readDefinitions(name, o)
+	if err != nil {
+		return nil, err
+	}
+	if matrix != nil {
+		return newMatrixApp(name, definitions, o)
+	}
+	return newAppForTestDefinition(name, definitions[0].testSpec, definitions[0].scenario, o)
+}
+
+// Load reads the scenario as New does, i.e. with its fragments expanded and its placeholders
+// replaced, without creating an executor, e.g. to export its requests. A scenario with a matrix
+// is read for every row of the matrix.
+func Load(name string, opts ...Opts) ([]*yaml.Scenario, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	_, definitions, err := readDefinitions(name, o)
+	if err != nil {
+		return nil, err
+	}
+	scenarios := make([]*yaml.Scenario, len(definitions))
+	for i, definition := range definitions {
+		scenarios[i] = definition.scenario
+	}
+	return scenarios, nil
+}
+
+// rowDefinition is the test definition of a scenario for a row of its matrix.
+type rowDefinition struct {
+	row      map[string]string
+	testSpec *TestSpec
+	scenario *yaml.Scenario
+}
+
+// readDefinitions reads the scenario definition of the reader of the options and returns its matrix,
+// if any. The test definition is read once for every row of the matrix, or once with a nil row for
+// scenarios without matrix.
+func readDefinitions(name string, o *options) (*yaml.Matrix, []*rowDefinition, error) {
 	data, err := io.ReadAll(o.Reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read HTTP scenario definition: %w", err)
+		return nil, nil, fmt.Errorf("failed to read HTTP scenario definition: %w", err)
 	}
-	testSpec, yamlScenario, err := readData(data)
+	yamlTestSpec, err := yaml.NewTestSpecFromBytes(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read test definition: %w", err)
+		return nil, nil, fmt.Errorf("failed to read test definition: %w", err)
 	}
-	return newAppForTestDefinition(name, testSpec, yamlScenario, o)
+	if yamlTestSpec.Matrix == nil {
+		testSpec, yamlScenario, err := readData(name, data, o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read test definition: %w", err)
+		}
+		return nil, []*rowDefinition{{testSpec: testSpec, scenario: yamlScenario}}, nil
+	}
+	definitions, err := readRowDefinitions(name, data, yamlTestSpec.Matrix, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	return yamlTestSpec.Matrix, definitions, nil
+}
+
+// readRowDefinitions reads the test definition for every row of the matrix of a scenario.
+func readRowDefinitions(name string, data []byte, matrix *yaml.Matrix, o *options) ([]*rowDefinition, error) {
+	rows, err := matrixRows(matrix, o.ResourceReader)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyMatrix
+	}
+	definitions := make([]*rowDefinition, len(rows))
+	for i, row := range rows {
+		testSpec, yamlScenario, err := readData(name, data, o, yaml.NewVariablesReplacer(row))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read test definition for matrix row %s: %w", rowDescription(row), err)
+		}
+		definitions[i] = &rowDefinition{row: row, testSpec: testSpec, scenario: yamlScenario}
+	}
+	return definitions, nil
 }
 
 type scenarioExecutor interface {
-	Play() (*http.ExecuteResult, error)
+	Play(ctx context.Context) (*http.ExecuteResult, error)
 }
 
 type app struct {
 	scenarioExecutor scenarioExecutor
 }
 
-func (a *app) Play() (*http.ExecuteResult, error) {
-	return a.scenarioExecutor.Play()
+func (a *app) Play(ctx context.Context) (*http.ExecuteResult, error) {
+	return a.scenarioExecutor.Play(ctx)
 }
 
 func newAppForTestDefinition(name string,
@@ -89,10 +232,15 @@ func newAppForTestDefinition(name string,
 ) (*app, error) {
 	switch testSpec.Type {
 	case TestTypeHTTP:
-		httpExecutor, err := http.NewExecutor(
-			yamlScenarioToHTTPScenario(name, scenario),
-			http.WithLogger(options.Logger),
-		)
+		httpScenario := yamlScenarioToHTTPScenario(name, scenario)
+		httpScenario.Timeout = testSpec.Timeout
+		httpScenario.MaxDuration = testSpec.MaxDuration
+		httpScenario.Cookies = testSpec.Cookies
+		httpOpts, err := httpOptions(testSpec, options)
+		if err != nil {
+			return nil, err
+		}
+		httpExecutor, err := http.NewExecutor(httpScenario, httpOpts...)
 		if err != nil {
 			return nil, err
 		}
@@ -104,14 +252,67 @@ func newAppForTestDefinition(name string,
 	}
 }
 
-func readData(data []byte) (*TestSpec, *yaml.Scenario, error) {
-	yamlTestSpec, yamlScenario, err := yaml.NewTestDefinitionFromBytes(
-		data,
-		replacer.NewFuncReplacer(),
-	)
+// readData expands the fragments used by the scenario and parses it, with the placeholders replaced.
+func readData(name string, data []byte, options *options, replacers ...replacer.Replacer) (*TestSpec, *yaml.Scenario, error) {
+	data, err := yaml.ExpandFragments(name, data, options.ResourceReader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to expand fragments: %w", err)
+	}
+	funcReplacerOpts := []replacer.FuncOpts{}
+	if options.LookupEnv != nil {
+		funcReplacerOpts = append(funcReplacerOpts, replacer.WithEnvLookup(options.LookupEnv))
+	}
+	replacers = append([]replacer.Replacer{replacer.NewFuncReplacer(funcReplacerOpts...)}, replacers...)
+	if len(options.Variables) > 0 {
+		replacers = append(replacers, yaml.NewVariablesReplacer(options.Variables))
+	}
+	if len(options.Secrets) > 0 {
+		replacers = append(replacers, yaml.NewSecretsReplacer(options.Secrets))
+	}
+	yamlTestSpec, yamlScenario, err := yaml.NewTestDefinitionFromBytes(data, replacers...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to parse HTTP scenario definition: %w", err)
 	}
 
 	return yamlTestSpecToTestSpec(yamlTestSpec), yamlScenario, nil
 }
+
+func httpOptions(testSpec *TestSpec, options *options) ([]http.Opts, error) {
+	httpOpts := []http.Opts{
+		http.WithLogger(options.Logger),
+		http.WithSchemaLoader(jsonschema.Loader(options.ResourceReader)),
+		http.WithAttachmentLoader(http.AttachmentLoader(options.ResourceReader)),
+	}
+	if options.CurlWriter != nil {
+		httpOpts = append(httpOpts, http.WithCurlWriter(options.CurlWriter))
+	}
+	client, err := newHTTPClient(testSpec.Client, options)
+	if err != nil {
+		return nil, err
+	}
+	if client != nil {
+		httpOpts = append(httpOpts, http.WithHTTPClient(client))
+	}
+	contract, err := scenarioContract(testSpec, options)
+	if err != nil || contract == nil {
+		return httpOpts, err
+	}
+	document, err := openapi.NewDocument(contract.name, contract.data)
+	if err != nil {
+		return nil, err
+	}
+	return append(httpOpts, http.WithContract(document)), nil
+}
+
+// scenarioContract returns the OpenAPI document referenced by the scenario, or else the
+// contract of the options, or nil if the scenario is not validated against a contract.
+func scenarioContract(testSpec *TestSpec, options *options) (*contract, error) {
+	if testSpec.OpenAPI == "" {
+		return options.Contract, nil
+	}
+	data, err := options.ResourceReader(testSpec.OpenAPI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenAPI document %s: %w", testSpec.OpenAPI, err)
+	}
+	return &contract{name: testSpec.OpenAPI, data: data}, nil
+}
@@ -0,0 +1,399 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer starts a server with the handler, which is closed when the test finishes.
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newTestApp creates an app for the scenario, which discards its logs.
+func newTestApp(name, scenario string, opts ...Opts) (App, error) {
+	return New(name, append([]Opts{
+		WithReader(bytes.NewBufferString(scenario)),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+	}, opts...)...)
+}
+
+func TestStructuredBodies(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			_ = r.ParseMultipartForm(1 << 20)
+			for name, files := range r.MultipartForm.File {
+				f, _ := files[0].Open()
+				content, _ := io.ReadAll(f)
+				_ = f.Close()
+				w.Header().Set("X-"+name, files[0].Filename+":"+string(content))
+			}
+			w.Header().Set("X-Description", r.FormValue("description"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("X-Body", string(body))
+	})
+
+	tests := []struct {
+		name            string
+		request         string
+		expectedHeaders map[string]string
+	}{
+		{
+			name: "json",
+			request: `json:
+        name: test
+        tags: [a]`,
+			expectedHeaders: map[string]string{
+				"X-Content-Type": "application/json",
+				"X-Body":         `{"name":"test","tags":["a"]}`,
+			},
+		},
+		{
+			name: "form",
+			request: `form:
+        user: test`,
+			expectedHeaders: map[string]string{
+				"X-Content-Type": "application/x-www-form-urlencoded",
+				"X-Body":         "user=test",
+			},
+		},
+		{
+			name: "raw",
+			request: `raw:
+        content_type: text/csv
+        data: "a,b"`,
+			expectedHeaders: map[string]string{
+				"X-Content-Type": "text/csv",
+				"X-Body":         "a,b",
+			},
+		},
+		{
+			name: "multipart",
+			request: `multipart:
+        - name: description
+          value: files
+        - name: avatar
+          file: files/avatar.png
+        - name: data
+          base64: Ymlu
+          filename: data.bin`,
+			expectedHeaders: map[string]string{
+				"X-Description": "files",
+				"X-Avatar":      "avatar.png:png",
+				"X-Data":        "data.bin:bin",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := []string{}
+			for name, value := range tt.expectedHeaders {
+				assertions = append(assertions, fmt.Sprintf(`        - key: %s
+          assertion: equal
+          value: %q`, name, value))
+			}
+			scenario := fmt.Sprintf(`version: v1
+type: http
+steps:
+  - name: upload
+    request:
+      method: POST
+      url: %s
+      %s
+    validation:
+      headers:
+%s
+`, server.URL, tt.request, strings.Join(assertions, "\n"))
+			app, err := newTestApp("bodies", scenario,
+				WithResourceReader(func(name string) ([]byte, error) {
+					if name != "files/avatar.png" {
+						return nil, fmt.Errorf("unknown resource %s", name)
+					}
+					return []byte("png"), nil
+				}),
+			)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			result, err := app.Play(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+			assert.Len(t, result.StepResults[0].AssertionResults, len(tt.expectedHeaders))
+			for _, assertionResult := range result.StepResults[0].AssertionResults {
+				assert.True(t, assertionResult.Success, "%s: %s", assertionResult.Key, assertionResult.Actual)
+			}
+		})
+	}
+}
+
+func TestClientDefaults(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		client         string
+		defaults       string
+		expectedStatus string
+		expectedErr    string
+	}{
+		{
+			name:           "redirects are followed by default",
+			expectedStatus: "200",
+		},
+		{
+			name:           "client defaults",
+			defaults:       "redirects:\n  follow: false\n",
+			expectedStatus: "301",
+		},
+		{
+			name:           "client of the scenario overrides the client defaults",
+			client:         "client:\n  redirects:\n    follow: true\n",
+			defaults:       "redirects:\n  follow: false\n",
+			expectedStatus: "200",
+		},
+		{
+			name:        "invalid client defaults",
+			defaults:    "redirects:\n  max: -1\n",
+			expectedErr: "failed to read client defaults: invalid client configuration: redirects max must not be negative",
+		},
+		{
+			name:        "missing TLS resource",
+			client:      "client:\n  tls:\n    ca: ca.pem\n",
+			expectedErr: "failed to read TLS resource ca.pem: not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := fmt.Sprintf(`version: v1
+type: http
+%ssteps:
+  - name: old
+    request:
+      method: GET
+      url: %s/old
+    validation:
+      status:
+        assertion: equal
+        value: "%s"
+`, tt.client, server.URL, tt.expectedStatus)
+			app, err := newTestApp("client", scenario,
+				WithClientDefaults([]byte(tt.defaults)),
+				WithResourceReader(func(string) ([]byte, error) {
+					return nil, fmt.Errorf("not found")
+				}),
+			)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			result, err := app.Play(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+		})
+	}
+}
+
+func TestGraphQL(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch string(body) {
+		case `{"query":"query user($id: ID!) { user(id: $id) { id name } }","variables":{"id":"42"},"operationName":"user"}`:
+			_, _ = w.Write([]byte(`{"data": {"user": {"id": "42", "name": "bob"}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"errors": [{"message": "unexpected request", "extensions": {"code": "BAD_REQUEST"}}]}`))
+		}
+	})
+
+	scenario := fmt.Sprintf(`version: v1
+type: http
+steps:
+  - name: user
+    request:
+      method: POST
+      url: %[1]s
+      graphql:
+        query: "query user($id: ID!) { user(id: $id) { id name } }"
+        variables:
+          id: "42"
+        operation_name: user
+    validation:
+      status:
+        assertion: equal
+        value: "200"
+      graphql:
+        no_errors: true
+        data:
+          - key: user.name
+            assertion: equal
+            value: bob
+    capture:
+      - name: name
+        data: user.name
+  - name: invalid
+    request:
+      method: POST
+      url: %[1]s?name=${vars.name}
+      graphql:
+        query: "{ users { id } }"
+    validation:
+      graphql:
+        error_codes: [BAD_REQUEST]
+`, server.URL)
+	app, err := newTestApp("graphql", scenario)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := app.Play(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	if assert.Len(t, result.StepResults, 2) {
+		assert.Equal(t, server.URL+"?name=bob", result.StepResults[1].URL)
+	}
+}
+
+func contractDocument(path string) []byte {
+	return []byte(fmt.Sprintf(`openapi: 3.0.0
+paths:
+  %s:
+    get:
+      responses:
+        200:
+          description: ok
+`, path))
+}
+
+func TestContract(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name              string
+		openAPI           string
+		opts              []Opts
+		expectedAssertion string
+		expectedSuccess   bool
+	}{
+		{
+			name:              "contract of the options",
+			opts:              []Opts{WithContract("project.yaml", contractDocument("/users"))},
+			expectedAssertion: "contract",
+			expectedSuccess:   true,
+		},
+		{
+			name:              "contract referenced by the scenario overrides the contract of the options",
+			openAPI:           "openapi: scenario.yaml\n",
+			opts:              []Opts{WithContract("project.yaml", contractDocument("/users"))},
+			expectedAssertion: "operation",
+			expectedSuccess:   false,
+		},
+		{
+			name:            "no contract",
+			expectedSuccess: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := fmt.Sprintf(`version: v1
+type: http
+%ssteps:
+  - name: users
+    request:
+      method: GET
+      url: %s/users
+    validation:
+      status:
+        assertion: equal
+        value: "200"
+`, tt.openAPI, server.URL)
+			opts := append([]Opts{
+				WithResourceReader(func(name string) ([]byte, error) {
+					return contractDocument("/projects"), nil
+				}),
+			}, tt.opts...)
+			app, err := newTestApp("contract", scenario, opts...)
+			assert.NoError(t, err)
+
+			result, err := app.Play(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSuccess, result.Success)
+			assertionResults := result.StepResults[0].AssertionResults
+			if tt.expectedAssertion == "" {
+				assert.Len(t, assertionResults, 1)
+				return
+			}
+			assert.Len(t, assertionResults, 2)
+			assert.Equal(t, tt.expectedAssertion, string(assertionResults[1].Assertion))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		matrix       string
+		expectedURLs []string
+		expectedErr  error
+	}{
+		{
+			name:         "without matrix",
+			expectedURLs: []string{"http://localhost/users/${variables.id}"},
+		},
+		{
+			name:         "every row of the matrix",
+			matrix:       "matrix:\n  rows:\n    - id: \"1\"\n    - id: \"2\"\n",
+			expectedURLs: []string{"http://localhost/users/1", "http://localhost/users/2"},
+		},
+		{
+			name:         "without rows",
+			matrix:       "matrix:\n  rows: []\n",
+			expectedURLs: []string{},
+			expectedErr:  ErrEmptyMatrix,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarios, err := Load("users", WithReader(bytes.NewBufferString(`version: v1
+type: http
+`+tt.matrix+`steps:
+  - name: user
+    request:
+      method: GET
+      url: http://localhost/users/${variables.id}
+`)))
+			assert.ErrorIs(t, err, tt.expectedErr)
+			urls := []string{}
+			for _, scenario := range scenarios {
+				urls = append(urls, scenario.Steps[0].Request.URL)
+			}
+			assert.Equal(t, tt.expectedURLs, urls)
+		})
+	}
+}
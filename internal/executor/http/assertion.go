@@ -0,0 +1,180 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+)
+
+type valueType string
+
+// Different value types which can be asserted with the type assertion method.
+const (
+	ValueTypeString valueType = "string"
+	ValueTypeNumber valueType = "number"
+	ValueTypeBool   valueType = "bool"
+	ValueTypeArray  valueType = "array"
+	ValueTypeObject valueType = "object"
+	ValueTypeNull   valueType = "null"
+)
+
+// assertionSubject is the value of a response on which an assertion is performed.
+type assertionSubject struct {
+	value     string
+	valueType valueType
+	length    int
+	exists    bool
+}
+
+func bodySubject(body []byte, key string) *assertionSubject {
+	jsonValue := gjson.GetBytes(body, key)
+	subject := &assertionSubject{
+		value:  jsonValue.String(),
+		exists: jsonValue.Exists(),
+		length: utf8.RuneCountInString(jsonValue.String()),
+	}
+	switch {
+	case jsonValue.IsArray():
+		subject.valueType = ValueTypeArray
+		subject.length = len(jsonValue.Array())
+	case jsonValue.IsObject():
+		subject.valueType = ValueTypeObject
+		subject.length = len(jsonValue.Map())
+	case jsonValue.IsBool():
+		subject.valueType = ValueTypeBool
+	case jsonValue.Type == gjson.Number:
+		subject.valueType = ValueTypeNumber
+	case jsonValue.Type == gjson.Null:
+		subject.valueType = ValueTypeNull
+	default:
+		subject.valueType = ValueTypeString
+	}
+	return subject
+}
+
+func headerSubject(headers http.Header, key string) *assertionSubject {
+	value := headers.Get(key)
+	return &assertionSubject{
+		value:     value,
+		valueType: ValueTypeString,
+		length:    utf8.RuneCountInString(value),
+		exists:    len(headers.Values(key)) > 0,
+	}
+}
+
+func statusSubject(status int) *assertionSubject {
+	value := strconv.Itoa(status)
+	return &assertionSubject{
+		value:     value,
+		valueType: ValueTypeNumber,
+		length:    len(value),
+		exists:    true,
+	}
+}
+
+// assertFunc asserts the subject against the assertion. If the assertion fails
+// a message describing the failure is returned.
+type assertFunc func(subject *assertionSubject, assertion *Assertion) (bool, string)
+
+func assertFuncs() map[assertionMethod]assertFunc {
+	funcs := map[assertionMethod]assertFunc{
+		AssertionMethodEqual:      assertEqual,
+		AssertionMethodNotEqual:   assertNotEqual,
+		AssertionMethodRegex:      assertRegex,
+		AssertionMethodNotEmpty:   assertNotEmpty,
+		AssertionMethodContains:   assertStringFunc(strings.Contains, "contain"),
+		AssertionMethodStartsWith: assertStringFunc(strings.HasPrefix, "start with"),
+		AssertionMethodEndsWith:   assertStringFunc(strings.HasSuffix, "end with"),
+		AssertionMethodIn:         assertIn,
+		AssertionMethodExists:     assertExists,
+		AssertionMethodNotExists:  assertNotExists,
+		AssertionMethodLength:     assertLength,
+		AssertionMethodType:       assertType,
+	}
+	for method, comparison := range numberComparisons() {
+		funcs[method] = assertNumberFunc(comparison)
+	}
+	return funcs
+}
+
+// numberComparison compares a value to the expected value of an assertion.
+type numberComparison struct {
+	compare     func(a, b float64) bool
+	description string
+}
+
+func numberComparisons() map[assertionMethod]numberComparison {
+	return map[assertionMethod]numberComparison{
+		AssertionMethodGreaterThan:        {func(a, b float64) bool { return a > b }, "greater than"},
+		AssertionMethodGreaterThanOrEqual: {func(a, b float64) bool { return a >= b }, "greater than or equal to"},
+		AssertionMethodLessThan:           {func(a, b float64) bool { return a < b }, "less than"},
+		AssertionMethodLessThanOrEqual:    {func(a, b float64) bool { return a <= b }, "less than or equal to"},
+	}
+}
+
+func assertEqual(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	return subject.value == assertion.Value, fmt.Sprintf("has value %s, expected %s", subject.value, assertion.Value)
+}
+
+func assertNotEqual(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	return subject.value != assertion.Value, fmt.Sprintf("has value %s, expected a value other than %s", subject.value, assertion.Value)
+}
+
+func assertRegex(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	re, err := regexp.Compile(assertion.Value)
+	if err != nil {
+		return false, fmt.Sprintf("can not be matched against invalid regex %s: %s", assertion.Value, err)
+	}
+	return re.MatchString(subject.value), fmt.Sprintf("has value %s, expected to match %s", subject.value, assertion.Value)
+}
+
+func assertNotEmpty(subject *assertionSubject, _ *Assertion) (bool, string) {
+	return subject.value != "", "is empty"
+}
+
+func assertStringFunc(f func(s, substr string) bool, description string) assertFunc {
+	return func(subject *assertionSubject, assertion *Assertion) (bool, string) {
+		return f(subject.value, assertion.Value), fmt.Sprintf("has value %s, expected to %s %s", subject.value, description, assertion.Value)
+	}
+}
+
+func assertNumberFunc(comparison numberComparison) assertFunc {
+	return func(subject *assertionSubject, assertion *Assertion) (bool, string) {
+		value, err := strconv.ParseFloat(subject.value, 64)
+		if err != nil {
+			return false, fmt.Sprintf("has non numeric value %s", subject.value)
+		}
+		expected, err := strconv.ParseFloat(assertion.Value, 64)
+		if err != nil {
+			return false, fmt.Sprintf("can not be compared to non numeric value %s", assertion.Value)
+		}
+		return comparison.compare(value, expected), fmt.Sprintf("has value %s, expected %s %s", subject.value, comparison.description, assertion.Value)
+	}
+}
+
+func assertIn(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	return slices.Contains(assertion.Values, subject.value),
+		fmt.Sprintf("has value %s, expected one of [%s]", subject.value, strings.Join(assertion.Values, ", "))
+}
+
+func assertExists(subject *assertionSubject, _ *Assertion) (bool, string) {
+	return subject.exists, "not found"
+}
+
+func assertNotExists(subject *assertionSubject, _ *Assertion) (bool, string) {
+	return !subject.exists, "exists, expected it to be absent"
+}
+
+func assertLength(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	return strconv.Itoa(subject.length) == assertion.Value, fmt.Sprintf("has length %d, expected %s", subject.length, assertion.Value)
+}
+
+func assertType(subject *assertionSubject, assertion *Assertion) (bool, string) {
+	return string(subject.valueType) == assertion.Value, fmt.Sprintf("has type %s, expected %s", subject.valueType, assertion.Value)
+}
@@ -0,0 +1,363 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssertFuncs(t *testing.T) {
+	body := []byte(`{
+		"name": "alice",
+		"email": "alice@example.com",
+		"age": 42,
+		"score": 9.5,
+		"admin": true,
+		"roles": ["admin", "user"],
+		"address": {"city": "Berlin", "zip": "10115"},
+		"manager": null,
+		"emoji": "héllo"
+	}`)
+
+	tests := []struct {
+		name            string
+		key             string
+		assertion       *Assertion
+		expectedSuccess bool
+		expectedMessage string
+	}{
+		{
+			name:            "equal",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodEqual, Value: "alice"},
+			expectedSuccess: true,
+			expectedMessage: "has value alice, expected alice",
+		},
+		{
+			name:            "equal fails",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodEqual, Value: "bob"},
+			expectedMessage: "has value alice, expected bob",
+		},
+		{
+			name:            "not equal",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodNotEqual, Value: "alice"},
+			expectedMessage: "has value alice, expected a value other than alice",
+		},
+		{
+			name:            "regex",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodRegex, Value: `^[a-z]+@example\.com$`},
+			expectedSuccess: true,
+			expectedMessage: `has value alice@example.com, expected to match ^[a-z]+@example\.com$`,
+		},
+		{
+			name:            "regex does not match",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodRegex, Value: `^[0-9]+$`},
+			expectedMessage: "has value alice@example.com, expected to match ^[0-9]+$",
+		},
+		{
+			name:            "invalid regex",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodRegex, Value: `(alice`},
+			expectedMessage: "can not be matched against invalid regex (alice: error parsing regexp: missing closing ): `(alice`",
+		},
+		{
+			name:            "not empty",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodNotEmpty},
+			expectedSuccess: true,
+			expectedMessage: "is empty",
+		},
+		{
+			name:            "not empty of a missing key",
+			key:             "missing",
+			assertion:       &Assertion{Assertion: AssertionMethodNotEmpty},
+			expectedMessage: "is empty",
+		},
+		{
+			name:            "contains",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodContains, Value: "@example"},
+			expectedSuccess: true,
+			expectedMessage: "has value alice@example.com, expected to contain @example",
+		},
+		{
+			name:            "contains fails",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodContains, Value: "bob"},
+			expectedMessage: "has value alice@example.com, expected to contain bob",
+		},
+		{
+			name:            "starts with",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodStartsWith, Value: "alice"},
+			expectedSuccess: true,
+			expectedMessage: "has value alice@example.com, expected to start with alice",
+		},
+		{
+			name:            "ends with",
+			key:             "email",
+			assertion:       &Assertion{Assertion: AssertionMethodEndsWith, Value: ".org"},
+			expectedMessage: "has value alice@example.com, expected to end with .org",
+		},
+		{
+			name:            "greater than",
+			key:             "age",
+			assertion:       &Assertion{Assertion: AssertionMethodGreaterThan, Value: "41"},
+			expectedSuccess: true,
+			expectedMessage: "has value 42, expected greater than 41",
+		},
+		{
+			name:            "greater than an equal value",
+			key:             "age",
+			assertion:       &Assertion{Assertion: AssertionMethodGreaterThan, Value: "42"},
+			expectedMessage: "has value 42, expected greater than 42",
+		},
+		{
+			name:            "greater than or equal",
+			key:             "age",
+			assertion:       &Assertion{Assertion: AssertionMethodGreaterThanOrEqual, Value: "42"},
+			expectedSuccess: true,
+			expectedMessage: "has value 42, expected greater than or equal to 42",
+		},
+		{
+			name:            "less than with decimals",
+			key:             "score",
+			assertion:       &Assertion{Assertion: AssertionMethodLessThan, Value: "9.75"},
+			expectedSuccess: true,
+			expectedMessage: "has value 9.5, expected less than 9.75",
+		},
+		{
+			name:            "less than or equal",
+			key:             "score",
+			assertion:       &Assertion{Assertion: AssertionMethodLessThanOrEqual, Value: "9"},
+			expectedMessage: "has value 9.5, expected less than or equal to 9",
+		},
+		{
+			name:            "greater than on a string",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodGreaterThan, Value: "1"},
+			expectedMessage: "has non numeric value alice",
+		},
+		{
+			name:            "less than a string",
+			key:             "age",
+			assertion:       &Assertion{Assertion: AssertionMethodLessThan, Value: "old"},
+			expectedMessage: "can not be compared to non numeric value old",
+		},
+		{
+			name:            "greater than on a missing key",
+			key:             "missing",
+			assertion:       &Assertion{Assertion: AssertionMethodGreaterThan, Value: "0"},
+			expectedMessage: "has non numeric value ",
+		},
+		{
+			name:            "in",
+			key:             "address.city",
+			assertion:       &Assertion{Assertion: AssertionMethodIn, Values: []string{"Paris", "Berlin"}},
+			expectedSuccess: true,
+			expectedMessage: "has value Berlin, expected one of [Paris, Berlin]",
+		},
+		{
+			name:            "in fails",
+			key:             "address.city",
+			assertion:       &Assertion{Assertion: AssertionMethodIn, Values: []string{"Paris", "Rome"}},
+			expectedMessage: "has value Berlin, expected one of [Paris, Rome]",
+		},
+		{
+			name:            "exists",
+			key:             "address.zip",
+			assertion:       &Assertion{Assertion: AssertionMethodExists},
+			expectedSuccess: true,
+			expectedMessage: "not found",
+		},
+		{
+			name:            "null exists",
+			key:             "manager",
+			assertion:       &Assertion{Assertion: AssertionMethodExists},
+			expectedSuccess: true,
+			expectedMessage: "not found",
+		},
+		{
+			name:            "exists fails",
+			key:             "address.street",
+			assertion:       &Assertion{Assertion: AssertionMethodExists},
+			expectedMessage: "not found",
+		},
+		{
+			name:            "not exists",
+			key:             "address.street",
+			assertion:       &Assertion{Assertion: AssertionMethodNotExists},
+			expectedSuccess: true,
+			expectedMessage: "exists, expected it to be absent",
+		},
+		{
+			name:            "not exists fails",
+			key:             "name",
+			assertion:       &Assertion{Assertion: AssertionMethodNotExists},
+			expectedMessage: "exists, expected it to be absent",
+		},
+		{
+			name:            "length of a string in characters",
+			key:             "emoji",
+			assertion:       &Assertion{Assertion: AssertionMethodLength, Value: "5"},
+			expectedSuccess: true,
+			expectedMessage: "has length 5, expected 5",
+		},
+		{
+			name:            "length of an array",
+			key:             "roles",
+			assertion:       &Assertion{Assertion: AssertionMethodLength, Value: "2"},
+			expectedSuccess: true,
+			expectedMessage: "has length 2, expected 2",
+		},
+		{
+			name:            "length of an object",
+			key:             "address",
+			assertion:       &Assertion{Assertion: AssertionMethodLength, Value: "3"},
+			expectedMessage: "has length 2, expected 3",
+		},
+		{
+			name:            "type",
+			key:             "admin",
+			assertion:       &Assertion{Assertion: AssertionMethodType, Value: "bool"},
+			expectedSuccess: true,
+			expectedMessage: "has type bool, expected bool",
+		},
+		{
+			name:            "type mismatch",
+			key:             "age",
+			assertion:       &Assertion{Assertion: AssertionMethodType, Value: "string"},
+			expectedMessage: "has type number, expected string",
+		},
+		{
+			name:            "type of a missing key",
+			key:             "missing",
+			assertion:       &Assertion{Assertion: AssertionMethodType, Value: "null"},
+			expectedSuccess: true,
+			expectedMessage: "has type null, expected null",
+		},
+	}
+	funcs := assertFuncs()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := funcs[tt.assertion.Assertion]
+			if !assert.True(t, ok) {
+				return
+			}
+			success, message := f(bodySubject(body, tt.key), tt.assertion)
+			assert.Equal(t, tt.expectedSuccess, success)
+			assert.Equal(t, tt.expectedMessage, message)
+		})
+	}
+}
+
+func TestBodySubject(t *testing.T) {
+	body := []byte(`{"name": "héllo", "age": 42, "admin": false, "roles": ["a", "b", "c"], "address": {"city": "Berlin"}, "manager": null}`)
+
+	tests := []struct {
+		name     string
+		key      string
+		expected *assertionSubject
+	}{
+		{
+			name:     "string",
+			key:      "name",
+			expected: &assertionSubject{value: "héllo", valueType: ValueTypeString, length: 5, exists: true},
+		},
+		{
+			name:     "number",
+			key:      "age",
+			expected: &assertionSubject{value: "42", valueType: ValueTypeNumber, length: 2, exists: true},
+		},
+		{
+			name:     "bool",
+			key:      "admin",
+			expected: &assertionSubject{value: "false", valueType: ValueTypeBool, length: 5, exists: true},
+		},
+		{
+			name:     "array",
+			key:      "roles",
+			expected: &assertionSubject{value: `["a", "b", "c"]`, valueType: ValueTypeArray, length: 3, exists: true},
+		},
+		{
+			name:     "element of an array",
+			key:      "roles.1",
+			expected: &assertionSubject{value: "b", valueType: ValueTypeString, length: 1, exists: true},
+		},
+		{
+			name:     "object",
+			key:      "address",
+			expected: &assertionSubject{value: `{"city": "Berlin"}`, valueType: ValueTypeObject, length: 1, exists: true},
+		},
+		{
+			name:     "null",
+			key:      "manager",
+			expected: &assertionSubject{valueType: ValueTypeNull, exists: true},
+		},
+		{
+			name:     "missing",
+			key:      "address.street",
+			expected: &assertionSubject{valueType: ValueTypeNull},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, bodySubject(body, tt.key))
+		})
+	}
+}
+
+func TestHeaderSubject(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set("X-Empty", "")
+
+	tests := []struct {
+		name     string
+		key      string
+		expected *assertionSubject
+	}{
+		{
+			name:     "header",
+			key:      "Content-Type",
+			expected: &assertionSubject{value: "application/json", valueType: ValueTypeString, length: 16, exists: true},
+		},
+		{
+			name:     "name is case insensitive",
+			key:      "content-type",
+			expected: &assertionSubject{value: "application/json", valueType: ValueTypeString, length: 16, exists: true},
+		},
+		{
+			name:     "empty header",
+			key:      "X-Empty",
+			expected: &assertionSubject{valueType: ValueTypeString, exists: true},
+		},
+		{
+			name:     "missing header",
+			key:      "X-Missing",
+			expected: &assertionSubject{valueType: ValueTypeString},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, headerSubject(headers, tt.key))
+		})
+	}
+}
+
+func TestStatusSubject(t *testing.T) {
+	subject := statusSubject(http.StatusNoContent)
+	assert.Equal(t, &assertionSubject{value: "204", valueType: ValueTypeNumber, length: 3, exists: true}, subject)
+
+	success, message := assertFuncs()[AssertionMethodLessThan](subject, &Assertion{Assertion: AssertionMethodLessThan, Value: "300"})
+	assert.True(t, success)
+	assert.Equal(t, "has value 204, expected less than 300", message)
+	success, message = assertFuncs()[AssertionMethodIn](subject, &Assertion{Assertion: AssertionMethodIn, Values: []string{"200", "201"}})
+	assert.False(t, success)
+	assert.Equal(t, "has value 204, expected one of [200, 201]", message)
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"fmt"
+)
+
+// ErrInvalidAttachment is an error for when a file attached to a step or the scenario can not be loaded.
+type ErrInvalidAttachment struct {
+	StepName string
+	File     string
+	Err      error
+}
+
+func (e ErrInvalidAttachment) Error() string {
+	// Attachments of the scenario, such as the private key of its authentication, are not loaded for a step.
+	if e.StepName == "" {
+		return fmt.Sprintf("unable to load attachment %s: %s", e.File, e.Err)
+	}
+	return fmt.Sprintf("unable to load attachment %s for step %s: %s", e.File, e.StepName, e.Err)
+}
+
+func (e ErrInvalidAttachment) Unwrap() error {
+	return e.Err
+}
+
+// loadAttachments loads the content of the files attached to the multipart bodies of all steps of the
+// scenario, and the private keys of the JWT authentication of the scenario and its steps.
+func (s Scenario) loadAttachments(load AttachmentLoader) error {
+	err := s.Auth.loadPrivateKey("", load)
+	if err != nil {
+		return err
+	}
+	for _, step := range s.allSteps() {
+		err = step.Auth.loadPrivateKey(step.Name, load)
+		if err != nil {
+			return err
+		}
+		if step.Request == nil {
+			continue
+		}
+		for _, part := range step.Request.Multipart {
+			err = part.loadContent(step.Name, load)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Part) loadContent(stepName string, load AttachmentLoader) error {
+	if p.File == "" {
+		return nil
+	}
+	content, err := loadAttachment(stepName, p.File, load)
+	if err != nil {
+		return err
+	}
+	p.Content = content
+	return nil
+}
+
+func (a *Auth) loadPrivateKey(stepName string, load AttachmentLoader) error {
+	if a == nil || a.JWT == nil || a.JWT.PrivateKeyFile == "" {
+		return nil
+	}
+	privateKey, err := loadAttachment(stepName, a.JWT.PrivateKeyFile, load)
+	if err != nil {
+		return err
+	}
+	a.JWT.PrivateKey = privateKey
+	return nil
+}
+
+func loadAttachment(stepName, file string, load AttachmentLoader) ([]byte, error) {
+	if load == nil {
+		return nil, ErrInvalidAttachment{StepName: stepName, File: file, Err: fmt.Errorf("no attachment loader")}
+	}
+	content, err := load(file)
+	if err != nil {
+		return nil, ErrInvalidAttachment{StepName: stepName, File: file, Err: err}
+	}
+	return content, nil
+}
@@ -0,0 +1,216 @@
+package http
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Different authentication settings.
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypePassword          = "password"
+	AlgorithmHS256             = "HS256"
+	AlgorithmRS256             = "RS256"
+	EncodingHex                = "hex"
+	EncodingBase64             = "base64"
+
+	defaultSignatureHeader = "X-Signature"
+)
+
+// ErrAuthFailed is an error for when the request of a step can not be authenticated.
+type ErrAuthFailed struct {
+	StepName, Msg string
+}
+
+func (e ErrAuthFailed) Error() string {
+	return fmt.Sprintf("unable to authenticate request of step %s: %s", e.StepName, e.Msg)
+}
+
+// authClient authenticates requests before they are sent.
+type authClient struct {
+	client   Client
+	auth     *Auth
+	tokens   *tokenCache
+	stepName string
+}
+
+// clientFor returns the client for the requests of the step, which authenticates the
+// requests with the authentication of the step or else the authentication of the scenario.
+func (e Executor) clientFor(step *Step) Client {
+	auth := e.scenario.Auth
+	if step.Auth != nil {
+		auth = step.Auth
+	}
+	if auth == nil || auth.None {
+		return e.httpClient
+	}
+	return &authClient{client: e.httpClient, auth: auth, tokens: e.tokens, stepName: step.Name}
+}
+
+func (c *authClient) Do(req *http.Request) (*http.Response, error) {
+	err := c.authenticate(req)
+	if err != nil {
+		return nil, ErrAuthFailed{StepName: c.stepName, Msg: err.Error()}
+	}
+	return c.client.Do(req)
+}
+
+func (c *authClient) authenticate(req *http.Request) error {
+	switch {
+	case c.auth.Basic != nil:
+		req.SetBasicAuth(c.auth.Basic.Username, c.auth.Basic.Password)
+	case c.auth.Bearer != "":
+		req.Header.Set("Authorization", "Bearer "+c.auth.Bearer)
+	case c.auth.OAuth2 != nil:
+		token, err := c.tokens.token(req.Context(), c.client, c.auth.OAuth2)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	case c.auth.JWT != nil:
+		return c.auth.JWT.authenticate(req, time.Now())
+	case c.auth.HMAC != nil:
+		return c.auth.HMAC.sign(req, time.Now())
+	}
+	return nil
+}
+
+func (j *JWT) authenticate(req *http.Request, now time.Time) error {
+	token, err := j.sign(now)
+	if err != nil {
+		return err
+	}
+	if j.Header == "" || strings.EqualFold(j.Header, "Authorization") {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	req.Header.Set(j.Header, token)
+	return nil
+}
+
+// sign returns the signed token. The iat claim and, if ExpiresIn is set, the exp claim
+// are set to the time of signing, unless they are defined as claims.
+func (j *JWT) sign(now time.Time) (string, error) {
+	claims := map[string]any{"iat": now.Unix()}
+	if j.ExpiresIn > 0 {
+		claims["exp"] = now.Add(j.ExpiresIn).Unix()
+	}
+	for name, value := range j.Claims {
+		claims[name] = value
+	}
+	header, err := json.Marshal(map[string]string{"alg": j.Algorithm, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature, err := j.signature([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (j *JWT) signature(signingInput []byte) ([]byte, error) {
+	switch j.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, []byte(j.Secret))
+		_, _ = mac.Write(signingInput)
+		return mac.Sum(nil), nil
+	case AlgorithmRS256:
+		key, err := parseRSAPrivateKey(j.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(signingInput)
+		return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm %s", j.Algorithm)
+	}
+}
+
+// parseRSAPrivateKey parses a PEM encoded RSA private key in PKCS #1 or PKCS #8 format.
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+func (h *HMAC) sign(req *http.Request, now time.Time) error {
+	body, err := requestBody(req)
+	if err != nil {
+		return err
+	}
+	content := []string{req.Method, req.URL.RequestURI()}
+	if h.TimestampHeader != "" {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		req.Header.Set(h.TimestampHeader, timestamp)
+		content = append(content, timestamp)
+	}
+	content = append(content, string(body))
+	mac := hmac.New(h.hash(), []byte(h.Key))
+	_, _ = mac.Write([]byte(strings.Join(content, "\n")))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	if h.Encoding == EncodingBase64 {
+		signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	header := h.Header
+	if header == "" {
+		header = defaultSignatureHeader
+	}
+	req.Header.Set(header, signature)
+	return nil
+}
+
+func (h *HMAC) hash() func() hash.Hash {
+	if strings.EqualFold(h.Algorithm, "sha512") {
+		return sha512.New
+	}
+	return sha256.New
+}
+
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+	return io.ReadAll(body)
+}
@@ -0,0 +1,390 @@
+package http
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"hash"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// decodeJWT returns the header, the claims and the signature of a token, and its signing input.
+func decodeJWT(t *testing.T, token string) (map[string]any, map[string]any, []byte, string) {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	if !assert.Len(t, parts, 3) {
+		t.FailNow()
+	}
+	header, claims := map[string]any{}, map[string]any{}
+	for i, v := range []map[string]any{header, claims} {
+		b, err := base64.RawURLEncoding.DecodeString(parts[i])
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &v))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	return header, claims, signature, parts[0] + "." + parts[1]
+}
+
+func TestJWTSignHS256(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name           string
+		jwt            *JWT
+		expectedClaims map[string]any
+	}{
+		{
+			name:           "issued at",
+			jwt:            &JWT{Algorithm: AlgorithmHS256, Secret: "secret"},
+			expectedClaims: map[string]any{"iat": float64(1700000000)},
+		},
+		{
+			name: "expiry and claims",
+			jwt: &JWT{
+				Algorithm: AlgorithmHS256,
+				Secret:    "secret",
+				ExpiresIn: time.Hour,
+				Claims:    map[string]any{"sub": "user", "roles": []any{"admin"}},
+			},
+			expectedClaims: map[string]any{"iat": float64(1700000000), "exp": float64(1700003600), "sub": "user", "roles": []any{"admin"}},
+		},
+		{
+			name: "claims override the issued at time",
+			jwt: &JWT{
+				Algorithm: AlgorithmHS256,
+				Secret:    "secret",
+				Claims:    map[string]any{"iat": 1},
+			},
+			expectedClaims: map[string]any{"iat": float64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.jwt.sign(now)
+			assert.NoError(t, err)
+			header, claims, signature, signingInput := decodeJWT(t, token)
+			assert.Equal(t, map[string]any{"alg": "HS256", "typ": "JWT"}, header)
+			assert.Equal(t, tt.expectedClaims, claims)
+			mac := hmac.New(sha256.New, []byte("secret"))
+			_, _ = mac.Write([]byte(signingInput))
+			assert.Equal(t, mac.Sum(nil), signature)
+		})
+	}
+}
+
+func TestJWTSignRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if !assert.NoError(t, err) {
+		return
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		privateKey  []byte
+		expectedErr string
+	}{
+		{
+			name:       "PKCS #1 key",
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+		{
+			name:       "PKCS #8 key",
+			privateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		},
+		{
+			name:        "key not PEM encoded",
+			privateKey:  []byte("key"),
+			expectedErr: "private key is not PEM encoded",
+		},
+		{
+			name:        "key not an RSA key",
+			privateKey:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8}),
+			expectedErr: "private key is not an RSA key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwt := &JWT{Algorithm: AlgorithmRS256, PrivateKey: tt.privateKey}
+			token, err := jwt.sign(time.Now())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			header, _, signature, signingInput := decodeJWT(t, token)
+			assert.Equal(t, "RS256", header["alg"])
+			digest := sha256.Sum256([]byte(signingInput))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+		})
+	}
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	tests := []struct {
+		name           string
+		jwt            *JWT
+		expectedHeader string
+		expectedPrefix string
+		expectedErr    string
+	}{
+		{
+			name:           "bearer token",
+			jwt:            &JWT{Algorithm: AlgorithmHS256, Secret: "secret"},
+			expectedHeader: "Authorization",
+			expectedPrefix: "Bearer ey",
+		},
+		{
+			name:           "custom header",
+			jwt:            &JWT{Algorithm: AlgorithmHS256, Secret: "secret", Header: "X-Token"},
+			expectedHeader: "X-Token",
+			expectedPrefix: "ey",
+		},
+		{
+			name:        "unsupported algorithm",
+			jwt:         &JWT{Algorithm: "none"},
+			expectedErr: "unsupported JWT algorithm none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost/users", nil)
+			err := tt.jwt.authenticate(req, time.Now())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(req.Header.Get(tt.expectedHeader), tt.expectedPrefix))
+		})
+	}
+}
+
+func TestHMACSign(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	sum := func(newHash func() hash.Hash, content string) []byte {
+		m := hmac.New(newHash, []byte("key"))
+		_, _ = m.Write([]byte(content))
+		return m.Sum(nil)
+	}
+
+	tests := []struct {
+		name              string
+		hmac              *HMAC
+		expectedHeaders   map[string]string
+		unexpectedHeaders []string
+	}{
+		{
+			name: "hex encoded sha256 in the default header",
+			hmac: &HMAC{Key: "key"},
+			expectedHeaders: map[string]string{
+				"X-Signature": hex.EncodeToString(sum(sha256.New, "POST\n/users?page=1\n{\"name\":\"test\"}")),
+			},
+			unexpectedHeaders: []string{"X-Timestamp"},
+		},
+		{
+			name: "base64 encoded sha512 with timestamp",
+			hmac: &HMAC{Key: "key", Algorithm: "SHA512", Encoding: EncodingBase64, Header: "X-Hmac", TimestampHeader: "X-Timestamp"},
+			expectedHeaders: map[string]string{
+				"X-Hmac":      base64.StdEncoding.EncodeToString(sum(sha512.New, "POST\n/users?page=1\n1700000000\n{\"name\":\"test\"}")),
+				"X-Timestamp": "1700000000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "http://localhost/users?page=1", strings.NewReader(`{"name":"test"}`))
+			assert.NoError(t, err)
+			err = tt.hmac.sign(req, now)
+			assert.NoError(t, err)
+			for name, value := range tt.expectedHeaders {
+				assert.Equal(t, value, req.Header.Get(name), name)
+			}
+			for _, name := range tt.unexpectedHeaders {
+				assert.Empty(t, req.Header.Get(name), name)
+			}
+		})
+	}
+}
+
+// tokenServer is an OAuth2 token endpoint, which records the grant types of the token requests.
+type tokenServer struct {
+	*httptest.Server
+	grants    []string
+	expiresIn int
+}
+
+func newTokenServer(t *testing.T, expiresIn int) *tokenServer {
+	s := &tokenServer{expiresIn: expiresIn}
+	s.Server = newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			assert.NoError(t, r.ParseForm())
+			grant := r.PostForm.Get("grant_type")
+			s.grants = append(s.grants, grant)
+			clientID, clientSecret, _ := r.BasicAuth()
+			if clientID != "client" || clientSecret != "s3cret" || (grant == "refresh_token" && r.PostForm.Get("refresh_token") != "refresh") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if grant == GrantTypePassword && (r.PostForm.Get("username") != "user" || r.PostForm.Get("password") != "pass") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "token-" + r.PostForm.Get("scope"),
+				"refresh_token": "refresh",
+				"expires_in":    s.expiresIn,
+			})
+		default:
+			w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		}
+	})
+	return s
+}
+
+func TestOAuth2Token(t *testing.T) {
+	tests := []struct {
+		name           string
+		expiresIn      int
+		oauth2         OAuth2
+		expectedToken  string
+		expectedGrants []string
+		expectedErr    string
+	}{
+		{
+			name:           "client credentials are cached",
+			expiresIn:      3600,
+			oauth2:         OAuth2{GrantType: GrantTypeClientCredentials, ClientID: "client", ClientSecret: "s3cret", Scopes: []string{"read", "write"}},
+			expectedToken:  "token-read write",
+			expectedGrants: []string{"client_credentials"},
+		},
+		{
+			name:           "expiring token is refreshed",
+			expiresIn:      10,
+			oauth2:         OAuth2{GrantType: GrantTypePassword, ClientID: "client", ClientSecret: "s3cret", Username: "user", Password: "pass"},
+			expectedToken:  "token-",
+			expectedGrants: []string{"password", "refresh_token"},
+		},
+		{
+			name:        "token endpoint error",
+			oauth2:      OAuth2{GrantType: GrantTypeClientCredentials, ClientID: "client", ClientSecret: "wrong"},
+			expectedErr: "token endpoint returned status 401: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTokenServer(t, tt.expiresIn)
+			tt.oauth2.TokenURL = server.URL + "/token"
+			tokens := newTokenCache()
+
+			for i := 0; i < 2; i++ {
+				token, err := tokens.token(context.Background(), server.Client(), &tt.oauth2)
+				if tt.expectedErr != "" {
+					assert.EqualError(t, err, tt.expectedErr)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedToken, token)
+			}
+			assert.Equal(t, tt.expectedGrants, server.grants)
+		})
+	}
+}
+
+func TestOAuth2TokenWithoutAccessToken(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"error": "invalid_scope"}`))
+	})
+
+	_, err := newTokenCache().token(context.Background(), server.Client(), &OAuth2{GrantType: GrantTypeClientCredentials, TokenURL: server.URL})
+	assert.EqualError(t, err, `token endpoint returned no access token: {"error": "invalid_scope"}`)
+}
+
+func TestPlayAuth(t *testing.T) {
+	server := newTokenServer(t, 3600)
+
+	authorization := func(value string) *Validation {
+		return &Validation{Headers: []*Assertion{{Key: "X-Authorization", Assertion: AssertionMethodEqual, Value: value}}}
+	}
+	scenario := &Scenario{
+		Name: "auth",
+		Auth: &Auth{OAuth2: &OAuth2{GrantType: GrantTypeClientCredentials, TokenURL: server.URL + "/token", ClientID: "client", ClientSecret: "s3cret"}},
+		Steps: []*Step{
+			{
+				Name:       "scenario auth",
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+				Validation: authorization("Bearer token-"),
+			},
+			{
+				Name:       "step auth",
+				Auth:       &Auth{Basic: &BasicAuth{Username: "user", Password: "pass"}},
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+				Validation: authorization("Basic dXNlcjpwYXNz"),
+			},
+			{
+				Name:       "no auth",
+				Auth:       &Auth{None: true},
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+				Validation: authorization(""),
+			},
+			{
+				Name:       "bearer",
+				Auth:       &Auth{Bearer: "abc"},
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+				Validation: authorization("Bearer abc"),
+			},
+			{
+				Name:       "scenario auth again",
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+				Validation: authorization("Bearer token-"),
+			},
+		},
+	}
+
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	// the token is fetched once per execution of the scenario.
+	assert.Equal(t, []string{"client_credentials"}, server.grants)
+}
+
+func TestPlayAuthFailed(t *testing.T) {
+	server := newTokenServer(t, 3600)
+
+	scenario := &Scenario{
+		Name: "auth",
+		Steps: []*Step{{
+			Name:       "users",
+			Auth:       &Auth{OAuth2: &OAuth2{GrantType: GrantTypeClientCredentials, TokenURL: server.URL + "/token", ClientID: "client", ClientSecret: "wrong"}},
+			Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users"},
+			Validation: statusValidation("200"),
+		}},
+	}
+
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ErrCaptureFailed is an error for when a value could not be captured
+// from the response of a step.
+type ErrCaptureFailed struct {
+	StepName, Name, Msg string
+}
+
+func (e ErrCaptureFailed) Error() string {
+	return fmt.Sprintf("unable to capture variable %s for step %s: %s", e.Name, e.StepName, e.Msg)
+}
+
+// ErrUndefinedVariable is an error for when a step uses a variable
+// which has not been captured by any of the previous steps.
+type ErrUndefinedVariable struct {
+	StepName, Name string
+}
+
+func (e ErrUndefinedVariable) Error() string {
+	return fmt.Sprintf("step %s uses undefined variable %s", e.StepName, e.Name)
+}
+
+func (e Executor) captureVariables(step *Step) error {
+	for _, capture := range step.Capture {
+		value, err := capture.valueFrom(step.RequestResult)
+		if err != nil {
+			return ErrCaptureFailed{
+				StepName: step.Name,
+				Name:     capture.Name,
+				Msg:      err.Error(),
+			}
+		}
+		e.variables[capture.Name] = value
+	}
+	return nil
+}
+
+// captureFailed marks a step as failed because a value could not be captured, the
+// reason is reported as failed assertion result of the step.
+func captureFailed(stepResult *ExecuteStepResult, err error) {
+	assertionResult := &AssertionResult{
+		Type:      ValidationCapture,
+		Assertion: AssertionMethod("capture"),
+		Message:   err.Error(),
+	}
+	var captureErr ErrCaptureFailed
+	if errors.As(err, &captureErr) {
+		assertionResult.Key = captureErr.Name
+		assertionResult.Message = fmt.Sprintf("unable to capture variable %s: %s", captureErr.Name, captureErr.Msg)
+	}
+	stepResult.AssertionResults = append(stepResult.AssertionResults, assertionResult)
+	stepResult.Assertions = len(stepResult.AssertionResults)
+	stepResult.Success = false
+}
+
+func (c Capture) valueFrom(requestResult *RequestResult) (string, error) {
+	value, err := c.sourceValue(requestResult)
+	if err != nil {
+		return "", err
+	}
+	if c.Regex == "" {
+		return value, nil
+	}
+	re, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return "", err
+	}
+	matches := re.FindStringSubmatch(value)
+	switch {
+	case matches == nil:
+		return "", fmt.Errorf("regex %s does not match %s", c.Regex, value)
+	case len(matches) > 1:
+		return matches[1], nil
+	default:
+		return matches[0], nil
+	}
+}
+
+func (c Capture) sourceValue(requestResult *RequestResult) (string, error) {
+	switch {
+	case c.Body != "":
+		return bodyValue(requestResult.Body, c.Body)
+	case c.Data != "":
+		return bodyValue(requestResult.Body, graphQLDataPath(c.Data))
+	case c.Header != "":
+		if len(requestResult.Headers.Values(c.Header)) == 0 {
+			return "", fmt.Errorf("header %s not found", c.Header)
+		}
+		return requestResult.Headers.Get(c.Header), nil
+	case c.Status:
+		return strconv.Itoa(requestResult.Status), nil
+	case c.Cookie != "":
+		return cookieValue(requestResult.Headers, c.Cookie)
+	default:
+		return string(requestResult.Body), nil
+	}
+}
+
+func bodyValue(body []byte, key string) (string, error) {
+	jsonValue := gjson.GetBytes(body, key)
+	if !jsonValue.Exists() {
+		return "", fmt.Errorf("body key %s not found", key)
+	}
+	return jsonValue.String(), nil
+}
+
+func cookieValue(headers http.Header, name string) (string, error) {
+	cookie := findCookie(headers, name)
+	if cookie == nil {
+		return "", fmt.Errorf("cookie %s not found", name)
+	}
+	return cookie.Value, nil
+}
+
+// replaceVariables replaces all ${vars.<name>} placeholders in the JSON
+// representation of a step with previously captured values.
+func (e Executor) replaceVariables(stepName, stepJSONString string) (string, error) {
+	placeHolders := variablePlaceholders().FindAllStringSubmatch(stepJSONString, -1)
+	for _, placeHolder := range placeHolders {
+		value, ok := e.variables[placeHolder[1]]
+		if !ok {
+			return "", ErrUndefinedVariable{
+				StepName: stepName,
+				Name:     placeHolder[1],
+			}
+		}
+		stepJSONString = strings.ReplaceAll(stepJSONString, placeHolder[0], jsonEscape(value))
+	}
+	return stepJSONString, nil
+}
+
+func variablePlaceholders() *regexp.Regexp {
+	return regexp.MustCompile(`\$\{vars\.([^\}]*)\}`)
+}
+
+// jsonEscape escapes a value such that it can be embedded in a JSON string.
+func jsonEscape(value string) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	return string(b[1 : len(b)-1])
+}
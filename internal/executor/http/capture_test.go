@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayCapture(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "abc-123")
+		if r.URL.Path == "/users/42" {
+			_, _ = w.Write([]byte(`{"name": "bob"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 42, "token": "Bearer xyz"}`))
+	})
+
+	tests := []struct {
+		name                    string
+		capture                 *Capture
+		expectedPath            string
+		expectedAssertionResult *AssertionResult
+	}{
+		{
+			name:         "body",
+			capture:      &Capture{Name: "id", Body: "id"},
+			expectedPath: "/users/42",
+		},
+		{
+			name:         "header with regex",
+			capture:      &Capture{Name: "id", Header: "X-Request-Id", Regex: `-(\d+)$`},
+			expectedPath: "/users/123",
+		},
+		{
+			name:    "body path matching nothing",
+			capture: &Capture{Name: "id", Body: "user.id"},
+			expectedAssertionResult: &AssertionResult{
+				Type:      ValidationCapture,
+				Key:       "id",
+				Assertion: AssertionMethod("capture"),
+				Message:   "unable to capture variable id: body key user.id not found",
+			},
+		},
+		{
+			name:    "regex matching nothing",
+			capture: &Capture{Name: "id", Body: "token", Regex: `^Basic (.+)$`},
+			expectedAssertionResult: &AssertionResult{
+				Type:      ValidationCapture,
+				Key:       "id",
+				Assertion: AssertionMethod("capture"),
+				Message:   "unable to capture variable id: regex ^Basic (.+)$ does not match Bearer xyz",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := &Scenario{
+				Name: "capture",
+				Steps: []*Step{
+					{
+						Name:       "create",
+						Request:    &Request{Method: http.MethodPost, URL: server.URL + "/users"},
+						Validation: statusValidation("200"),
+						Capture:    []*Capture{tt.capture},
+					},
+					{
+						Name:       "get",
+						Request:    &Request{Method: http.MethodGet, URL: server.URL + "/users/${vars.id}"},
+						Validation: statusValidation("200"),
+					},
+				},
+			}
+
+			result, err := newTestExecutor(t, scenario).Play(context.Background())
+			assert.NoError(t, err)
+			if tt.expectedAssertionResult != nil {
+				assert.False(t, result.Success)
+				assert.Len(t, result.StepResults, 1)
+				assert.False(t, result.StepResults[0].Success)
+				assert.Contains(t, result.StepResults[0].AssertionResults, tt.expectedAssertionResult)
+				assert.Equal(t, len(result.StepResults[0].AssertionResults), result.StepResults[0].Assertions)
+				return
+			}
+			assert.Len(t, result.StepResults, 2)
+			assert.True(t, result.Success)
+			assert.Equal(t, server.URL+tt.expectedPath, result.StepResults[1].URL)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// ErrInvalidClientConfig is returned when an HTTP client can not be created for a client configuration.
+var ErrInvalidClientConfig = fmt.Errorf("invalid client configuration")
+
+// ClientConfig configures the HTTP client of a scenario. RootCAs, Certificate and Key are
+// PEM encoded, the certificates of RootCAs are trusted in addition to the system certificates.
+// If FollowRedirects is not set, redirect responses are returned as the response of a step.
+// HTTP2 forces HTTP/2, for http URLs with prior knowledge (h2c). If UnixSocket is set, all
+// requests are sent over the Unix domain socket, regardless of the host of their URL.
+type ClientConfig struct {
+	RootCAs            []byte
+	Certificate        []byte
+	Key                []byte
+	InsecureSkipVerify bool
+	Proxy              string
+	FollowRedirects    bool
+	MaxRedirects       int
+	HTTP2              bool
+	UnixSocket         string
+}
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// NewClient creates an HTTP client for the client configuration.
+func NewClient(config *ClientConfig) (*http.Client, error) {
+	transport, err := config.transport()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if !config.FollowRedirects {
+				return http.ErrUseLastResponse
+			}
+			// via contains the original request and all previous redirects.
+			if len(via) > config.MaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", config.MaxRedirects)
+			}
+			return nil
+		},
+	}, nil
+}
+
+func (c *ClientConfig) transport() (http.RoundTripper, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	dial := dialer.DialContext
+	if c.UnixSocket != "" {
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", c.UnixSocket)
+		}
+	}
+	if c.HTTP2 {
+		if c.Proxy != "" {
+			return nil, fmt.Errorf("%w: proxies are not supported with HTTP/2", ErrInvalidClientConfig)
+		}
+		return newHTTP2Transport(tlsConfig, dial), nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.DialContext = dial
+	if c.Proxy != "" {
+		proxyURL, err := url.Parse(c.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid proxy %s: %s", ErrInvalidClientConfig, c.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return transport, nil
+}
+
+func (c *ClientConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // explicitly configured, e.g. for ephemeral environments
+	}
+	if len(c.RootCAs) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(c.RootCAs) {
+			return nil, fmt.Errorf("%w: no certificates found in CA bundle", ErrInvalidClientConfig)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(c.Certificate) > 0 || len(c.Key) > 0 {
+		certificate, err := tls.X509KeyPair(c.Certificate, c.Key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid client certificate: %s", ErrInvalidClientConfig, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// http2Transport forces HTTP/2, over TLS for https URLs and over cleartext TCP for http URLs.
+type http2Transport struct {
+	tls       *http2.Transport
+	cleartext *http2.Transport
+}
+
+func newHTTP2Transport(tlsConfig *tls.Config, dial dialFunc) *http2Transport {
+	return &http2Transport{
+		tls: &http2.Transport{
+			TLSClientConfig: tlsConfig,
+			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+				conn, err := dial(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				tlsConn := tls.Client(conn, cfg)
+				err = tlsConn.HandshakeContext(ctx)
+				if err != nil {
+					_ = conn.Close()
+					return nil, err
+				}
+				return tlsConn, nil
+			},
+		},
+		cleartext: &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			},
+		},
+	}
+}
+
+func (t *http2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "http" {
+		return t.cleartext.RoundTrip(req)
+	}
+	return t.tls.RoundTrip(req)
+}
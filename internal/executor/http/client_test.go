@@ -0,0 +1,318 @@
+package http
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// testCertificate is a certificate and its private key, PEM encoded.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// newTestCertificate creates a certificate signed by the parent, or a self signed CA if parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func protoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+}
+
+func TestClientTLS(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	serverCertificate := newTestCertificate(t, "server", ca)
+	clientCertificate := newTestCertificate(t, "client", ca)
+	otherCA := newTestCertificate(t, "other", nil)
+
+	server := httptest.NewUnstartedServer(protoHandler())
+	serverKeyPair, err := tls.X509KeyPair(serverCertificate.certPEM, serverCertificate.keyPEM)
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.certificate)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverKeyPair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		config        *ClientConfig
+		expectedProto string
+		expectErr     bool
+	}{
+		{
+			name:          "client certificate and CA bundle",
+			config:        &ClientConfig{RootCAs: ca.certPEM, Certificate: clientCertificate.certPEM, Key: clientCertificate.keyPEM},
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			name:          "forced HTTP/2",
+			config:        &ClientConfig{RootCAs: ca.certPEM, Certificate: clientCertificate.certPEM, Key: clientCertificate.keyPEM, HTTP2: true},
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			name:          "insecure skip verify",
+			config:        &ClientConfig{InsecureSkipVerify: true, Certificate: clientCertificate.certPEM, Key: clientCertificate.keyPEM},
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			name:      "without client certificate",
+			config:    &ClientConfig{RootCAs: ca.certPEM},
+			expectErr: true,
+		},
+		{
+			name:      "client certificate of another CA",
+			config:    &ClientConfig{RootCAs: ca.certPEM, Certificate: otherCA.certPEM, Key: otherCA.keyPEM},
+			expectErr: true,
+		},
+		{
+			name:      "server certificate of an untrusted CA",
+			config:    &ClientConfig{RootCAs: otherCA.certPEM, Certificate: clientCertificate.certPEM, Key: clientCertificate.keyPEM},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.config)
+			assert.NoError(t, err)
+			response, err := client.Get(server.URL)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			assert.Equal(t, tt.expectedProto, response.Proto)
+		})
+	}
+}
+
+func TestClientHTTP2Cleartext(t *testing.T) {
+	server := newTestServer(t, h2c.NewHandler(protoHandler(), &http2.Server{}).ServeHTTP)
+
+	tests := []struct {
+		name          string
+		http2         bool
+		expectedProto string
+	}{
+		{
+			name:          "HTTP/1.1 by default",
+			expectedProto: "HTTP/1.1",
+		},
+		{
+			name:          "HTTP/2 with prior knowledge",
+			http2:         true,
+			expectedProto: "HTTP/2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(&ClientConfig{HTTP2: tt.http2})
+			assert.NoError(t, err)
+			response, err := client.Get(server.URL)
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			assert.Equal(t, tt.expectedProto, response.Proto)
+		})
+	}
+}
+
+func TestClientRedirects(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// /redirect/<n> redirects n times before responding.
+		var n int
+		_, err := fmt.Sscanf(r.URL.Path, "/redirect/%d", &n)
+		if err == nil && n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/redirect/%d", n-1), http.StatusFound)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		config         *ClientConfig
+		redirects      int
+		expectedStatus int
+		expectErr      bool
+	}{
+		{
+			name:           "redirect response is returned",
+			config:         &ClientConfig{},
+			redirects:      1,
+			expectedStatus: http.StatusFound,
+		},
+		{
+			name:           "redirects are followed",
+			config:         &ClientConfig{FollowRedirects: true, MaxRedirects: 2},
+			redirects:      2,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "too many redirects",
+			config:    &ClientConfig{FollowRedirects: true, MaxRedirects: 2},
+			redirects: 3,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.config)
+			assert.NoError(t, err)
+			response, err := client.Get(fmt.Sprintf("%s/redirect/%d", server.URL, tt.redirects))
+			if tt.expectErr {
+				assert.ErrorContains(t, err, "stopped after 2 redirects")
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer response.Body.Close()
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	proxy := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		// requests to a proxy contain the absolute URL of the target.
+		w.Header().Set("X-Proxied-Host", r.URL.Host)
+	})
+
+	client, err := NewClient(&ClientConfig{Proxy: proxy.URL})
+	assert.NoError(t, err)
+	response, err := client.Get("http://api.example.com/users")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer response.Body.Close()
+	assert.Equal(t, "api.example.com", response.Header.Get("X-Proxied-Host"))
+}
+
+func TestClientUnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "inquiry")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+	listener, err := net.Listen("unix", socket)
+	if !assert.NoError(t, err) {
+		return
+	}
+	server := &httptest.Server{
+		Listener: listener,
+		Config: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Host", r.Host)
+		}), ReadHeaderTimeout: time.Second},
+	}
+	server.Start()
+	defer server.Close()
+
+	client, err := NewClient(&ClientConfig{UnixSocket: socket})
+	assert.NoError(t, err)
+	// the host of the URL is sent as host header, the connection is made to the socket.
+	response, err := client.Get("http://api.local/users")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer response.Body.Close()
+	assert.Equal(t, "api.local", response.Header.Get("X-Host"))
+}
+
+func TestNewClientInvalidConfig(t *testing.T) {
+	ca := newTestCertificate(t, "ca", nil)
+	other := newTestCertificate(t, "other", nil)
+
+	tests := []struct {
+		name   string
+		config *ClientConfig
+	}{
+		{
+			name:   "CA bundle without certificates",
+			config: &ClientConfig{RootCAs: []byte("no certificate")},
+		},
+		{
+			name:   "certificate without key",
+			config: &ClientConfig{Certificate: ca.certPEM},
+		},
+		{
+			name:   "key not matching the certificate",
+			config: &ClientConfig{Certificate: ca.certPEM, Key: other.keyPEM},
+		},
+		{
+			name:   "invalid proxy",
+			config: &ClientConfig{Proxy: "://localhost"},
+		},
+		{
+			name:   "proxy with HTTP/2",
+			config: &ClientConfig{Proxy: "http://localhost:8080", HTTP2: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.config)
+			assert.ErrorIs(t, err, ErrInvalidClientConfig)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/inquiryproj/inquiry/internal/executor/condition"
+)
+
+// shouldExecute reports whether a step is executed, based on its skip and
+// only controls and its if condition.
+func (e Executor) shouldExecute(step *Step) (bool, error) {
+	if step.Skip || e.scenario.excludedByOnly(step) {
+		return false, nil
+	}
+	if step.If == "" {
+		return true, nil
+	}
+	expression, err := condition.Parse(step.If)
+	if err != nil {
+		return false, err
+	}
+	return expression.Evaluate(func(operand string) (string, error) {
+		return e.resolveOperand(step.Name, operand)
+	})
+}
+
+// excludedByOnly reports whether a step is excluded, because other steps
+// of the scenario are marked as only. Setup and teardown steps are never excluded.
+func (s Scenario) excludedByOnly(step *Step) bool {
+	if step.Only || !slices.Contains(s.Steps, step) {
+		return false
+	}
+	return slices.ContainsFunc(s.Steps, func(s *Step) bool {
+		return s.Only
+	})
+}
+
+// resolveOperand replaces the placeholders of an operand of a condition.
+// Undefined variables resolve to an empty string, such that conditions can
+// check whether a variable has been captured. Likewise, operands with outputs
+// of steps which are not executed, e.g. skipped steps, or with keys which are
+// not found in the response resolve to an empty string, which is false.
+func (e Executor) resolveOperand(stepName, operand string) (string, error) {
+	operand, err := e.replaceStepOutputs(stepName, operand)
+	var nonExecutedStep ErrNonExecutedStep
+	var keyNotFound ErrJSONKeyNotFound
+	if errors.As(err, &nonExecutedStep) || errors.As(err, &keyNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return variablePlaceholders().ReplaceAllStringFunc(operand, func(placeHolder string) string {
+		return e.variables[variablePlaceholders().FindStringSubmatch(placeHolder)[1]]
+	}), nil
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayCondition(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	})
+
+	tests := []struct {
+		name     string
+		login    *Step
+		next     *Step
+		executed bool
+	}{
+		{
+			name:     "status of a previous step",
+			login:    &Step{Validation: statusValidation("401")},
+			next:     &Step{If: "${steps.login.status} == 401"},
+			executed: true,
+		},
+		{
+			name:     "status of a previous step does not match",
+			login:    &Step{Validation: statusValidation("401")},
+			next:     &Step{If: "${steps.login.status} == 200"},
+			executed: false,
+		},
+		{
+			name:     "status of a skipped step",
+			login:    &Step{Skip: true, Validation: statusValidation("401")},
+			next:     &Step{If: "${steps.login.status} == 401"},
+			executed: false,
+		},
+		{
+			name:     "negated status of a skipped step",
+			login:    &Step{Skip: true, Validation: statusValidation("401")},
+			next:     &Step{If: "!${steps.login.status}"},
+			executed: true,
+		},
+		{
+			name:     "body of a skipped step",
+			login:    &Step{Skip: true, Validation: statusValidation("401")},
+			next:     &Step{If: "${steps.login.response.body.token}"},
+			executed: false,
+		},
+		{
+			name:     "missing key of a previous step",
+			login:    &Step{Validation: statusValidation("401")},
+			next:     &Step{If: "${steps.login.response.body.token} != ''"},
+			executed: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.login.Name = "login"
+			tt.login.Request = &Request{Method: http.MethodPost, URL: server.URL + "/login"}
+			tt.next.Name = "next"
+			tt.next.Request = &Request{Method: http.MethodGet, URL: server.URL + "/users"}
+			tt.next.Validation = statusValidation("200")
+			scenario := &Scenario{Name: "condition", Steps: []*Step{tt.login, tt.next}}
+
+			result, err := newTestExecutor(t, scenario).Play(context.Background())
+			assert.NoError(t, err)
+			assert.True(t, result.Success)
+			assert.Len(t, result.StepResults, 2)
+			assert.Equal(t, !tt.executed, result.StepResults[1].Skipped)
+		})
+	}
+}
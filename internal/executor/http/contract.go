@@ -0,0 +1,39 @@
+package http
+
+import "github.com/inquiryproj/inquiry/internal/executor/openapi"
+
+// validateContract validates the request and response of a step against the contract
+// of the scenario. Every violation is reported as a failed assertion, if there
+// are no violations a single successful assertion is reported.
+func (e Executor) validateContract(step *Step, requestResult *RequestResult) []*AssertionResult {
+	if e.contract == nil {
+		return []*AssertionResult{}
+	}
+	operation, violations := e.contract.Validate(&openapi.Exchange{
+		Method:  step.Request.Method,
+		URL:     step.Request.URL,
+		Status:  requestResult.Status,
+		Headers: requestResult.Headers,
+		Body:    requestResult.Body,
+	})
+	if len(violations) == 0 {
+		return []*AssertionResult{{
+			Type:      ValidationContract,
+			Key:       operation,
+			Assertion: AssertionMethod("contract"),
+			Expected:  operation,
+			Success:   true,
+		}}
+	}
+	assertionResults := []*AssertionResult{}
+	for _, violation := range violations {
+		assertionResults = append(assertionResults, &AssertionResult{
+			Type:      ValidationContract,
+			Key:       violation.Key,
+			Assertion: AssertionMethod(violation.Kind),
+			Expected:  operation,
+			Message:   violation.Message,
+		})
+	}
+	return assertionResults
+}
@@ -0,0 +1,128 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inquiryproj/inquiry/internal/executor/openapi"
+)
+
+const contractDocument = `openapi: 3.0.0
+info:
+  title: test
+  version: 1.0.0
+paths:
+  /users/{id}:
+    get:
+      responses:
+        200:
+          description: user
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: integer
+`
+
+func TestPlayContract(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users/1":
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		case "/users/2":
+			_, _ = w.Write([]byte(`{"id": "2"}`))
+		default:
+			w.WriteHeader(http.StatusTeapot)
+		}
+	})
+	contract, err := openapi.NewDocument("api.yaml", []byte(contractDocument))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		path            string
+		expectedResults []*AssertionResult
+	}{
+		{
+			name: "matching response",
+			path: "/users/1",
+			expectedResults: []*AssertionResult{{
+				Type:      ValidationContract,
+				Key:       "GET /users/{id}",
+				Assertion: "contract",
+				Expected:  "GET /users/{id}",
+				Success:   true,
+			}},
+		},
+		{
+			name: "body violating the schema",
+			path: "/users/2",
+			expectedResults: []*AssertionResult{{
+				Type:      ValidationContract,
+				Key:       "/id",
+				Assertion: openapi.ViolationBody,
+				Expected:  "GET /users/{id}",
+				Message:   "body at /id has type string, expected integer",
+			}},
+		},
+		{
+			name: "undocumented status",
+			path: "/users/3",
+			expectedResults: []*AssertionResult{{
+				Type:      ValidationContract,
+				Key:       "GET /users/{id}",
+				Assertion: openapi.ViolationStatus,
+				Expected:  "GET /users/{id}",
+				Message:   "status 418 is not documented for GET /users/{id}",
+			}},
+		},
+		{
+			name: "undocumented path",
+			path: "/projects",
+			expectedResults: []*AssertionResult{{
+				Type:      ValidationContract,
+				Key:       "GET /projects",
+				Assertion: openapi.ViolationOperation,
+				Expected:  "GET /projects",
+				Message:   "path /projects is not documented",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := &Scenario{Name: "contract", Steps: []*Step{{
+				Name:       "get",
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + tt.path},
+				Validation: &Validation{},
+			}}}
+			result, err := newTestExecutor(t, scenario, WithContract(contract)).Play(context.Background())
+			assert.NoError(t, err)
+			stepResult := result.StepResults[0]
+			assert.Equal(t, tt.expectedResults[0].Success, stepResult.Success)
+			assert.Equal(t, tt.expectedResults, stepResult.AssertionResults)
+		})
+	}
+}
+
+func TestPlayWithoutContract(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	scenario := &Scenario{Name: "contract", Steps: []*Step{{
+		Name:       "get",
+		Request:    &Request{Method: http.MethodGet, URL: server.URL + "/undocumented"},
+		Validation: statusValidation("200"),
+	}}}
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	for _, assertionResult := range result.StepResults[0].AssertionResults {
+		assert.NotEqual(t, ValidationContract, assertionResult.Type)
+	}
+}
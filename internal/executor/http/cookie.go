@@ -0,0 +1,106 @@
+package http
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// cookieClient stores the cookies of responses in a cookie jar and sends them with subsequent requests.
+type cookieClient struct {
+	client Client
+	jar    http.CookieJar
+}
+
+// withCookieJar returns a client with an empty cookie jar. HTTP clients get the jar
+// assigned, such that the cookies of redirect responses are stored as well.
+func withCookieJar(client Client) Client {
+	jar, _ := cookiejar.New(nil)
+	if httpClient, ok := client.(*http.Client); ok {
+		c := *httpClient
+		c.Jar = jar
+		return &c
+	}
+	return &cookieClient{client: client, jar: jar}
+}
+
+func (c *cookieClient) Do(req *http.Request) (*http.Response, error) {
+	for _, cookie := range c.jar.Cookies(req.URL) {
+		req.AddCookie(cookie)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.jar.SetCookies(req.URL, resp.Cookies())
+	return resp, nil
+}
+
+func findCookie(headers http.Header, name string) *http.Cookie {
+	response := &http.Response{Header: headers}
+	for _, cookie := range response.Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// cookieAttributes returns the attributes of a cookie which can be asserted, with their value types.
+func cookieAttributes() map[string]func(cookie *http.Cookie) (string, valueType) {
+	return map[string]func(cookie *http.Cookie) (string, valueType){
+		"value":  func(cookie *http.Cookie) (string, valueType) { return cookie.Value, ValueTypeString },
+		"domain": func(cookie *http.Cookie) (string, valueType) { return cookie.Domain, ValueTypeString },
+		"path":   func(cookie *http.Cookie) (string, valueType) { return cookie.Path, ValueTypeString },
+		"expires": func(cookie *http.Cookie) (string, valueType) {
+			if cookie.Expires.IsZero() {
+				return "", ValueTypeString
+			}
+			return cookie.Expires.UTC().Format(http.TimeFormat), ValueTypeString
+		},
+		"max_age": func(cookie *http.Cookie) (string, valueType) { return strconv.Itoa(cookie.MaxAge), ValueTypeNumber },
+		"secure":  func(cookie *http.Cookie) (string, valueType) { return strconv.FormatBool(cookie.Secure), ValueTypeBool },
+		"http_only": func(cookie *http.Cookie) (string, valueType) {
+			return strconv.FormatBool(cookie.HttpOnly), ValueTypeBool
+		},
+		"same_site": func(cookie *http.Cookie) (string, valueType) { return sameSite(cookie.SameSite), ValueTypeString },
+	}
+}
+
+func sameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "lax"
+	case http.SameSiteStrictMode:
+		return "strict"
+	case http.SameSiteNoneMode:
+		return "none"
+	default:
+		return ""
+	}
+}
+
+// cookieSubject returns the subject of an assertion on a cookie set by the response. The key is
+// either the name of the cookie, asserting its value, or the name followed by an attribute,
+// e.g. session.http_only.
+func cookieSubject(headers http.Header, key string) *assertionSubject {
+	name, attribute := key, "value"
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		if _, ok := cookieAttributes()[key[i+1:]]; ok {
+			name, attribute = key[:i], key[i+1:]
+		}
+	}
+	cookie := findCookie(headers, name)
+	if cookie == nil {
+		return &assertionSubject{valueType: ValueTypeNull}
+	}
+	value, valueType := cookieAttributes()[attribute](cookie)
+	return &assertionSubject{
+		value:     value,
+		valueType: valueType,
+		length:    utf8.RuneCountInString(value),
+		exists:    true,
+	}
+}
@@ -0,0 +1,274 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrappedClient is a client which is not a *http.Client, such that the cookie jar
+// is applied by the executor.
+type wrappedClient struct {
+	client *http.Client
+}
+
+func (c *wrappedClient) Do(req *http.Request) (*http.Response, error) {
+	return c.client.Do(req)
+}
+
+func newSessionServer(t *testing.T) *httptest.Server {
+	return newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true})
+			http.Redirect(w, r, "/home", http.StatusFound)
+		case "/home":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			cookie, err := r.Cookie("session")
+			if err != nil || cookie.Value != "abc" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	})
+}
+
+func sessionScenario(url string, cookies bool) *Scenario {
+	return &Scenario{
+		Name:    "session",
+		Cookies: cookies,
+		Steps: []*Step{
+			{
+				Name:       "anonymous",
+				Request:    &Request{Method: http.MethodGet, URL: url + "/me"},
+				Validation: statusValidation("401"),
+			},
+			{
+				Name:       "login",
+				Request:    &Request{Method: http.MethodPost, URL: url + "/login"},
+				Validation: statusValidation("204"),
+			},
+			{
+				Name:       "me",
+				Request:    &Request{Method: http.MethodGet, URL: url + "/me"},
+				Validation: statusValidation("200"),
+			},
+		},
+	}
+}
+
+func TestPlayCookieJar(t *testing.T) {
+	server := newSessionServer(t)
+
+	tests := []struct {
+		name            string
+		client          Client
+		cookies         bool
+		expectedSuccess bool
+	}{
+		{
+			name:            "http client",
+			client:          &http.Client{},
+			cookies:         true,
+			expectedSuccess: true,
+		},
+		{
+			name:            "custom client",
+			client:          &wrappedClient{client: &http.Client{CheckRedirect: noRedirects}},
+			cookies:         true,
+			expectedSuccess: true,
+		},
+		{
+			name:    "without cookie jar",
+			client:  &http.Client{},
+			cookies: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := sessionScenario(server.URL, tt.cookies)
+			if _, ok := tt.client.(*wrappedClient); ok {
+				// redirects are not followed by the client, the cookie is set by the redirect response.
+				scenario.Steps[1].Validation = statusValidation("302")
+			}
+			e, err := NewExecutor(scenario, WithHTTPClient(tt.client))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// every execution starts with an empty cookie jar, the first step is anonymous again.
+			for i := 0; i < 2; i++ {
+				result, err := e.Play(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSuccess, result.Success)
+				assert.True(t, result.StepResults[0].Success)
+			}
+		})
+	}
+}
+
+func noRedirects(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+func TestPlayCookieCapture(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc"})
+			return
+		}
+		if r.Header.Get("X-Session") != "abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	scenario := &Scenario{
+		Name: "capture",
+		Steps: []*Step{
+			{
+				Name:       "login",
+				Request:    &Request{Method: http.MethodPost, URL: server.URL + "/login"},
+				Validation: statusValidation("200"),
+				Capture:    []*Capture{{Name: "session", Cookie: "session"}, {Name: "other", Cookie: "other"}},
+			},
+		},
+	}
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Contains(t, result.StepResults[0].AssertionResults, &AssertionResult{
+		Type:      ValidationCapture,
+		Key:       "other",
+		Assertion: AssertionMethod("capture"),
+		Message:   "unable to capture variable other: cookie other not found",
+	})
+
+	scenario.Steps[0].Capture = scenario.Steps[0].Capture[:1]
+	scenario.Steps = append(scenario.Steps, &Step{
+		Name:       "me",
+		Request:    &Request{Method: http.MethodGet, URL: server.URL + "/me", Headers: []*Header{{Name: "X-Session", Value: "${vars.session}"}}},
+		Validation: statusValidation("200"),
+	})
+	result, err = newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestCookieSubject(t *testing.T) {
+	headers := http.Header{}
+	headers.Add("Set-Cookie", (&http.Cookie{
+		Name:     "session",
+		Value:    "abc",
+		Domain:   "example.com",
+		Path:     "/app",
+		Expires:  time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		MaxAge:   3600,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}).String())
+	headers.Add("Set-Cookie", "theme.dark=yes")
+
+	tests := []struct {
+		name     string
+		key      string
+		expected *assertionSubject
+	}{
+		{
+			name:     "value",
+			key:      "session",
+			expected: &assertionSubject{value: "abc", valueType: ValueTypeString, length: 3, exists: true},
+		},
+		{
+			name:     "domain",
+			key:      "session.domain",
+			expected: &assertionSubject{value: "example.com", valueType: ValueTypeString, length: 11, exists: true},
+		},
+		{
+			name:     "path",
+			key:      "session.path",
+			expected: &assertionSubject{value: "/app", valueType: ValueTypeString, length: 4, exists: true},
+		},
+		{
+			name:     "expires",
+			key:      "session.expires",
+			expected: &assertionSubject{value: "Wed, 02 Jan 2030 03:04:05 GMT", valueType: ValueTypeString, length: 29, exists: true},
+		},
+		{
+			name:     "max age",
+			key:      "session.max_age",
+			expected: &assertionSubject{value: "3600", valueType: ValueTypeNumber, length: 4, exists: true},
+		},
+		{
+			name:     "secure",
+			key:      "session.secure",
+			expected: &assertionSubject{value: "true", valueType: ValueTypeBool, length: 4, exists: true},
+		},
+		{
+			name:     "http only",
+			key:      "session.http_only",
+			expected: &assertionSubject{value: "true", valueType: ValueTypeBool, length: 4, exists: true},
+		},
+		{
+			name:     "same site",
+			key:      "session.same_site",
+			expected: &assertionSubject{value: "strict", valueType: ValueTypeString, length: 6, exists: true},
+		},
+		{
+			name:     "name with dot",
+			key:      "theme.dark",
+			expected: &assertionSubject{value: "yes", valueType: ValueTypeString, length: 3, exists: true},
+		},
+		{
+			name:     "missing cookie",
+			key:      "missing",
+			expected: &assertionSubject{valueType: ValueTypeNull},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cookieSubject(headers, tt.key))
+		})
+	}
+}
+
+func TestPlayCookieAssertions(t *testing.T) {
+	server := newSessionServer(t)
+
+	scenario := &Scenario{
+		Name: "cookies",
+		Steps: []*Step{{
+			Name:    "login",
+			Request: &Request{Method: http.MethodPost, URL: server.URL + "/login"},
+			Validation: &Validation{
+				Status: &Assertion{Assertion: AssertionMethodEqual, Value: "302"},
+				Cookies: []*Assertion{
+					{Key: "session", Assertion: AssertionMethodEqual, Value: "abc"},
+					{Key: "session.http_only", Assertion: AssertionMethodEqual, Value: "true"},
+					{Key: "session.secure", Assertion: AssertionMethodEqual, Value: "true"},
+					{Key: "missing", Assertion: AssertionMethodNotExists},
+				},
+			},
+		}},
+	}
+	e, err := NewExecutor(scenario, WithHTTPClient(&http.Client{CheckRedirect: noRedirects}))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	result, err := e.Play(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	successes := []bool{}
+	for _, assertionResult := range result.StepResults[0].AssertionResults {
+		successes = append(successes, assertionResult.Success)
+	}
+	assert.Equal(t, []bool{true, true, true, false, true}, successes)
+	assert.Equal(t, ValidationCookies, result.StepResults[0].AssertionResults[3].Type)
+	assert.Equal(t, "session.secure", result.StepResults[0].AssertionResults[3].Key)
+	assert.Equal(t, "false", result.StepResults[0].AssertionResults[3].Actual)
+}
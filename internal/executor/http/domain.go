@@ -2,18 +2,35 @@
 package http
 
 import (
+	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
+
+	"github.com/inquiryproj/inquiry/internal/executor/openapi"
 )
 
 type assertionMethod string
 
 // Different assertion methods.
 const (
-	AssertionMethodEqual    assertionMethod = "equal"
-	AssertionMethodRegex    assertionMethod = "regex"
-	AssertionMethodNotEmpty assertionMethod = "not_empty"
+	AssertionMethodEqual              assertionMethod = "equal"
+	AssertionMethodNotEqual           assertionMethod = "not_equal"
+	AssertionMethodRegex              assertionMethod = "regex"
+	AssertionMethodNotEmpty           assertionMethod = "not_empty"
+	AssertionMethodContains           assertionMethod = "contains"
+	AssertionMethodStartsWith         assertionMethod = "starts_with"
+	AssertionMethodEndsWith           assertionMethod = "ends_with"
+	AssertionMethodGreaterThan        assertionMethod = "gt"
+	AssertionMethodGreaterThanOrEqual assertionMethod = "gte"
+	AssertionMethodLessThan           assertionMethod = "lt"
+	AssertionMethodLessThanOrEqual    assertionMethod = "lte"
+	AssertionMethodIn                 assertionMethod = "in"
+	AssertionMethodExists             assertionMethod = "exists"
+	AssertionMethodNotExists          assertionMethod = "not_exists"
+	AssertionMethodLength             assertionMethod = "length"
+	AssertionMethodType               assertionMethod = "type"
 )
 
 // AssertionMethod returns an assertion method for a given string.
@@ -28,6 +45,17 @@ const (
 	ValidationBody    validationType = "body"
 	ValidationStatus  validationType = "status"
 	ValidationHeaders validationType = "headers"
+	ValidationCookies validationType = "cookies"
+	ValidationLatency validationType = "latency"
+	ValidationSchema  validationType = "schema"
+	// ValidationContract validates a request and its response against an OpenAPI document.
+	ValidationContract validationType = "contract"
+	// ValidationDuration asserts the total execution time of a scenario.
+	ValidationDuration validationType = "duration"
+	// ValidationGraphQL validates the data and errors of a GraphQL response.
+	ValidationGraphQL validationType = "graphql"
+	// ValidationCapture reports values which could not be captured from a response.
+	ValidationCapture validationType = "capture"
 )
 
 // Client is the interface for perfoming HTTP requests.
@@ -40,12 +68,33 @@ type Executor struct {
 	scenario   *Scenario
 	httpClient Client
 	logger     *slog.Logger
+	variables  map[string]string
+	contract   *openapi.Document
+	curlWriter io.Writer
+	tokens     *tokenCache
 }
 
 // Scenario is the main struct for a test scenario to be executed.
+// MaxDuration, if set, is asserted against the total execution time.
+// If Cookies is set, the cookies of all responses are stored in a cookie jar
+// and sent with subsequent requests of the scenario.
+// Auth authenticates the requests of all steps, which do not define their own authentication.
 type Scenario struct {
-	Name  string
-	Steps []*Step
+	Name        string
+	Timeout     time.Duration
+	MaxDuration time.Duration
+	Cookies     bool
+	Auth        *Auth
+	Setup       []*Step
+	Steps       []*Step
+	Teardown    []*Step
+}
+
+func (s Scenario) allSteps() []*Step {
+	steps := []*Step{}
+	steps = append(steps, s.Setup...)
+	steps = append(steps, s.Steps...)
+	return append(steps, s.Teardown...)
 }
 
 // ScenarioMetrics is a struct for storing metrics of a scenario.
@@ -53,42 +102,140 @@ type scenarioMetrics struct {
 	TotalExecutionTime time.Duration
 }
 
-// InputReplacement is a struct for replacing dynamic inputs in a step.
+// InputReplacement is a struct for replacing dynamic inputs in a step, which
+// are either a key of the response body or the status of a previous step.
 type InputReplacement struct {
 	StepName         string
 	JSONKey          string
+	Status           bool
 	ReplacementValue string
 }
 
 // Step represents a step in a scenario.
 type Step struct {
 	Name          string
+	If            string `json:"-"` // placeholders are resolved when the condition is evaluated
+	Skip          bool
+	Only          bool
+	Timeout       time.Duration
 	Request       *Request
+	Auth          *Auth
 	Validation    *Validation
+	Until         *Validation
 	RequestResult *RequestResult
 	IsExecuted    bool
 	Retry         *Retry
+	Capture       []*Capture
+}
+
+// Capture represents a value of a response which is stored as a scenario variable.
+type Capture struct {
+	Name   string
+	Body   string
+	Header string
+	Status bool
+	Cookie string
+	Data   string
+	Regex  string
 }
 
 // Retry for a single step.
+// Attempts is the maximum number of retries after the first attempt, if it is
+// not set retries are only limited by MaxDuration. The delay between attempts
+// starts at Timeout and grows exponentially with Backoff up to MaxDelay.
 type Retry struct {
-	Attempts int           `yaml:"attempts"`
-	Timeout  time.Duration `yaml:"timeout"`
+	Attempts      int           `yaml:"attempts"`
+	Timeout       time.Duration `yaml:"timeout"`
+	Backoff       float64       `yaml:"backoff"`
+	MaxDelay      time.Duration `yaml:"max_delay"`
+	Jitter        float64       `yaml:"jitter"`
+	MaxDuration   time.Duration `yaml:"max_duration"`
+	Statuses      []int         `yaml:"statuses"`
+	NetworkErrors bool          `yaml:"network_errors"`
 }
 
-// Request represents a HTTP request.
+// Request represents a HTTP request. The body is either Body of the type ContentType,
+// a URL encoded form or a multipart body.
 type Request struct {
-	Method  string
-	URL     string
-	Headers []*Header
-	Body    string
+	Method      string
+	URL         string
+	Headers     []*Header
+	Body        string
+	ContentType string
+	Form        map[string]string
+	Multipart   []*Part
+}
+
+// Part represents a part of a multipart body. Parts with a file name are files, of which
+// the content is either defined inline or read from the attachment File.
+type Part struct {
+	Name        string
+	Value       string
+	File        string
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// Auth authenticates requests with one of the authentication methods.
+// None disables the authentication of the scenario for a step.
+type Auth struct {
+	None   bool
+	Basic  *BasicAuth
+	Bearer string
+	OAuth2 *OAuth2
+	JWT    *JWT
+	HMAC   *HMAC
+}
+
+// BasicAuth authenticates requests with HTTP basic authentication.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// OAuth2 authenticates requests with an access token of the client credentials or password grant.
+// The token is fetched once per execution of a scenario and refreshed before it expires.
+type OAuth2 struct {
+	GrantType    string
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Username     string
+	Password     string
+	Scopes       []string
+}
+
+// JWT authenticates requests with a locally signed JSON Web Token, which is signed with
+// HS256 and Secret or RS256 and the PEM encoded PrivateKey read from the attachment PrivateKeyFile.
+// The token is sent in Header, as bearer token if Header is the Authorization header.
+type JWT struct {
+	Algorithm      string
+	Secret         string
+	PrivateKeyFile string
+	PrivateKey     []byte
+	Claims         map[string]any
+	ExpiresIn      time.Duration
+	Header         string
+}
+
+// HMAC signs requests with an HMAC of the method, the request URI, the timestamp, if
+// TimestampHeader is set, and the body of the request, separated by newlines.
+// The signature is sent in Header, encoded as hex or base64.
+type HMAC struct {
+	Key             string
+	Algorithm       string
+	Encoding        string
+	Header          string
+	TimestampHeader string
 }
 
 // RequestResult represents the result of an HTTP request.
 type RequestResult struct {
-	Body    []byte
-	Status  int
-	Headers http.Header
+	Body     []byte
+	Status   int
+	Headers  http.Header
+	Duration time.Duration
 }
 
 // Validation represents the validation of a step.
@@ -96,6 +243,10 @@ type Validation struct {
 	Body    []*Assertion
 	Status  *Assertion
 	Headers []*Assertion
+	Cookies []*Assertion
+	Latency *Assertion
+	Schema  *Schema `json:"-"` // compiled when the executor is created
+	GraphQL *GraphQLValidation
 }
 
 // Assertion represents an assertion, as part of a validation.
@@ -103,6 +254,25 @@ type Assertion struct {
 	Key       string
 	Assertion assertionMethod
 	Value     string
+	Values    []string
+}
+
+func (a Assertion) expected() string {
+	if a.Assertion == AssertionMethodIn {
+		return strings.Join(a.Values, ", ")
+	}
+	return a.Value
+}
+
+// AssertionResult is the outcome of a single assertion performed on a response.
+type AssertionResult struct {
+	Type      validationType
+	Key       string
+	Assertion assertionMethod
+	Expected  string
+	Actual    string
+	Success   bool
+	Message   string
 }
 
 // Header represents a HTTP header.
@@ -1,16 +1,27 @@
 package http
 
 import (
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
+
+	"github.com/inquiryproj/inquiry/internal/executor/jsonschema"
+	"github.com/inquiryproj/inquiry/internal/executor/openapi"
 )
 
 type options struct {
-	HTTPClient Client
-	Logger     *slog.Logger
+	HTTPClient   Client
+	Logger       *slog.Logger
+	SchemaLoader jsonschema.Loader
+	Contract     *openapi.Document
+	CurlWriter   io.Writer
+	Attachments  AttachmentLoader
 }
 
+// AttachmentLoader loads the content of an attachment, such as a file of a multipart body.
+type AttachmentLoader func(name string) ([]byte, error)
+
 func defaultOptions() *options {
 	return &options{
 		HTTPClient: http.DefaultClient,
@@ -37,6 +48,35 @@ func WithLogger(logger *slog.Logger) Opts {
 	}
 }
 
+// WithSchemaLoader sets the loader used to load the JSON Schemas referenced by the scenario.
+func WithSchemaLoader(loader jsonschema.Loader) Opts {
+	return func(o *options) {
+		o.SchemaLoader = loader
+	}
+}
+
+// WithContract sets the OpenAPI document every request and response of the scenario is validated against.
+func WithContract(contract *openapi.Document) Opts {
+	return func(o *options) {
+		o.Contract = contract
+	}
+}
+
+// WithAttachmentLoader sets the loader used to load the files attached to the multipart bodies of the scenario.
+func WithAttachmentLoader(loader AttachmentLoader) Opts {
+	return func(o *options) {
+		o.Attachments = loader
+	}
+}
+
+// WithCurlWriter sets the writer the curl command lines of failing steps are written to,
+// such that their requests can be reproduced.
+func WithCurlWriter(w io.Writer) Opts {
+	return func(o *options) {
+		o.CurlWriter = w
+	}
+}
+
 // NewExecutor creates a new HTTP test scenario executor.
 func NewExecutor(scenario *Scenario, opts ...Opts) (*Executor, error) {
 	o := defaultOptions()
@@ -44,10 +84,20 @@ func NewExecutor(scenario *Scenario, opts ...Opts) (*Executor, error) {
 	for _, opt := range opts {
 		opt(o)
 	}
+	err := scenario.compileSchemas(o.SchemaLoader)
+	if err != nil {
+		return nil, err
+	}
+	err = scenario.loadAttachments(o.Attachments)
+	if err != nil {
+		return nil, err
+	}
 	executor := &Executor{}
 	executor.scenario = scenario
 	executor.httpClient = o.HTTPClient
 	executor.logger = o.Logger
+	executor.contract = o.Contract
+	executor.curlWriter = o.CurlWriter
 
 	return executor, nil
 }
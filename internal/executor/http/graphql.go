@@ -0,0 +1,85 @@
+package http
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// GraphQLValidation validates a GraphQL response. Data asserts values of the data of the
+// response, the keys are relative to data. NoErrors asserts that the response has no errors,
+// ErrorCodes asserts that the errors contain the given codes of their extensions.
+type GraphQLValidation struct {
+	Data       []*Assertion
+	NoErrors   bool
+	ErrorCodes []string
+}
+
+func (v *GraphQLValidation) validate(body []byte) []*AssertionResult {
+	assertionResults := []*AssertionResult{}
+	if v == nil {
+		return assertionResults
+	}
+	for _, assertion := range v.Data {
+		assertionResults = append(assertionResults, assertValue(bodySubject(body, graphQLDataPath(assertion.Key)), ValidationGraphQL, assertion))
+	}
+	if v.NoErrors {
+		assertionResults = append(assertionResults, assertNoGraphQLErrors(body))
+	}
+	for _, code := range v.ErrorCodes {
+		assertionResults = append(assertionResults, assertGraphQLErrorCode(body, code))
+	}
+	return assertionResults
+}
+
+// graphQLDataPath returns the path of a key relative to the data of a GraphQL response.
+func graphQLDataPath(key string) string {
+	if key == "" {
+		return "data"
+	}
+	return "data." + key
+}
+
+// assertNoGraphQLErrors asserts that the errors of a GraphQL response are absent or empty.
+func assertNoGraphQLErrors(body []byte) *AssertionResult {
+	errors := gjson.GetBytes(body, "errors")
+	assertionResult := &AssertionResult{
+		Type:      ValidationGraphQL,
+		Key:       "errors",
+		Assertion: AssertionMethod("no_errors"),
+		Expected:  "[]",
+		Actual:    errors.Raw,
+		Success:   len(errors.Array()) == 0,
+	}
+	if !assertionResult.Success {
+		messages := []string{}
+		for _, message := range gjson.GetBytes(body, "errors.#.message").Array() {
+			messages = append(messages, message.String())
+		}
+		assertionResult.Message = fmt.Sprintf("response has errors: %s", strings.Join(messages, "; "))
+	}
+	return assertionResult
+}
+
+// assertGraphQLErrorCode asserts that one of the errors of a GraphQL response has the given
+// code in its extensions, e.g. {"extensions": {"code": "UNAUTHENTICATED"}}.
+func assertGraphQLErrorCode(body []byte, code string) *AssertionResult {
+	codes := []string{}
+	for _, value := range gjson.GetBytes(body, "errors.#.extensions.code").Array() {
+		codes = append(codes, value.String())
+	}
+	assertionResult := &AssertionResult{
+		Type:      ValidationGraphQL,
+		Key:       "errors",
+		Assertion: AssertionMethod("error_code"),
+		Expected:  code,
+		Actual:    strings.Join(codes, ", "),
+		Success:   slices.Contains(codes, code),
+	}
+	if !assertionResult.Success {
+		assertionResult.Message = fmt.Sprintf("errors do not contain code %s", code)
+	}
+	return assertionResult
+}
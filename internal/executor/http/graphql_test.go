@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQLValidation(t *testing.T) {
+	data := []byte(`{"data": {"user": {"id": "42", "name": "bob", "roles": ["admin"]}}}`)
+	errors := []byte(`{"data": null, "errors": [
+		{"message": "not authenticated", "extensions": {"code": "UNAUTHENTICATED"}},
+		{"message": "not found", "extensions": {"code": "NOT_FOUND"}}
+	]}`)
+
+	tests := []struct {
+		name                     string
+		validation               *GraphQLValidation
+		body                     []byte
+		expectedAssertionResults []*AssertionResult
+	}{
+		{
+			name:                     "without validation",
+			body:                     data,
+			expectedAssertionResults: []*AssertionResult{},
+		},
+		{
+			name: "data",
+			validation: &GraphQLValidation{Data: []*Assertion{
+				{Key: "user.name", Assertion: AssertionMethodEqual, Value: "bob"},
+				{Key: "user.roles.#", Assertion: AssertionMethodEqual, Value: "1"},
+			}},
+			body: data,
+			expectedAssertionResults: []*AssertionResult{
+				{Type: ValidationGraphQL, Key: "user.name", Assertion: AssertionMethodEqual, Expected: "bob", Actual: "bob", Success: true},
+				{Type: ValidationGraphQL, Key: "user.roles.#", Assertion: AssertionMethodEqual, Expected: "1", Actual: "1", Success: true},
+			},
+		},
+		{
+			name:       "no errors",
+			validation: &GraphQLValidation{NoErrors: true},
+			body:       data,
+			expectedAssertionResults: []*AssertionResult{
+				{Type: ValidationGraphQL, Key: "errors", Assertion: AssertionMethod("no_errors"), Expected: "[]", Success: true},
+			},
+		},
+		{
+			name:       "no errors with errors",
+			validation: &GraphQLValidation{NoErrors: true},
+			body:       errors,
+			expectedAssertionResults: []*AssertionResult{
+				{
+					Type:      ValidationGraphQL,
+					Key:       "errors",
+					Assertion: AssertionMethod("no_errors"),
+					Expected:  "[]",
+					Actual: `[
+		{"message": "not authenticated", "extensions": {"code": "UNAUTHENTICATED"}},
+		{"message": "not found", "extensions": {"code": "NOT_FOUND"}}
+	]`,
+					Message: "response has errors: not authenticated; not found",
+				},
+			},
+		},
+		{
+			name:       "error codes",
+			validation: &GraphQLValidation{ErrorCodes: []string{"NOT_FOUND", "FORBIDDEN"}},
+			body:       errors,
+			expectedAssertionResults: []*AssertionResult{
+				{Type: ValidationGraphQL, Key: "errors", Assertion: AssertionMethod("error_code"), Expected: "NOT_FOUND", Actual: "UNAUTHENTICATED, NOT_FOUND", Success: true},
+				{
+					Type:      ValidationGraphQL,
+					Key:       "errors",
+					Assertion: AssertionMethod("error_code"),
+					Expected:  "FORBIDDEN",
+					Actual:    "UNAUTHENTICATED, NOT_FOUND",
+					Message:   "errors do not contain code FORBIDDEN",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertionResults := tt.validation.validate(tt.body)
+			for _, assertionResult := range assertionResults {
+				// messages of data assertions are covered by the assertion tests.
+				if assertionResult.Assertion == AssertionMethodEqual {
+					assertionResult.Message = ""
+				}
+			}
+			assert.Equal(t, tt.expectedAssertionResults, assertionResults)
+		})
+	}
+}
+
+func TestPlayGraphQL(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request := struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}{}
+		_ = json.Unmarshal(body, &request)
+		w.Header().Set("Content-Type", "application/json")
+		if request.Variables["id"] == nil {
+			_, _ = w.Write([]byte(`{"data": {"createUser": {"id": "42"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"user": {"id": "` + request.Variables["id"].(string) + `"}}}`))
+	})
+
+	scenario := &Scenario{
+		Name: "graphql",
+		Steps: []*Step{
+			{
+				Name: "create",
+				Request: &Request{
+					Method:      http.MethodPost,
+					URL:         server.URL,
+					Body:        `{"query": "mutation { createUser { id } }"}`,
+					ContentType: "application/json",
+				},
+				Validation: &Validation{GraphQL: &GraphQLValidation{NoErrors: true}},
+				Capture:    []*Capture{{Name: "id", Data: "createUser.id"}},
+			},
+			{
+				Name: "get",
+				Request: &Request{
+					Method:      http.MethodPost,
+					URL:         server.URL,
+					Body:        `{"query": "query($id: ID!) { user(id: $id) { id } }", "variables": {"id": "${vars.id}"}}`,
+					ContentType: "application/json",
+				},
+				Validation: &Validation{GraphQL: &GraphQLValidation{
+					Data: []*Assertion{{Key: "user.id", Assertion: AssertionMethodEqual, Value: "42"}},
+				}},
+				Capture: []*Capture{{Name: "missing", Data: "createUser.id"}},
+			},
+		},
+	}
+
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	if !assert.Len(t, result.StepResults, 2) {
+		return
+	}
+	assert.True(t, result.StepResults[0].Success)
+	assert.False(t, result.StepResults[1].Success)
+	assert.True(t, result.StepResults[1].AssertionResults[0].Success)
+	assert.Contains(t, result.StepResults[1].AssertionResults, &AssertionResult{
+		Type:      ValidationCapture,
+		Key:       "missing",
+		Assertion: AssertionMethod("capture"),
+		Message:   "unable to capture variable missing: body key data.createUser.id not found",
+	})
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is the time before the expiry of an access token at which it is refreshed.
+const tokenExpiryMargin = 30 * time.Second
+
+type oauth2Token struct {
+	accessToken  string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+// valid returns whether the token can still be used, tokens without expiry are valid indefinitely.
+func (t *oauth2Token) valid(now time.Time) bool {
+	return t.expiresAt.IsZero() || now.Add(tokenExpiryMargin).Before(t.expiresAt)
+}
+
+// tokenCache caches the access tokens of a scenario execution.
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]*oauth2Token
+}
+
+func newTokenCache() *tokenCache {
+	return &tokenCache{tokens: map[string]*oauth2Token{}}
+}
+
+// token returns a valid access token for the OAuth2 configuration. Expired tokens are
+// refreshed with their refresh token, if any, or else fetched again.
+func (c *tokenCache) token(ctx context.Context, client Client, o *OAuth2) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := strings.Join(append([]string{o.TokenURL, o.GrantType, o.ClientID, o.Username}, o.Scopes...), "\x00")
+	cached, ok := c.tokens[key]
+	if ok && cached.valid(time.Now()) {
+		return cached.accessToken, nil
+	}
+	var token *oauth2Token
+	err := fmt.Errorf("no refresh token")
+	if ok && cached.refreshToken != "" {
+		token, err = o.requestToken(ctx, client, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {cached.refreshToken},
+		})
+	}
+	if err != nil {
+		token, err = o.requestToken(ctx, client, o.grantValues())
+	}
+	if err != nil {
+		return "", err
+	}
+	c.tokens[key] = token
+	return token.accessToken, nil
+}
+
+func (o *OAuth2) grantValues() url.Values {
+	values := url.Values{"grant_type": {o.GrantType}}
+	if o.GrantType == GrantTypePassword {
+		values.Set("username", o.Username)
+		values.Set("password", o.Password)
+	}
+	if len(o.Scopes) > 0 {
+		values.Set("scope", strings.Join(o.Scopes, " "))
+	}
+	return values
+}
+
+// requestToken requests an access token from the token endpoint, the client
+// authenticates with HTTP basic authentication as defined by RFC 6749.
+func (o *OAuth2) requestToken(ctx context.Context, client Client, values url.Values) (*oauth2Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.TokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if o.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(o.ClientID), url.QueryEscape(o.ClientSecret))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, body)
+	}
+	tokenResponse := struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+	}{}
+	err = json.Unmarshal(body, &tokenResponse)
+	if err != nil || tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint returned no access token: %s", body)
+	}
+	token := &oauth2Token{accessToken: tokenResponse.AccessToken, refreshToken: tokenResponse.RefreshToken}
+	if tokenResponse.ExpiresIn > 0 {
+		token.expiresAt = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
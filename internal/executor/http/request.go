@@ -3,17 +3,28 @@ package http
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/curl"
 )
 
-func (r Request) toHTTPRequest() (*http.Request, error) {
+func (r Request) toHTTPRequest(ctx context.Context) (*http.Request, error) {
+	body, contentType, err := r.body()
+	if err != nil {
+		return nil, err
+	}
 	var reader io.Reader
-	if r.Body != "" {
-		reader = bytes.NewReader([]byte(r.Body))
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 
-	req, err := http.NewRequestWithContext(context.Background(), r.Method, r.URL, reader)
+	req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, reader)
 	if err != nil {
 		return nil, err
 	}
@@ -21,5 +32,104 @@ func (r Request) toHTTPRequest() (*http.Request, error) {
 	for _, h := range r.Headers {
 		req.Header.Set(h.Name, h.Value)
 	}
+	// The content type of multipart bodies contains the boundary of the parts and can not be overridden.
+	if contentType != "" && (req.Header.Get("Content-Type") == "" || len(r.Multipart) > 0) {
+		req.Header.Set("Content-Type", contentType)
+	}
 	return req, nil
 }
+
+// body returns the body of the request and its content type.
+func (r Request) body() ([]byte, string, error) {
+	switch {
+	case len(r.Multipart) > 0:
+		return r.multipartBody()
+	case len(r.Form) > 0:
+		values := url.Values{}
+		for name, value := range r.Form {
+			values.Set(name, value)
+		}
+		return []byte(values.Encode()), "application/x-www-form-urlencoded", nil
+	case r.Body != "":
+		return []byte(r.Body), r.ContentType, nil
+	default:
+		return nil, "", nil
+	}
+}
+
+func (r Request) multipartBody() ([]byte, string, error) {
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+	for _, part := range r.Multipart {
+		err := part.write(w)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to write multipart part %s: %w", part.Name, err)
+		}
+	}
+	err := w.Close()
+	if err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), w.FormDataContentType(), nil
+}
+
+func (p *Part) write(w *multipart.Writer) error {
+	if p.FileName == "" {
+		return w.WriteField(p.Name, p.Value)
+	}
+	contentType := p.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(p.Name), escapeQuotes(p.FileName)))
+	header.Set("Content-Type", contentType)
+	partWriter, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = partWriter.Write(p.Content)
+	return err
+}
+
+func escapeQuotes(s string) string {
+	return strings.NewReplacer("\\", "\\\\", `"`, "\\\"").Replace(s)
+}
+
+// Curl returns the curl command line performing the request.
+func (r Request) Curl() string {
+	request := &curl.Request{Method: r.Method, URL: r.URL}
+	for _, header := range r.Headers {
+		request.Headers = append(request.Headers, &curl.Header{Name: header.Name, Value: header.Value})
+	}
+	for _, part := range r.Multipart {
+		curlPart := &curl.Part{Name: part.Name, Value: part.Value, ContentType: part.ContentType}
+		// Inline files are referenced by their file name, the file has to be created to reproduce the request.
+		if part.FileName != "" {
+			curlPart.File = part.File
+			curlPart.FileName = part.FileName
+		}
+		if part.FileName != "" && part.File == "" {
+			curlPart.File = part.FileName
+		}
+		request.Parts = append(request.Parts, curlPart)
+	}
+	if len(r.Multipart) > 0 {
+		return curl.Command(request)
+	}
+	body, contentType, _ := r.body()
+	request.Body = string(body)
+	if contentType != "" && !hasHeader(r.Headers, "Content-Type") {
+		request.Headers = append(request.Headers, &curl.Header{Name: "Content-Type", Value: contentType})
+	}
+	return curl.Command(request)
+}
+
+func hasHeader(headers []*Header, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+	return false
+}
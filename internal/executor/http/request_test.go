@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTTPRequest(t *testing.T) {
+	tests := []struct {
+		name                string
+		request             Request
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:    "without body",
+			request: Request{Method: http.MethodGet, URL: "http://localhost/users"},
+		},
+		{
+			name:                "body with content type",
+			request:             Request{Method: http.MethodPost, URL: "http://localhost/users", Body: `{"name":"test"}`, ContentType: "application/json"},
+			expectedContentType: "application/json",
+			expectedBody:        `{"name":"test"}`,
+		},
+		{
+			name: "content type header overrides the content type of the body",
+			request: Request{
+				Method:      http.MethodPost,
+				URL:         "http://localhost/users",
+				Headers:     []*Header{{Name: "Content-Type", Value: "application/merge-patch+json"}},
+				Body:        `{"name":"test"}`,
+				ContentType: "application/json",
+			},
+			expectedContentType: "application/merge-patch+json",
+			expectedBody:        `{"name":"test"}`,
+		},
+		{
+			name:                "form",
+			request:             Request{Method: http.MethodPost, URL: "http://localhost/login", Form: map[string]string{"user": "test", "password": "a&b c"}},
+			expectedContentType: "application/x-www-form-urlencoded",
+			expectedBody:        "password=a%26b+c&user=test",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.request.toHTTPRequest(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.request.Method, req.Method)
+			assert.Equal(t, tt.expectedContentType, req.Header.Get("Content-Type"))
+			if tt.expectedBody == "" {
+				assert.Nil(t, req.Body)
+				return
+			}
+			body, err := io.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, string(body))
+		})
+	}
+}
+
+func TestToHTTPRequestMultipart(t *testing.T) {
+	request := Request{
+		Method:  http.MethodPost,
+		URL:     "http://localhost/upload",
+		Headers: []*Header{{Name: "Content-Type", Value: "application/json"}},
+		Multipart: []*Part{
+			{Name: "description", Value: "avatar"},
+			{Name: "avatar", FileName: `me "1".png`, ContentType: "image/png", Content: []byte("png")},
+			{Name: "data", FileName: "data.bin", Content: []byte{0, 1}},
+		},
+	}
+
+	req, err := request.toHTTPRequest(context.Background())
+	assert.NoError(t, err)
+	// the content type of the header can not override the boundary of the parts.
+	err = req.ParseMultipartForm(1 << 20)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"avatar"}, req.MultipartForm.Value["description"])
+
+	avatar := req.MultipartForm.File["avatar"]
+	if assert.Len(t, avatar, 1) {
+		assert.Equal(t, `me "1".png`, avatar[0].Filename)
+		assert.Equal(t, "image/png", avatar[0].Header.Get("Content-Type"))
+		assertFileContent(t, "png", avatar[0])
+	}
+	data := req.MultipartForm.File["data"]
+	if assert.Len(t, data, 1) {
+		assert.Equal(t, "application/octet-stream", data[0].Header.Get("Content-Type"))
+		assertFileContent(t, "\x00\x01", data[0])
+	}
+}
+
+func assertFileContent(t *testing.T, expected string, header *multipart.FileHeader) {
+	t.Helper()
+	f, err := header.Open()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
+
+func TestPlayMultipartAttachment(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("avatar")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		_, _ = fmt.Fprintf(w, `{"file_name": %q, "content": %q}`, header.Filename, content)
+	})
+
+	scenario := &Scenario{
+		Name: "upload",
+		Steps: []*Step{{
+			Name: "upload",
+			Request: &Request{
+				Method:    http.MethodPost,
+				URL:       server.URL,
+				Multipart: []*Part{{Name: "avatar", File: "files/avatar.png", FileName: "avatar.png"}},
+			},
+			Validation: &Validation{
+				Status: &Assertion{Assertion: AssertionMethodEqual, Value: "200"},
+				Body: []*Assertion{
+					{Key: "file_name", Assertion: AssertionMethodEqual, Value: "avatar.png"},
+					{Key: "content", Assertion: AssertionMethodEqual, Value: "png"},
+				},
+			},
+		}},
+	}
+	loaded := []string{}
+	e, err := NewExecutor(scenario, WithAttachmentLoader(func(name string) ([]byte, error) {
+		loaded = append(loaded, name)
+		return []byte("png"), nil
+	}))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"files/avatar.png"}, loaded)
+
+	result, err := e.Play(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+}
+
+func TestNewExecutorAttachmentErrors(t *testing.T) {
+	errNotFound := fmt.Errorf("not found")
+	scenario := func() *Scenario {
+		return &Scenario{
+			Name: "upload",
+			Steps: []*Step{{
+				Name: "upload",
+				Request: &Request{
+					Method:    http.MethodPost,
+					URL:       "http://localhost",
+					Multipart: []*Part{{Name: "avatar", File: "avatar.png"}},
+				},
+			}},
+		}
+	}
+	tests := []struct {
+		name        string
+		opts        []Opts
+		expectedErr string
+	}{
+		{
+			name:        "without attachment loader",
+			expectedErr: "unable to load attachment avatar.png for step upload: no attachment loader",
+		},
+		{
+			name: "attachment not found",
+			opts: []Opts{WithAttachmentLoader(func(string) ([]byte, error) {
+				return nil, errNotFound
+			})},
+			expectedErr: "unable to load attachment avatar.png for step upload: not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewExecutor(scenario(), tt.opts...)
+			assert.EqualError(t, err, tt.expectedErr)
+			assert.ErrorAs(t, err, &ErrInvalidAttachment{})
+		})
+	}
+}
+
+func TestRequestCurl(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  Request
+		expected string
+	}{
+		{
+			name:     "body with content type",
+			request:  Request{Method: http.MethodPost, URL: "http://localhost/users", Body: `{"name":"test"}`, ContentType: "application/json"},
+			expected: "curl -X POST 'http://localhost/users' \\\n  -H 'Content-Type: application/json' \\\n  --data-raw '{\"name\":\"test\"}'",
+		},
+		{
+			name:     "form",
+			request:  Request{Method: http.MethodPost, URL: "http://localhost/login", Form: map[string]string{"user": "test"}},
+			expected: "curl -X POST 'http://localhost/login' \\\n  -H 'Content-Type: application/x-www-form-urlencoded' \\\n  --data-raw 'user=test'",
+		},
+		{
+			name: "multipart with inline file",
+			request: Request{Method: http.MethodPost, URL: "http://localhost/upload", Multipart: []*Part{
+				{Name: "description", Value: "avatar"},
+				{Name: "avatar", FileName: "avatar.png", Content: []byte("png")},
+			}},
+			expected: "curl -X POST 'http://localhost/upload' \\\n  --form-string 'description=avatar' \\\n  -F 'avatar=@avatar.png;filename=avatar.png'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.request.Curl())
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// executeWithRetries executes the step until it succeeds or the retry policy
+// of the step does not allow any further attempts.
+func (e Executor) executeWithRetries(ctx context.Context, step *Step) (*ExecuteStepResult, error) {
+	start := time.Now()
+	attempts := []*AttemptResult{}
+	for {
+		stepResult, err := e.executeAndValidate(ctx, step)
+		attempt := newAttemptResult(step, stepResult, err)
+		attempts = append(attempts, attempt)
+		delay := step.Retry.delay(len(attempts))
+		if ctx.Err() != nil || !step.shouldRetry(stepResult, err) || !step.Retry.allows(len(attempts), time.Since(start)+delay) {
+			stepResult.Attempts = attempts
+			stepResult.Retries = len(attempts) - 1
+			return stepResult, err
+		}
+		attempt.Delay = delay
+		e.logger.Debug(fmt.Sprintf("retrying step %s in %v seconds", step.Name, delay.Seconds()))
+		err = sleep(ctx, delay)
+		if err != nil {
+			stepResult.Attempts = attempts
+			stepResult.Retries = len(attempts) - 1
+			return stepResult, err
+		}
+	}
+}
+
+// sleep waits for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newAttemptResult(step *Step, stepResult *ExecuteStepResult, err error) *AttemptResult {
+	attempt := &AttemptResult{
+		RequestDuration: stepResult.RequestDuration,
+		Success:         err == nil && stepResult.Success,
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	attempt.Status = step.RequestResult.Status
+	return attempt
+}
+
+// shouldRetry reports whether the step is retried after an attempt. Steps with
+// an until assertion set are retried until the assertions succeed, other steps
+// are retried if the attempt failed and the failure is retryable.
+func (s *Step) shouldRetry(stepResult *ExecuteStepResult, err error) bool {
+	if s.Retry == nil {
+		return false
+	}
+	if s.Until != nil && err == nil {
+		return !assertionsSucceeded(s.Until.validate(s.RequestResult))
+	}
+	if err == nil && stepResult.Success {
+		return false
+	}
+	return s.Retry.retryable(s.RequestResult, err)
+}
+
+// retryable reports whether a failed attempt is retried. If neither statuses
+// nor network errors are configured, every failure is retried.
+func (r *Retry) retryable(requestResult *RequestResult, err error) bool {
+	if len(r.Statuses) == 0 && !r.NetworkErrors {
+		return true
+	}
+	if err != nil {
+		return r.NetworkErrors
+	}
+	return slices.Contains(r.Statuses, requestResult.Status)
+}
+
+// allows reports whether another attempt is allowed after the given number of
+// attempts, which would start after the given elapsed time.
+func (r *Retry) allows(attempts int, elapsed time.Duration) bool {
+	if r == nil {
+		return false
+	}
+	if r.MaxDuration > 0 && elapsed > r.MaxDuration {
+		return false
+	}
+	return attempts <= r.Attempts || (r.Attempts == 0 && r.MaxDuration > 0)
+}
+
+// delay returns the delay after the given attempt.
+func (r *Retry) delay(attempt int) time.Duration {
+	if r == nil {
+		return 0
+	}
+	delay := float64(r.Timeout)
+	if r.Backoff > 1 {
+		delay *= math.Pow(r.Backoff, float64(attempt-1))
+	}
+	if r.MaxDelay > 0 {
+		delay = math.Min(delay, float64(r.MaxDelay))
+	}
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlayUntil(t *testing.T) {
+	polls := atomic.Int32{}
+	server := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		state := "pending"
+		if polls.Add(1) >= 3 {
+			state = "done"
+		}
+		_, _ = fmt.Fprintf(w, `{"state": %q}`, state)
+	})
+
+	tests := []struct {
+		name            string
+		attempts        int
+		expectedSuccess bool
+		expectedRetries int
+	}{
+		{
+			name:            "polls until the assertions succeed",
+			attempts:        5,
+			expectedSuccess: true,
+			expectedRetries: 2,
+		},
+		{
+			name:            "stops after the attempts",
+			attempts:        1,
+			expectedSuccess: false,
+			expectedRetries: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls.Store(0)
+			scenario := &Scenario{
+				Name: "until",
+				Steps: []*Step{{
+					Name:    "poll",
+					Request: &Request{Method: http.MethodGet, URL: server.URL},
+					Until: &Validation{Body: []*Assertion{
+						{Key: "state", Assertion: AssertionMethodEqual, Value: "done"},
+					}},
+					Validation: &Validation{Body: []*Assertion{
+						{Key: "state", Assertion: AssertionMethodEqual, Value: "done"},
+					}},
+					Retry: &Retry{Attempts: tt.attempts},
+				}},
+			}
+
+			result, err := newTestExecutor(t, scenario).Play(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSuccess, result.Success)
+			assert.Equal(t, tt.expectedRetries, result.StepResults[0].Retries)
+			assert.Len(t, result.StepResults[0].Attempts, tt.expectedRetries+1)
+		})
+	}
+}
@@ -1,16 +1,21 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/tidwall/gjson"
 )
 
+// defaultTeardownTimeout limits the duration of the teardown steps of scenarios without timeout.
+const defaultTeardownTimeout = time.Minute
+
 // ErrInvalidPathReplacement is an error for when a replacement json path
 // is not found in the defined step's JSON output.
 type ErrInvalidPathReplacement struct {
@@ -42,102 +47,237 @@ func (e ErrJSONKeyNotFound) Error() string {
 }
 
 // ExecuteResult is the result of executing a scenario.
+// Results of setup and teardown steps are reported separately from the
+// results of the main steps of the scenario. Scenarios which are executed
+// multiple times, e.g. for every row of a matrix, report their executions as sub results.
+// Assertions on the scenario as a whole, such as its maximum duration, are
+// reported as assertion results of the scenario.
 type ExecuteResult struct {
 	Name               string
 	TotalExecutionTime time.Duration
 	TotalAssertions    int
+	AssertionResults   []*AssertionResult
+	SetupResults       []*ExecuteStepResult
 	StepResults        []*ExecuteStepResult
+	TeardownResults    []*ExecuteStepResult
+	SubResults         []*ExecuteResult
+	TimedOut           bool
 	Success            bool
 }
 
 // ExecuteStepResult is the result of executing a step.
+// Skipped steps are neither successful nor failed.
 type ExecuteStepResult struct {
-	Name            string
-	Assertions      int
-	URL             string
+	Name             string
+	Assertions       int
+	AssertionResults []*AssertionResult
+	URL              string
+	RequestDuration  time.Duration
+	Duration         time.Duration
+	Retries          int
+	Attempts         []*AttemptResult
+	Skipped          bool
+	Success          bool
+}
+
+// AttemptResult is the result of a single attempt of executing a step.
+// Delay is the time waited after the attempt before the step is retried.
+type AttemptResult struct {
+	Status          int
 	RequestDuration time.Duration
-	Duration        time.Duration
-	Retries         int
+	Delay           time.Duration
 	Success         bool
+	Error           string
 }
 
-// Play executes the scenario.
-func (e Executor) Play() (*ExecuteResult, error) {
+// Play executes the scenario. The main steps are only executed if all setup
+// steps succeed, teardown steps are always executed.
+// The scenario is aborted once the context is done or the timeout of the
+// scenario is exceeded, in which case the result is marked as timed out.
+// Teardown steps are executed even if the scenario is aborted, with their own timeout.
+// Every execution of the scenario starts with an empty cookie jar.
+func (e Executor) Play(ctx context.Context) (*ExecuteResult, error) {
+	scenarioCtx, cancel := e.scenarioContext(ctx)
+	defer cancel()
 	executeResult := &ExecuteResult{
-		Name: e.scenario.Name,
+		Name:            e.scenario.Name,
+		SetupResults:    []*ExecuteStepResult{},
+		StepResults:     []*ExecuteStepResult{},
+		TeardownResults: []*ExecuteStepResult{},
+		SubResults:      []*ExecuteResult{},
+	}
+	e.variables = map[string]string{}
+	e.tokens = newTokenCache()
+	if e.scenario.Cookies {
+		e.httpClient = withCookieJar(e.httpClient)
 	}
 	start := time.Now()
-	totalAssertions := 0
-	success := true
-	for _, step := range e.scenario.Steps {
-		stepResult, err := e.playStep(step)
-		totalAssertions += stepResult.Assertions
-		success = stepResult.Success && success
-		executeResult.StepResults = append(executeResult.StepResults, stepResult)
-		if err != nil {
-			break
-		}
+
+	setupResults, err := e.playSteps(scenarioCtx, e.scenario.Setup)
+	executeResult.SetupResults = setupResults
+	if err == nil && stepsSucceeded(setupResults) {
+		executeResult.StepResults, _ = e.playSteps(scenarioCtx, e.scenario.Steps)
+	} else {
+		e.logger.Warn("setup failed, skipping steps", slog.String("scenario", e.scenario.Name))
 	}
+	executeResult.TeardownResults = e.playTeardown(ctx)
+
 	executeResult.TotalExecutionTime = time.Since(start)
-	executeResult.TotalAssertions = totalAssertions
-	executeResult.Success = success
+	executeResult.AssertionResults = e.scenario.validate(executeResult.TotalExecutionTime)
+	executeResult.TotalAssertions = len(executeResult.AssertionResults)
+	executeResult.TimedOut = scenarioCtx.Err() != nil
+	executeResult.Success = err == nil && !executeResult.TimedOut && assertionsSucceeded(executeResult.AssertionResults)
+	for _, stepResults := range [][]*ExecuteStepResult{executeResult.SetupResults, executeResult.StepResults, executeResult.TeardownResults} {
+		for _, stepResult := range stepResults {
+			if stepResult.Skipped {
+				continue
+			}
+			executeResult.TotalAssertions += stepResult.Assertions
+			executeResult.Success = stepResult.Success && executeResult.Success
+		}
+	}
 	return executeResult, nil
 }
 
-// FIXME don't return error on validation failures, distinct in stepresult.
-func (e Executor) playStep(step *Step) (*ExecuteStepResult, error) {
-	err := e.replaceDynamicInputs(step)
-	if err != nil {
-		return &ExecuteStepResult{}, err
+// scenarioContext returns the context of the setup and main steps, which is done once the
+// given context is done or the timeout of the scenario is exceeded.
+func (e Executor) scenarioContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if e.scenario.Timeout > 0 {
+		return context.WithTimeout(ctx, e.scenario.Timeout)
 	}
+	return context.WithCancel(ctx)
+}
 
-	retries := 0
-	if step.Retry != nil {
-		retries = step.Retry.Attempts
+// teardownTimeout returns the timeout of the teardown steps, which is the timeout of the
+// scenario or defaultTeardownTimeout for scenarios without timeout.
+func (e Executor) teardownTimeout() time.Duration {
+	if e.scenario.Timeout > 0 {
+		return e.scenario.Timeout
 	}
-	timeout := 1 * time.Second
-	if step.Retry != nil {
-		timeout = step.Retry.Timeout
+	return defaultTeardownTimeout
+}
+
+// validate performs the assertions on the scenario as a whole.
+func (s Scenario) validate(totalExecutionTime time.Duration) []*AssertionResult {
+	assertionResults := []*AssertionResult{}
+	if s.MaxDuration > 0 {
+		assertionResults = append(assertionResults, assertDuration(totalExecutionTime, ValidationDuration, &Assertion{
+			Assertion: AssertionMethodLessThanOrEqual,
+			Value:     s.MaxDuration.String(),
+		}))
 	}
+	return assertionResults
+}
+
+// playSteps executes the given steps in order, until a step returns an error.
+func (e Executor) playSteps(ctx context.Context, steps []*Step) ([]*ExecuteStepResult, error) {
+	stepResults := []*ExecuteStepResult{}
+	for _, step := range steps {
+		stepResult, err := e.playStep(ctx, step)
+		stepResults = append(stepResults, stepResult)
+		if err != nil {
+			e.logger.Error("step failed", slog.String("step", step.Name), slog.String("error", err.Error()))
+			return stepResults, err
+		}
+	}
+	return stepResults, nil
+}
+
+// playTeardown executes all teardown steps, regardless of failures of previous steps.
+// Cleanup is needed most when the scenario timed out or the run is cancelled, therefore
+// the teardown steps are not cancelled with the given context but have their own timeout.
+func (e Executor) playTeardown(ctx context.Context) []*ExecuteStepResult {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.teardownTimeout())
+	defer cancel()
+	stepResults := []*ExecuteStepResult{}
+	for _, step := range e.scenario.Teardown {
+		stepResult, err := e.playStep(ctx, step)
+		stepResults = append(stepResults, stepResult)
+		if err != nil {
+			e.logger.Error("teardown step failed", slog.String("step", step.Name), slog.String("error", err.Error()))
+		}
+	}
+	return stepResults
+}
+
+func stepsSucceeded(stepResults []*ExecuteStepResult) bool {
+	for _, stepResult := range stepResults {
+		if !stepResult.Success && !stepResult.Skipped {
+			return false
+		}
+	}
+	return true
+}
+
+func (e Executor) playStep(ctx context.Context, step *Step) (*ExecuteStepResult, error) {
+	execute, err := e.shouldExecute(step)
+	if err != nil {
+		return &ExecuteStepResult{Name: step.Name}, err
+	}
+	if !execute {
+		e.logger.Info("skipping step", slog.String("step", step.Name))
+		return &ExecuteStepResult{
+			Name:             step.Name,
+			URL:              step.Request.URL,
+			AssertionResults: []*AssertionResult{},
+			Attempts:         []*AttemptResult{},
+			Skipped:          true,
+		}, nil
+	}
+
+	err = e.replaceDynamicInputs(step)
+	if err != nil {
+		return &ExecuteStepResult{Name: step.Name}, err
+	}
+
 	start := time.Now()
-	stepResult, err := e.executeWithRetries(retries, timeout, step)
+	stepResult, err := e.executeWithRetries(ctx, step)
+	if err == nil {
+		err = e.captureVariables(step)
+		if err != nil {
+			captureFailed(stepResult, err)
+		}
+	}
 
 	stepResult.Duration = time.Since(start)
+	if !stepResult.Success {
+		e.writeCurl(step)
+	}
 	return stepResult, err
 }
 
-func (e Executor) executeWithRetries(retries int, timeout time.Duration, step *Step) (*ExecuteStepResult, error) {
-	stepResult, err := e.executeAndValidate(step)
-	stepResult.Retries = retries
-	if retries <= 0 {
-		return stepResult, err
+// writeCurl writes the curl command line of the request of a failing step, with all placeholders replaced.
+func (e Executor) writeCurl(step *Step) {
+	if e.curlWriter == nil {
+		return
 	}
-	if err != nil || !stepResult.Success {
-		e.logger.Debug(fmt.Sprintf("retrying step %s in %v seconds", step.Name, timeout.Seconds()))
-		time.Sleep(timeout)
-		return e.executeWithRetries(retries-1, timeout, step)
+	_, err := fmt.Fprintf(e.curlWriter, "# step %s failed, reproduce its request with:\n%s\n\n", step.Name, step.Request.Curl())
+	if err != nil {
+		e.logger.Warn("unable to write curl command", slog.String("step", step.Name), slog.String("error", err.Error()))
 	}
-
-	return stepResult, nil
 }
 
-func (e Executor) executeAndValidate(step *Step) (*ExecuteStepResult, error) {
+func (e Executor) executeAndValidate(ctx context.Context, step *Step) (*ExecuteStepResult, error) {
 	stepResult := &ExecuteStepResult{
-		Name:       step.Name,
-		URL:        step.Request.URL,
-		Assertions: len(step.Validation.Body) + len(step.Validation.Headers) + 1,
-		Success:    false,
+		Name:             step.Name,
+		URL:              step.Request.URL,
+		AssertionResults: []*AssertionResult{},
+		Success:          false,
 	}
 	start := time.Now()
-	requestResult, err := step.executeRequest(e.httpClient)
+	requestResult, err := step.executeRequest(ctx, e.clientFor(step))
 	stepResult.RequestDuration = time.Since(start)
 	if err != nil {
 		return stepResult, err
 	}
 
-	err = step.validate(requestResult)
-	if err == nil {
-		stepResult.Success = true
+	stepResult.AssertionResults = append(step.validate(requestResult), e.validateContract(step, requestResult)...)
+	stepResult.Assertions = len(stepResult.AssertionResults)
+	stepResult.Success = assertionsSucceeded(stepResult.AssertionResults)
+	for _, assertionResult := range stepResult.AssertionResults {
+		if !assertionResult.Success {
+			e.logger.Debug("assertion failed", slog.String("step", step.Name), slog.String("message", assertionResult.Message))
+		}
 	}
 	return stepResult, nil
 }
@@ -147,20 +287,33 @@ func (e Executor) replaceDynamicInputs(step *Step) error {
 	if err != nil {
 		return err
 	}
-	stepJSONString := string(b)
-	replaceKeyMap, err := e.createReplacementMap(stepJSONString)
+	stepJSONString, err := e.replaceStepOutputs(step.Name, string(b))
 	if err != nil {
 		return err
 	}
-	err = e.scenario.findReplacementValues(step.Name, replaceKeyMap)
+	stepJSONString, err = e.replaceVariables(step.Name, stepJSONString)
 	if err != nil {
 		return err
 	}
+	return json.Unmarshal([]byte(stepJSONString), &step)
+}
+
+// replaceStepOutputs replaces all ${steps.<name>.response.body.<key>} and ${steps.<name>.status}
+// placeholders with the values of the responses of previously executed steps.
+func (e Executor) replaceStepOutputs(stepName, s string) (string, error) {
+	replaceKeyMap, err := e.createReplacementMap(s)
+	if err != nil {
+		return "", err
+	}
+	err = e.scenario.findReplacementValues(stepName, replaceKeyMap)
+	if err != nil {
+		return "", err
+	}
 
 	for k, v := range replaceKeyMap {
-		stepJSONString = strings.ReplaceAll(stepJSONString, k, v.ReplacementValue)
+		s = strings.ReplaceAll(s, k, v.ReplacementValue)
 	}
-	return json.Unmarshal([]byte(stepJSONString), &step)
+	return s, nil
 }
 
 func (e Executor) createReplacementMap(stepJSONString string) (map[string]*InputReplacement, error) {
@@ -171,42 +324,64 @@ func (e Executor) createReplacementMap(stepJSONString string) (map[string]*Input
 			e.logger.Warn("invalid dynamic placeholder detected", slog.String("placeholder", strings.Join(placeHolder, ",")))
 			continue
 		}
-		separatedPath := strings.Split(placeHolder[1], ".")
-		if len(separatedPath) <= 3 {
+		replacement, ok := newInputReplacement(strings.Split(placeHolder[1], "."))
+		if !ok {
 			return nil, ErrInvalidPathReplacement{
 				path: placeHolder[1],
 			}
 		}
-		replaceKeyMap[placeHolder[0]] = &InputReplacement{
-			StepName: separatedPath[0],
-			JSONKey:  strings.Join(separatedPath[3:], "."),
-		}
+		replaceKeyMap[placeHolder[0]] = replacement
 	}
 
 	return replaceKeyMap, nil
 }
 
+// newInputReplacement returns the replacement of the path of a step output,
+// which is either <name>.status or <name>.response.body.<key>.
+func newInputReplacement(path []string) (*InputReplacement, bool) {
+	switch {
+	case len(path) == 2 && path[1] == "status":
+		return &InputReplacement{StepName: path[0], Status: true}, true
+	case len(path) > 3:
+		return &InputReplacement{StepName: path[0], JSONKey: strings.Join(path[3:], ".")}, true
+	}
+	return nil, false
+}
+
 func (s Scenario) findReplacementValues(stepName string, replaceKeyMap map[string]*InputReplacement) error {
 	for _, v := range replaceKeyMap {
-		for _, s := range s.Steps {
-			if s.Name == v.StepName {
-				if !s.IsExecuted {
-					return ErrNonExecutedStep{
-						StepName:            stepName,
-						NonExecutedStepName: v.StepName,
-					}
-				}
-				jsonValue := gjson.Get(string(s.RequestResult.Body), v.JSONKey)
-				if !jsonValue.Exists() {
-					return ErrJSONKeyNotFound{
-						StepName: stepName,
-						Key:      v.JSONKey,
-						Body:     string(s.RequestResult.Body),
-					}
-				}
-				v.ReplacementValue = jsonValue.String()
+		for _, step := range s.allSteps() {
+			if step.Name != v.StepName {
+				continue
 			}
+			value, err := step.outputValue(stepName, v)
+			if err != nil {
+				return err
+			}
+			v.ReplacementValue = value
 		}
 	}
 	return nil
 }
+
+// outputValue returns the value of an output of the step, for the step with the given name.
+func (s *Step) outputValue(stepName string, v *InputReplacement) (string, error) {
+	if !s.IsExecuted {
+		return "", ErrNonExecutedStep{
+			StepName:            stepName,
+			NonExecutedStepName: v.StepName,
+		}
+	}
+	if v.Status {
+		return strconv.Itoa(s.RequestResult.Status), nil
+	}
+	jsonValue := gjson.Get(string(s.RequestResult.Body), v.JSONKey)
+	if !jsonValue.Exists() {
+		return "", ErrJSONKeyNotFound{
+			StepName: stepName,
+			Key:      v.JSONKey,
+			Body:     string(s.RequestResult.Body),
+		}
+	}
+	return jsonValue.String(), nil
+}
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestExecutor(t *testing.T, scenario *Scenario, opts ...Opts) *Executor {
+	e, err := NewExecutor(scenario, append([]Opts{WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))}, opts...)...)
+	assert.NoError(t, err)
+	return e
+}
+
+// newTestServer starts a server with the handler, which is closed when the test finishes.
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func statusValidation(status string) *Validation {
+	return &Validation{Status: &Assertion{Assertion: AssertionMethodEqual, Value: status}}
+}
+
+func TestPlayReportsAllFailingAssertions(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"name": "alice", "age": 42}`))
+	})
+
+	scenario := &Scenario{
+		Name: "assertions",
+		Steps: []*Step{{
+			Name:    "create",
+			Request: &Request{Method: http.MethodPost, URL: server.URL},
+			Validation: &Validation{
+				Status:  &Assertion{Assertion: AssertionMethodEqual, Value: "200"},
+				Headers: []*Assertion{{Key: "Content-Type", Assertion: AssertionMethodEqual, Value: "application/json"}},
+				Body: []*Assertion{
+					{Key: "name", Assertion: AssertionMethodEqual, Value: "bob"},
+					{Key: "age", Assertion: AssertionMethodEqual, Value: "42"},
+					{Key: "email", Assertion: AssertionMethodExists},
+				},
+			},
+		}},
+	}
+
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 5, result.TotalAssertions)
+
+	stepResult := result.StepResults[0]
+	assert.False(t, stepResult.Success)
+	assert.Equal(t, 5, stepResult.Assertions)
+	messages := []string{}
+	for _, assertionResult := range stepResult.AssertionResults {
+		if !assertionResult.Success {
+			messages = append(messages, assertionResult.Message)
+		}
+	}
+	assert.Equal(t, []string{
+		"status has value 201, expected 200",
+		"header Content-Type has value text/plain, expected application/json",
+		"body key name has value alice, expected bob",
+		"body key email not found",
+	}, messages)
+}
+
+func TestPlayTeardownAfterFailingStep(t *testing.T) {
+	tornDown := atomic.Bool{}
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/failing":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/teardown":
+			tornDown.Store(true)
+		}
+	})
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	tests := []struct {
+		name            string
+		step            *Step
+		expectedMessage string
+		expectedError   string
+	}{
+		{
+			name: "failing assertion",
+			step: &Step{
+				Name:       "failing",
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/failing"},
+				Validation: statusValidation("200"),
+			},
+			expectedMessage: "status has value 500, expected 200",
+		},
+		{
+			name: "failing request",
+			step: &Step{
+				Name:       "failing",
+				Request:    &Request{Method: http.MethodGet, URL: closedServer.URL},
+				Validation: statusValidation("200"),
+			},
+			expectedError: "connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tornDown.Store(false)
+			scenario := &Scenario{
+				Name:  "failing",
+				Steps: []*Step{tt.step},
+				Teardown: []*Step{{
+					Name:       "teardown",
+					Request:    &Request{Method: http.MethodDelete, URL: server.URL + "/teardown"},
+					Validation: statusValidation("200"),
+				}},
+			}
+
+			result, err := newTestExecutor(t, scenario).Play(context.Background())
+			assert.NoError(t, err)
+			assert.False(t, result.Success)
+			assert.Len(t, result.StepResults, 1)
+			assert.False(t, result.StepResults[0].Success)
+			// the failure of the main step is reported as it is, not replaced by the teardown.
+			if tt.expectedMessage != "" {
+				assert.Len(t, result.StepResults[0].AssertionResults, 1)
+				assert.Equal(t, tt.expectedMessage, result.StepResults[0].AssertionResults[0].Message)
+			}
+			assert.Len(t, result.StepResults[0].Attempts, 1)
+			assert.Contains(t, result.StepResults[0].Attempts[0].Error, tt.expectedError)
+			assert.Len(t, result.TeardownResults, 1)
+			assert.True(t, result.TeardownResults[0].Success)
+			assert.True(t, tornDown.Load())
+		})
+	}
+}
+
+func TestPlayLatencyAndMaxDuration(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	})
+
+	scenario := &Scenario{
+		Name:        "slow",
+		MaxDuration: 50 * time.Millisecond,
+		Steps: []*Step{{
+			Name:    "slow",
+			Request: &Request{Method: http.MethodGet, URL: server.URL},
+			Validation: &Validation{
+				Status:  &Assertion{Assertion: AssertionMethodEqual, Value: "200"},
+				Latency: &Assertion{Assertion: AssertionMethodLessThan, Value: "50ms"},
+			},
+		}},
+	}
+
+	result, err := newTestExecutor(t, scenario).Play(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, 3, result.TotalAssertions)
+
+	stepResult := result.StepResults[0]
+	assert.False(t, stepResult.Success)
+	assert.GreaterOrEqual(t, stepResult.RequestDuration, 100*time.Millisecond)
+	assert.Len(t, stepResult.AssertionResults, 2)
+	assert.True(t, stepResult.AssertionResults[0].Success)
+	latency := stepResult.AssertionResults[1]
+	assert.Equal(t, ValidationLatency, latency.Type)
+	assert.False(t, latency.Success)
+	assert.Equal(t, "50ms", latency.Expected)
+	actual, err := time.ParseDuration(latency.Actual)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, actual, 100*time.Millisecond)
+	assert.Equal(t, "latency was "+latency.Actual+", expected less than 50ms", latency.Message)
+
+	assert.GreaterOrEqual(t, result.TotalExecutionTime, 100*time.Millisecond)
+	assert.Len(t, result.AssertionResults, 1)
+	maxDuration := result.AssertionResults[0]
+	assert.Equal(t, ValidationDuration, maxDuration.Type)
+	assert.False(t, maxDuration.Success)
+	assert.Equal(t, "duration was "+result.TotalExecutionTime.String()+", expected less than or equal to 50ms", maxDuration.Message)
+}
+
+func TestPlayTeardownAfterTimeout(t *testing.T) {
+	tornDown := atomic.Bool{}
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		case "/teardown":
+			tornDown.Store(true)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		ctx      func() (context.Context, context.CancelFunc)
+		scenario *Scenario
+	}{
+		{
+			name: "scenario timeout",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			scenario: &Scenario{Timeout: 100 * time.Millisecond},
+		},
+		{
+			name: "run deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 100*time.Millisecond)
+			},
+			scenario: &Scenario{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tornDown.Store(false)
+			tt.scenario.Name = "timeout"
+			tt.scenario.Steps = []*Step{{
+				Name:       "slow",
+				Request:    &Request{Method: http.MethodGet, URL: server.URL + "/slow"},
+				Validation: statusValidation("200"),
+			}}
+			tt.scenario.Teardown = []*Step{{
+				Name:       "teardown",
+				Request:    &Request{Method: http.MethodDelete, URL: server.URL + "/teardown"},
+				Validation: statusValidation("200"),
+			}}
+			ctx, cancel := tt.ctx()
+			defer cancel()
+
+			result, err := newTestExecutor(t, tt.scenario).Play(ctx)
+			assert.NoError(t, err)
+			assert.True(t, result.TimedOut)
+			assert.False(t, result.Success)
+			assert.False(t, result.StepResults[0].Success)
+			assert.Len(t, result.TeardownResults, 1)
+			assert.True(t, result.TeardownResults[0].Success)
+			assert.True(t, tornDown.Load())
+		})
+	}
+}
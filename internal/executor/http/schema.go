@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/inquiryproj/inquiry/internal/executor/jsonschema"
+)
+
+// ErrInvalidSchema is an error for when the JSON Schema of a step can not be compiled.
+type ErrInvalidSchema struct {
+	StepName string
+	Err      error
+}
+
+func (e ErrInvalidSchema) Error() string {
+	return fmt.Sprintf("invalid schema for step %s: %s", e.StepName, e.Err)
+}
+
+func (e ErrInvalidSchema) Unwrap() error {
+	return e.Err
+}
+
+// Schema is a JSON Schema the response body is validated against. The schema
+// is either defined inline or read from the resource referenced by Ref.
+type Schema struct {
+	Ref    string
+	Inline []byte
+	schema *jsonschema.Schema
+}
+
+func (s *Schema) compile(load jsonschema.Loader) error {
+	document := s.Inline
+	if s.Ref != "" {
+		ref, err := json.Marshal(map[string]string{"$ref": s.Ref})
+		if err != nil {
+			return err
+		}
+		document = ref
+	}
+	schema, err := jsonschema.Compile(document, load)
+	if err != nil {
+		return err
+	}
+	s.schema = schema
+	return nil
+}
+
+func (s *Schema) name() string {
+	if s.Ref != "" {
+		return s.Ref
+	}
+	return "inline"
+}
+
+// compileSchemas compiles the schemas of all steps of the scenario.
+func (s Scenario) compileSchemas(load jsonschema.Loader) error {
+	for _, step := range s.allSteps() {
+		for _, validation := range []*Validation{step.Validation, step.Until} {
+			if validation == nil || validation.Schema == nil {
+				continue
+			}
+			err := validation.Schema.compile(load)
+			if err != nil {
+				return ErrInvalidSchema{StepName: step.Name, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+// assertSchema validates the body against the schema, every violation of the
+// schema is reported as a failed assertion with the JSON pointer of the
+// violating value as key.
+func assertSchema(body []byte, schema *Schema) []*AssertionResult {
+	violations, err := schema.schema.Validate(body)
+	if err != nil {
+		return []*AssertionResult{{
+			Type:      ValidationSchema,
+			Assertion: AssertionMethod("schema"),
+			Expected:  schema.name(),
+			Actual:    string(body),
+			Message:   fmt.Sprintf("body %s", err),
+		}}
+	}
+	if len(violations) == 0 {
+		return []*AssertionResult{{
+			Type:      ValidationSchema,
+			Assertion: AssertionMethod("schema"),
+			Expected:  schema.name(),
+			Success:   true,
+		}}
+	}
+	assertionResults := []*AssertionResult{}
+	for _, violation := range violations {
+		assertionResults = append(assertionResults, &AssertionResult{
+			Type:      ValidationSchema,
+			Key:       violation.Pointer,
+			Assertion: AssertionMethod(violation.Keyword),
+			Expected:  schema.name(),
+			Message:   fmt.Sprintf("body at %s %s", pointerName(violation.Pointer), violation.Message),
+		})
+	}
+	return assertionResults
+}
+
+func pointerName(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
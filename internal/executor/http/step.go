@@ -1,19 +1,24 @@
 package http
 
 import (
+	"context"
 	"fmt"
 	"io"
-	"net/http"
-
-	"github.com/tidwall/gjson"
+	"time"
 )
 
-func (s *Step) executeRequest(httpClient Client) (*RequestResult, error) {
-	req, err := s.Request.toHTTPRequest()
+func (s *Step) executeRequest(ctx context.Context, httpClient Client) (*RequestResult, error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+	req, err := s.Request.toHTTPRequest(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	start := time.Now()
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -27,123 +32,117 @@ func (s *Step) executeRequest(httpClient Client) (*RequestResult, error) {
 	}
 	s.IsExecuted = true
 	s.RequestResult = &RequestResult{
-		Body:    b,
-		Status:  resp.StatusCode,
-		Headers: resp.Header,
+		Body:     b,
+		Status:   resp.StatusCode,
+		Headers:  resp.Header,
+		Duration: time.Since(start),
 	}
 	return s.RequestResult, nil
 }
 
-func (s Step) validate(requestResult *RequestResult) error {
-	err := s.validateStatus(requestResult.Status)
-	if err != nil {
-		return err
-	}
+// validate performs the until and validation assertions of the step on the request result.
+func (s Step) validate(requestResult *RequestResult) []*AssertionResult {
+	return append(s.Until.validate(requestResult), s.Validation.validate(requestResult)...)
+}
 
-	err = s.validateHeaders(requestResult.Headers)
-	if err != nil {
-		return err
+func (v *Validation) validate(requestResult *RequestResult) []*AssertionResult {
+	assertionResults := []*AssertionResult{}
+	if v == nil {
+		return assertionResults
 	}
-
-	err = s.validateBody(requestResult.Body)
-	if err != nil {
-		return err
+	if v.Status != nil {
+		assertionResults = append(assertionResults, assertValue(statusSubject(requestResult.Status), ValidationStatus, v.Status))
 	}
-
-	return nil
-}
-
-func (s Step) validateBody(body []byte) error {
-	for _, assertion := range s.Validation.Body {
-		jsonValue := gjson.Get(string(body), assertion.Key)
-		if !jsonValue.Exists() {
-			return s.errorForMsg(fmt.Sprintf("body key %s not found", assertion.Key))
-		}
-
-		err := s.assertValue(jsonValue.String(), ValidationBody, assertion)
-		if err != nil {
-			return err
-		}
+	for _, assertion := range v.Headers {
+		assertionResults = append(assertionResults, assertValue(headerSubject(requestResult.Headers, assertion.Key), ValidationHeaders, assertion))
 	}
-	return nil
-}
-
-func (s Step) validateStatus(status int) error {
-	if s.Validation.Status == nil {
-		return nil
+	for _, assertion := range v.Cookies {
+		assertionResults = append(assertionResults, assertValue(cookieSubject(requestResult.Headers, assertion.Key), ValidationCookies, assertion))
 	}
-
-	return s.assertValue(fmt.Sprintf("%d", status), ValidationStatus, s.Validation.Status)
+	for _, assertion := range v.Body {
+		assertionResults = append(assertionResults, assertValue(bodySubject(requestResult.Body, assertion.Key), ValidationBody, assertion))
+	}
+	if v.Latency != nil {
+		assertionResults = append(assertionResults, assertDuration(requestResult.Duration, ValidationLatency, v.Latency))
+	}
+	if v.Schema != nil {
+		assertionResults = append(assertionResults, assertSchema(requestResult.Body, v.Schema)...)
+	}
+	return append(assertionResults, v.GraphQL.validate(requestResult.Body)...)
 }
 
-func (s Step) validateHeaders(headers http.Header) error {
-	for _, assertion := range s.Validation.Headers {
-		err := s.assertValue(headers.Get(assertion.Key), ValidationHeaders, assertion)
-		if err != nil {
-			return err
+func assertionsSucceeded(assertionResults []*AssertionResult) bool {
+	for _, assertionResult := range assertionResults {
+		if !assertionResult.Success {
+			return false
 		}
 	}
-	return nil
-}
-
-// AssertionError is an error for when an assertion fails.
-type AssertionError struct {
-	StepName   string
-	RequestURL string
-	Msg        string
+	return true
 }
 
-func (e AssertionError) Error() string {
-	return fmt.Sprintf("failed at step \"%s\" for request to %s: %s", e.StepName, e.RequestURL, e.Msg)
+func assertValue(subject *assertionSubject, validationType validationType, assertion *Assertion) *AssertionResult {
+	assertionResult := &AssertionResult{
+		Type:      validationType,
+		Key:       assertion.Key,
+		Assertion: assertion.Assertion,
+		Expected:  assertion.expected(),
+		Actual:    subject.value,
+	}
+	assert, ok := assertFuncs()[assertion.Assertion]
+	if !ok {
+		assertionResult.Message = fmt.Sprintf("unknown assertion %s", assertion.Assertion)
+		return assertionResult
+	}
+	if !subject.exists && assertion.Assertion != AssertionMethodExists && assertion.Assertion != AssertionMethodNotExists {
+		assertionResult.Message = fmt.Sprintf("%s not found", subjectName(validationType, assertion))
+		return assertionResult
+	}
+	success, msg := assert(subject, assertion)
+	assertionResult.Success = success
+	if !success {
+		assertionResult.Message = fmt.Sprintf("%s %s", subjectName(validationType, assertion), msg)
+	}
+	return assertionResult
 }
 
-func (s Step) assertValue(value string, validationType validationType, assertion *Assertion) error {
-	switch assertion.Assertion {
-	case AssertionMethodEqual:
-		return s.assertEqual(value, validationType, assertion)
-	case AssertionMethodRegex:
-		// FIXME: implement regex assertions
-	case AssertionMethodNotEmpty:
-		return s.assertNotEmpty(value, validationType, assertion)
-	}
-
-	return nil
-}
-
-func (s Step) assertEqual(value string, validationType validationType, assertion *Assertion) error {
-	if value == assertion.Value {
-		return nil
+// assertDuration asserts a measured duration, such as the latency of a request,
+// against an assertion with a duration as value, e.g. 300ms.
+func assertDuration(duration time.Duration, validationType validationType, assertion *Assertion) *AssertionResult {
+	assertionResult := &AssertionResult{
+		Type:      validationType,
+		Assertion: assertion.Assertion,
+		Expected:  assertion.Value,
+		Actual:    duration.String(),
+	}
+	comparison, ok := numberComparisons()[assertion.Assertion]
+	if !ok {
+		assertionResult.Message = fmt.Sprintf("unknown %s assertion %s", validationType, assertion.Assertion)
+		return assertionResult
+	}
+	expected, err := time.ParseDuration(assertion.Value)
+	if err != nil {
+		assertionResult.Message = fmt.Sprintf("%s can not be compared to invalid duration %s", validationType, assertion.Value)
+		return assertionResult
 	}
-	switch validationType {
-	case ValidationBody:
-		return s.errorForMsg(fmt.Sprintf("body key %s has value %s, expected %s", assertion.Key, value, assertion.Value))
-	case ValidationStatus:
-		return s.errorForMsg(fmt.Sprintf("status has value %s, expected %s", value, assertion.Value))
-	case ValidationHeaders:
-		return s.errorForMsg(fmt.Sprintf("header %s has value %s, expected %s", assertion.Key, value, assertion.Value))
+	assertionResult.Success = comparison.compare(float64(duration), float64(expected))
+	if !assertionResult.Success {
+		assertionResult.Message = fmt.Sprintf("%s was %s, expected %s %s", validationType, duration, comparison.description, assertion.Value)
 	}
-	return nil
+	return assertionResult
 }
 
-func (s Step) assertNotEmpty(value string, validationType validationType, assertion *Assertion) error {
-	if value != "" {
-		return nil
-	}
+func subjectName(validationType validationType, assertion *Assertion) string {
 	switch validationType {
 	case ValidationBody:
-		return s.errorForMsg(fmt.Sprintf("body key %s is empty", assertion.Key))
-	case ValidationStatus:
-		return s.errorForMsg("status has no value")
+		return fmt.Sprintf("body key %s", assertion.Key)
 	case ValidationHeaders:
-		return s.errorForMsg(fmt.Sprintf("header %s is empty", assertion.Key))
-	}
-	return nil
-}
-
-func (s Step) errorForMsg(msg string) error {
-	return AssertionError{
-		StepName:   s.Name,
-		RequestURL: s.Request.URL,
-		Msg:        msg,
+		return fmt.Sprintf("header %s", assertion.Key)
+	case ValidationCookies:
+		return fmt.Sprintf("cookie %s", assertion.Key)
+	case ValidationGraphQL:
+		return fmt.Sprintf("data key %s", assertion.Key)
+	case ValidationStatus:
+		return "status"
 	}
+	return string(validationType)
 }
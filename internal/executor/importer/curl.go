@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"net/url"
+
+	"github.com/inquiryproj/inquiry/internal/executor/curl"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// ImportCurl converts a curl command line to a step. Unless a name is given, the
+// step is named after the method and the path of the request.
+func ImportCurl(name, command string) (*yaml.Step, error) {
+	request, err := curl.Parse(command)
+	if err != nil {
+		return nil, err
+	}
+	return curlRequestToStep(name, request), nil
+}
+
+// ImportCurlArgs converts the arguments of a curl command line, which are already split by a shell, to a step.
+func ImportCurlArgs(name string, args []string) (*yaml.Step, error) {
+	request, err := curl.ParseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return curlRequestToStep(name, request), nil
+}
+
+func curlRequestToStep(name string, request *curl.Request) *yaml.Step {
+	if name == "" {
+		path := request.URL
+		if u, err := url.Parse(request.URL); err == nil {
+			path = u.Path
+		}
+		name = slug(request.Method + " " + path)
+	}
+	step := &yaml.Step{
+		Name: name,
+		Request: &yaml.Request{
+			Method:  request.Method,
+			URL:     request.URL,
+			Headers: []*yaml.Header{},
+			Body:    request.Body,
+		},
+	}
+	for _, header := range request.Headers {
+		step.Request.Headers = append(step.Request.Headers, &yaml.Header{Name: header.Name, Value: header.Value})
+	}
+	for _, part := range request.Parts {
+		step.Request.Multipart = append(step.Request.Multipart, &yaml.Part{
+			Name:        part.Name,
+			Value:       part.Value,
+			File:        part.File,
+			FileName:    part.FileName,
+			ContentType: part.ContentType,
+		})
+	}
+	return step
+}
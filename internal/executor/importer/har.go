@@ -0,0 +1,140 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+const (
+	harScenarioName = "har"
+	baseURLVariable = "base_url"
+)
+
+type har struct {
+	Log *struct {
+		Pages   []*harPage  `json:"pages"`
+		Entries []*harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harPage struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type harEntry struct {
+	PageRef string `json:"pageref"`
+	Request struct {
+		Method   string       `json:"method"`
+		URL      string       `json:"url"`
+		Headers  []*harHeader `json:"headers"`
+		PostData *struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Status int `json:"status"`
+	} `json:"response"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ImportHAR converts the entries of a HAR file to scenarios, one scenario is created for
+// every page. Every entry is converted to a step asserting the recorded response status.
+// The origin of the first request of a scenario is stored as the base_url variable.
+func ImportHAR(data []byte) ([]*Scenario, error) {
+	h := &har{}
+	err := json.Unmarshal(data, h)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHAR, err)
+	}
+	if h.Log == nil {
+		return nil, fmt.Errorf("%w: log is missing", ErrInvalidHAR)
+	}
+	titles := map[string]string{}
+	for _, page := range h.Log.Pages {
+		titles[page.ID] = page.Title
+	}
+	scenarios := []*Scenario{}
+	byPage := map[string]*Scenario{}
+	for _, entry := range h.Log.Entries {
+		// Requests which were blocked or aborted have no response status.
+		if entry.Response.Status == 0 {
+			continue
+		}
+		scenario, ok := byPage[entry.PageRef]
+		if !ok {
+			scenario = newScenario(harScenarioName, baseURL(entry.Request.URL))
+			if title := titles[entry.PageRef]; title != "" {
+				scenario.Name = slug(title)
+			}
+			byPage[entry.PageRef] = scenario
+			scenarios = append(scenarios, scenario)
+		}
+		scenario.addStep(harEntryToStep(entry, scenario.TestSpec.Variables))
+	}
+	return uniqueNames(scenarios), nil
+}
+
+func harEntryToStep(entry *harEntry, variables []*yaml.Variable) *yaml.Step {
+	u, err := url.Parse(entry.Request.URL)
+	path := entry.Request.URL
+	if err == nil {
+		path = u.Path
+	}
+	step := &yaml.Step{
+		Name: slug(entry.Request.Method + " " + path),
+		Request: &yaml.Request{
+			Method:  strings.ToUpper(entry.Request.Method),
+			URL:     withBaseURL(entry.Request.URL, variables),
+			Headers: []*yaml.Header{},
+		},
+		Validation: &yaml.Validation{
+			Status: &yaml.Assertion{
+				Assertion: yaml.AssertionMethodEqual,
+				Value:     strconv.Itoa(entry.Response.Status),
+			},
+		},
+	}
+	for _, header := range entry.Request.Headers {
+		if skipHeader(header.Name) {
+			continue
+		}
+		step.Request.Headers = append(step.Request.Headers, &yaml.Header{Name: header.Name, Value: header.Value})
+	}
+	if entry.Request.PostData != nil {
+		step.Request.Body = entry.Request.PostData.Text
+	}
+	return step
+}
+
+// baseURL returns the base_url variable for the origin of the URL.
+func baseURL(rawURL string) []*yaml.Variable {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return []*yaml.Variable{}
+	}
+	return []*yaml.Variable{{Name: baseURLVariable, Value: u.Scheme + "://" + u.Host}}
+}
+
+// withBaseURL replaces the origin of the URL with the base_url variable, if it matches.
+func withBaseURL(rawURL string, variables []*yaml.Variable) string {
+	for _, variable := range variables {
+		if variable.Name != baseURLVariable {
+			continue
+		}
+		if rawURL == variable.Value || strings.HasPrefix(rawURL, variable.Value+"/") || strings.HasPrefix(rawURL, variable.Value+"?") {
+			return fmt.Sprintf("${variables.%s}%s", baseURLVariable, strings.TrimPrefix(rawURL, variable.Value))
+		}
+	}
+	return rawURL
+}
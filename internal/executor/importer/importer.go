@@ -0,0 +1,134 @@
+// Package importer converts recorded requests and request collections of other
+// tools to test scenarios.
+//
+// HAR files, as exported by the developer tools of browsers, and Postman
+// collections (v2.1) are supported. Every page of a HAR file and every top level
+// folder of a Postman collection is converted to a separate scenario.
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// Format is the format of imported data.
+type Format string
+
+// Different formats.
+const (
+	FormatHAR     Format = "har"
+	FormatPostman Format = "postman"
+)
+
+// error definitions.
+var (
+	ErrUnknownFormat     = fmt.Errorf("unknown import format, expected har or postman")
+	ErrInvalidHAR        = fmt.Errorf("invalid HAR file")
+	ErrInvalidCollection = fmt.Errorf("invalid Postman collection")
+	ErrNoRequests        = fmt.Errorf("no requests to import")
+)
+
+// Scenario is an imported scenario.
+type Scenario struct {
+	Name     string
+	TestSpec *yaml.TestSpec
+	Scenario *yaml.Scenario
+}
+
+// Marshal returns the YAML test definition of the scenario.
+func (s *Scenario) Marshal() ([]byte, error) {
+	return yaml.MarshalTestDefinition(s.TestSpec, s.Scenario)
+}
+
+// Import converts the data of the given format to scenarios.
+func Import(format Format, data []byte) ([]*Scenario, error) {
+	var scenarios []*Scenario
+	var err error
+	switch format {
+	case FormatHAR:
+		scenarios, err = ImportHAR(data)
+	case FormatPostman:
+		scenarios, err = ImportPostman(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(scenarios) == 0 {
+		return nil, ErrNoRequests
+	}
+	return scenarios, nil
+}
+
+func newScenario(name string, variables []*yaml.Variable) *Scenario {
+	return &Scenario{
+		Name: name,
+		TestSpec: &yaml.TestSpec{
+			Version:   "v1",
+			Type:      yaml.TestTypeHTTP,
+			Variables: variables,
+		},
+		Scenario: &yaml.Scenario{
+			Steps: []*yaml.Step{},
+		},
+	}
+}
+
+// addStep adds a step to the scenario, the name of the step is made unique within the scenario.
+func (s *Scenario) addStep(step *yaml.Step) {
+	name := step.Name
+	for i := 2; s.hasStep(step.Name); i++ {
+		step.Name = fmt.Sprintf("%s_%d", name, i)
+	}
+	s.Scenario.Steps = append(s.Scenario.Steps, step)
+}
+
+func (s *Scenario) hasStep(name string) bool {
+	for _, step := range s.Scenario.Steps {
+		if step.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueNames makes the names of the scenarios unique.
+func uniqueNames(scenarios []*Scenario) []*Scenario {
+	names := map[string]bool{}
+	for _, scenario := range scenarios {
+		name := scenario.Name
+		for i := 2; names[scenario.Name]; i++ {
+			scenario.Name = fmt.Sprintf("%s_%d", name, i)
+		}
+		names[scenario.Name] = true
+	}
+	return scenarios
+}
+
+// slug converts a name, e.g. Get users or GET /users/{id}, to a snake case name.
+func slug(s string) string {
+	s = regexp.MustCompile(`[^a-zA-Z0-9]+`).ReplaceAllString(s, "_")
+	s = strings.ToLower(strings.Trim(s, "_"))
+	if s == "" {
+		return "request"
+	}
+	return s
+}
+
+// skipHeader reports whether a recorded header is skipped, as it is either
+// set by the HTTP client or specific to the recorded session.
+func skipHeader(name string) bool {
+	if strings.HasPrefix(name, ":") {
+		return true
+	}
+	switch strings.ToLower(name) {
+	case "host", "content-length", "connection", "accept-encoding", "cookie":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImport converts the fixtures of the testdata directory and compares the scenarios with the
+// expected YAML test definitions in the directory named after the fixture, e.g. testdata/recording
+// for testdata/recording.har.
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name              string
+		format            Format
+		fixture           string
+		expectedScenarios []string
+	}{
+		{
+			name:              "HAR file",
+			format:            FormatHAR,
+			fixture:           "recording.har",
+			expectedScenarios: []string{"login", "login_2"},
+		},
+		{
+			name:              "Postman collection",
+			format:            FormatPostman,
+			fixture:           "collection.json",
+			expectedScenarios: []string{"users_api", "users", "users_2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if !assert.NoError(t, err) {
+				return
+			}
+			scenarios, err := Import(tt.format, data)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			names := []string{}
+			for _, scenario := range scenarios {
+				names = append(names, scenario.Name)
+				spec, err := scenario.Marshal()
+				assert.NoError(t, err)
+				expected, err := os.ReadFile(filepath.Join("testdata", strings.TrimSuffix(tt.fixture, filepath.Ext(tt.fixture)), scenario.Name+".yaml"))
+				assert.NoError(t, err)
+				assert.Equal(t, string(expected), string(spec), scenario.Name)
+			}
+			assert.Equal(t, tt.expectedScenarios, names)
+		})
+	}
+}
+
+func TestImportInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		data        string
+		expectedErr error
+	}{
+		{
+			name:        "unknown format",
+			format:      Format("insomnia"),
+			data:        `{}`,
+			expectedErr: ErrUnknownFormat,
+		},
+		{
+			name:        "HAR file which is no JSON",
+			format:      FormatHAR,
+			data:        `log:`,
+			expectedErr: ErrInvalidHAR,
+		},
+		{
+			name:        "HAR file without log",
+			format:      FormatHAR,
+			data:        `{"entries": []}`,
+			expectedErr: ErrInvalidHAR,
+		},
+		{
+			name:        "HAR file with invalid entries",
+			format:      FormatHAR,
+			data:        `{"log": {"entries": {}}}`,
+			expectedErr: ErrInvalidHAR,
+		},
+		{
+			name:        "HAR file without responses",
+			format:      FormatHAR,
+			data:        `{"log": {"entries": [{"request": {"method": "GET", "url": "http://localhost"}, "response": {"status": 0}}]}}`,
+			expectedErr: ErrNoRequests,
+		},
+		{
+			name:        "Postman collection which is no JSON",
+			format:      FormatPostman,
+			data:        `info:`,
+			expectedErr: ErrInvalidCollection,
+		},
+		{
+			name:        "Postman collection without info",
+			format:      FormatPostman,
+			data:        `{"item": []}`,
+			expectedErr: ErrInvalidCollection,
+		},
+		{
+			name:        "Postman collection with invalid URL",
+			format:      FormatPostman,
+			data:        `{"info": {"name": "api"}, "item": [{"name": "get", "request": {"url": 1}}]}`,
+			expectedErr: ErrInvalidCollection,
+		},
+		{
+			name:        "Postman collection with empty folders",
+			format:      FormatPostman,
+			data:        `{"info": {"name": "api"}, "item": [{"name": "folder", "item": []}]}`,
+			expectedErr: ErrNoRequests,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarios, err := Import(tt.format, []byte(tt.data))
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Nil(t, scenarios)
+		})
+	}
+}
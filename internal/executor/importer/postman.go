@@ -0,0 +1,343 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+const postmanScenarioName = "collection"
+
+type postmanCollection struct {
+	Info *struct {
+		Name   string `json:"name"`
+		Schema string `json:"schema"`
+	} `json:"info"`
+	Item     []*postmanItem     `json:"item"`
+	Variable []*postmanVariable `json:"variable"`
+}
+
+// postmanItem is either a request or a folder of items.
+type postmanItem struct {
+	Name     string             `json:"name"`
+	Item     []*postmanItem     `json:"item"`
+	Variable []*postmanVariable `json:"variable"`
+	Request  *postmanRequest    `json:"request"`
+	Response []*struct {
+		Code int `json:"code"`
+	} `json:"response"`
+}
+
+type postmanVariable struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+type postmanRequest struct {
+	Method string             `json:"method"`
+	Header []*postmanVariable `json:"header"`
+	Body   *postmanBody       `json:"body"`
+	URL    postmanURL         `json:"url"`
+}
+
+type postmanBody struct {
+	Mode       string             `json:"mode"`
+	Raw        string             `json:"raw"`
+	URLEncoded []*postmanVariable `json:"urlencoded"`
+	FormData   []*struct {
+		postmanVariable
+		Type string `json:"type"`
+		Src  any    `json:"src"`
+	} `json:"formdata"`
+	GraphQL *struct {
+		Query     string `json:"query"`
+		Variables string `json:"variables"`
+	} `json:"graphql"`
+	Options *struct {
+		Raw *struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+// UnmarshalJSON supports requests defined as a URL only.
+func (r *postmanRequest) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if json.Unmarshal(data, &rawURL) == nil {
+		*r = postmanRequest{Method: "GET", URL: postmanURL(rawURL)}
+		return nil
+	}
+	type request postmanRequest
+	return json.Unmarshal(data, (*request)(r))
+}
+
+// postmanURL is the raw URL of a request, which is defined as either a string or an object.
+type postmanURL string
+
+// UnmarshalJSON supports URLs defined as both a string and an object.
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if json.Unmarshal(data, &rawURL) == nil {
+		*u = postmanURL(rawURL)
+		return nil
+	}
+	object := struct {
+		Raw string `json:"raw"`
+	}{}
+	err := json.Unmarshal(data, &object)
+	if err != nil {
+		return err
+	}
+	*u = postmanURL(object.Raw)
+	return nil
+}
+
+// ImportPostman converts a Postman collection (v2.1) to scenarios. Requests at the root of the
+// collection are converted to a scenario named after the collection, every folder at the root
+// is converted to a separate scenario, which contains the requests of all nested folders.
+// Variables of the collection and the folders are converted to variables of the scenarios,
+// variables referenced as {{name}} are replaced by ${variables.name}.
+func ImportPostman(data []byte) ([]*Scenario, error) {
+	collection := &postmanCollection{}
+	err := json.Unmarshal(data, collection)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCollection, err)
+	}
+	if collection.Info == nil {
+		return nil, fmt.Errorf("%w: info is missing", ErrInvalidCollection)
+	}
+	name := postmanScenarioName
+	if collection.Info.Name != "" {
+		name = slug(collection.Info.Name)
+	}
+	root := newScenario(name, postmanVariables(collection.Variable))
+	scenarios := []*Scenario{root}
+	for _, item := range collection.Item {
+		if item.Request != nil {
+			root.addStep(postmanItemToStep(item))
+			continue
+		}
+		folder := newScenario(slug(item.Name), postmanVariables(collection.Variable))
+		addPostmanItems(folder, item)
+		scenarios = append(scenarios, folder)
+	}
+	result := []*Scenario{}
+	for _, scenario := range scenarios {
+		if len(scenario.Scenario.Steps) == 0 {
+			continue
+		}
+		addReferencedVariables(scenario)
+		result = append(result, scenario)
+	}
+	return uniqueNames(result), nil
+}
+
+// addPostmanItems adds the requests of a folder and its nested folders to the scenario.
+func addPostmanItems(scenario *Scenario, folder *postmanItem) {
+	scenario.TestSpec.Variables = setVariables(scenario.TestSpec.Variables, postmanVariables(folder.Variable))
+	for _, item := range folder.Item {
+		if item.Request != nil {
+			scenario.addStep(postmanItemToStep(item))
+			continue
+		}
+		addPostmanItems(scenario, item)
+	}
+}
+
+func postmanItemToStep(item *postmanItem) *yaml.Step {
+	step := &yaml.Step{
+		Name: slug(item.Name),
+		Request: &yaml.Request{
+			Method:  strings.ToUpper(item.Request.Method),
+			URL:     replaceVariables(string(item.Request.URL)),
+			Headers: []*yaml.Header{},
+		},
+	}
+	if step.Request.Method == "" {
+		step.Request.Method = "GET"
+	}
+	for _, header := range item.Request.Header {
+		if header.Disabled || skipHeader(header.Key) {
+			continue
+		}
+		step.Request.Headers = append(step.Request.Headers, &yaml.Header{
+			Name:  header.Key,
+			Value: replaceVariables(fmt.Sprint(header.Value)),
+		})
+	}
+	setPostmanBody(step.Request, item.Request.Body)
+	// The status of the first saved example response is asserted.
+	if len(item.Response) > 0 && item.Response[0].Code != 0 {
+		step.Validation = &yaml.Validation{
+			Status: &yaml.Assertion{
+				Assertion: yaml.AssertionMethodEqual,
+				Value:     strconv.Itoa(item.Response[0].Code),
+			},
+		}
+	}
+	return step
+}
+
+// setPostmanBody sets the body of the request. Raw bodies are kept as is, as they
+// may contain variables which are not valid JSON, e.g. {"limit": {{limit}}}.
+func setPostmanBody(request *yaml.Request, body *postmanBody) {
+	if body == nil {
+		return
+	}
+	contentType := ""
+	switch body.Mode {
+	case "raw":
+		request.Body = replaceVariables(body.Raw)
+		if body.Options != nil && body.Options.Raw != nil && body.Options.Raw.Language == "json" {
+			contentType = "application/json"
+		}
+	case "urlencoded":
+		request.Form = map[string]string{}
+		for _, field := range body.URLEncoded {
+			if !field.Disabled {
+				request.Form[replaceVariables(field.Key)] = replaceVariables(fmt.Sprint(field.Value))
+			}
+		}
+	case "formdata":
+		request.Multipart = postmanFormData(body)
+	case "graphql":
+		request.Body, contentType = postmanGraphQL(body)
+	}
+	if contentType != "" && !hasHeader(request.Headers, "Content-Type") {
+		request.Headers = append(request.Headers, &yaml.Header{Name: "Content-Type", Value: contentType})
+	}
+}
+
+// postmanFormData converts form data to multipart parts, files are attachments referenced by their path.
+func postmanFormData(body *postmanBody) []*yaml.Part {
+	parts := []*yaml.Part{}
+	for _, field := range body.FormData {
+		if field.Disabled {
+			continue
+		}
+		part := &yaml.Part{Name: field.Key}
+		switch src := field.Src.(type) {
+		case string:
+			part.File = src
+		case []any:
+			if len(src) > 0 {
+				part.File = fmt.Sprint(src[0])
+			}
+		}
+		if field.Type != "file" && field.Value != nil {
+			part.Value = replaceVariables(fmt.Sprint(field.Value))
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+func postmanGraphQL(body *postmanBody) (string, string) {
+	if body.GraphQL == nil {
+		return "", ""
+	}
+	payload := map[string]any{"query": body.GraphQL.Query}
+	var variables any
+	if json.Unmarshal([]byte(body.GraphQL.Variables), &variables) == nil {
+		payload["variables"] = variables
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", ""
+	}
+	return string(b), "application/json"
+}
+
+func hasHeader(headers []*yaml.Header, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func postmanVariables(postmanVariables []*postmanVariable) []*yaml.Variable {
+	variables := []*yaml.Variable{}
+	for _, variable := range postmanVariables {
+		if variable.Disabled || variable.Key == "" {
+			continue
+		}
+		value := ""
+		if variable.Value != nil {
+			value = fmt.Sprint(variable.Value)
+		}
+		variables = setVariables(variables, []*yaml.Variable{{Name: variable.Key, Value: replaceVariables(value)}})
+	}
+	return variables
+}
+
+// setVariables sets the variables, overriding variables which are already defined.
+func setVariables(variables, override []*yaml.Variable) []*yaml.Variable {
+	for _, variable := range override {
+		defined := false
+		for _, v := range variables {
+			if v.Name == variable.Name {
+				v.Value = variable.Value
+				defined = true
+			}
+		}
+		if !defined {
+			variables = append(variables, &yaml.Variable{Name: variable.Name, Value: variable.Value})
+		}
+	}
+	return variables
+}
+
+func postmanVariablePattern() *regexp.Regexp {
+	return regexp.MustCompile(`{{\s*([a-zA-Z0-9_.-]+)\s*}}`)
+}
+
+// replaceVariables replaces Postman variables, e.g. {{base_url}}, by variables
+// of the scenario. Dynamic variables, such as {{$guid}}, are left as is.
+func replaceVariables(s string) string {
+	return postmanVariablePattern().ReplaceAllString(s, "$${variables.$1}")
+}
+
+// addReferencedVariables defines the variables which are referenced by the steps of the scenario,
+// but not defined in the collection, e.g. variables of a Postman environment, with an empty value.
+func addReferencedVariables(scenario *Scenario) {
+	pattern := regexp.MustCompile(`\${variables\.([a-zA-Z0-9_.-]+)}`)
+	referenced := []*yaml.Variable{}
+	for _, step := range scenario.Scenario.Steps {
+		values := []string{step.Request.URL, step.Request.Body}
+		for _, header := range step.Request.Headers {
+			values = append(values, header.Value)
+		}
+		for name, value := range step.Request.Form {
+			values = append(values, name, value)
+		}
+		for _, part := range step.Request.Multipart {
+			values = append(values, part.Value)
+		}
+		for _, value := range values {
+			for _, match := range pattern.FindAllStringSubmatch(value, -1) {
+				referenced = append(referenced, &yaml.Variable{Name: match[1]})
+			}
+		}
+	}
+	for _, variable := range referenced {
+		if !hasVariable(scenario.TestSpec.Variables, variable.Name) {
+			scenario.TestSpec.Variables = append(scenario.TestSpec.Variables, variable)
+		}
+	}
+}
+
+func hasVariable(variables []*yaml.Variable, name string) bool {
+	for _, variable := range variables {
+		if variable.Name == name {
+			return true
+		}
+	}
+	return false
+}
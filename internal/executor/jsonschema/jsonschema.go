@@ -0,0 +1,289 @@
+// Package jsonschema validates JSON documents against a JSON Schema (draft 2020-12).
+//
+// All assertion and applicator keywords of the draft are supported, with the
+// exception of unevaluatedProperties, unevaluatedItems and the format keyword,
+// which is treated as an annotation. The nullable keyword of OpenAPI 3.0 schemas
+// is supported as well. References are resolved within the schema and to other
+// documents, which are read with a Loader, e.g.:
+//
+//	{"$ref": "user.schema.json#/$defs/address"}
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// error definitions.
+var (
+	ErrInvalidSchema   = fmt.Errorf("invalid schema")
+	ErrInvalidInstance = fmt.Errorf("invalid JSON")
+)
+
+// Loader loads the document of a schema referenced by name.
+type Loader func(name string) ([]byte, error)
+
+// Violation describes a part of a JSON document which does not conform to the schema.
+// Pointer is the JSON pointer of the value which violates the keyword.
+type Violation struct {
+	Pointer string
+	Keyword string
+	Message string
+}
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	load      Loader
+	documents map[string]any
+	patterns  map[string]*regexp.Regexp
+	keywords  map[string]keywordFunc
+}
+
+// Compile compiles a JSON Schema, referenced documents are loaded with the given loader.
+func Compile(data []byte, load Loader) (*Schema, error) {
+	root, err := decodeSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		load:      load,
+		documents: map[string]any{"": root},
+		patterns:  map[string]*regexp.Regexp{},
+		keywords:  keywords(),
+	}
+	err = s.compile("", root)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate validates a JSON document against the schema, every violation of the schema is returned.
+func (s *Schema) Validate(data []byte) ([]*Violation, error) {
+	var instance any
+	err := json.Unmarshal(data, &instance)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInstance, err)
+	}
+	return s.validate("", s.documents[""], instance, "", nil), nil
+}
+
+func decodeSchema(data []byte) (any, error) {
+	var schema any
+	err := json.Unmarshal(data, &schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSchema, err)
+	}
+	return schema, nil
+}
+
+// compile verifies the schema and its subschemas, such that all references
+// can be resolved and all patterns are valid regular expressions.
+func (s *Schema) compile(document string, schema any) error {
+	object, ok := schema.(map[string]any)
+	if !ok {
+		if _, ok := schema.(bool); ok {
+			return nil
+		}
+		return fmt.Errorf("%w: schema must be an object or a boolean", ErrInvalidSchema)
+	}
+	err := s.compileKeywords(document, object)
+	if err != nil {
+		return err
+	}
+	for _, subschema := range subschemas(object) {
+		err := s.compile(document, subschema)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compileKeywords(document string, object map[string]any) error {
+	if ref, ok := object["$ref"].(string); ok {
+		_, _, err := s.resolve(document, ref)
+		if err != nil {
+			return err
+		}
+	}
+	patterns := []string{}
+	if pattern, ok := object["pattern"].(string); ok {
+		patterns = append(patterns, pattern)
+	}
+	if patternProperties, ok := object["patternProperties"].(map[string]any); ok {
+		for pattern := range patternProperties {
+			patterns = append(patterns, pattern)
+		}
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("%w: pattern %s is not a valid regular expression: %s", ErrInvalidSchema, pattern, err)
+		}
+		s.patterns[pattern] = re
+	}
+	return nil
+}
+
+// subschemas returns the direct subschemas of a schema object.
+func subschemas(object map[string]any) []any {
+	result := []any{}
+	for _, keyword := range []string{"not", "if", "then", "else", "items", "contains", "additionalProperties", "propertyNames"} {
+		if subschema, ok := object[keyword]; ok {
+			result = append(result, subschema)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		if subschemas, ok := object[keyword].([]any); ok {
+			result = append(result, subschemas...)
+		}
+	}
+	for _, keyword := range []string{"properties", "patternProperties", "dependentSchemas", "$defs", "definitions"} {
+		if subschemas, ok := object[keyword].(map[string]any); ok {
+			for _, name := range sortedKeys(subschemas) {
+				result = append(result, subschemas[name])
+			}
+		}
+	}
+	return result
+}
+
+// resolve resolves a reference relative to the given document, it returns the
+// document containing the referenced schema and the schema itself.
+func (s *Schema) resolve(document, ref string) (string, any, error) {
+	name, fragment, _ := strings.Cut(ref, "#")
+	if name == "" {
+		name = document
+	}
+	root, err := s.document(name)
+	if err != nil {
+		return "", nil, err
+	}
+	schema, err := resolvePointer(root, fragment)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: unable to resolve reference %s: %s", ErrInvalidSchema, ref, err)
+	}
+	return name, schema, nil
+}
+
+func (s *Schema) document(name string) (any, error) {
+	if document, ok := s.documents[name]; ok {
+		return document, nil
+	}
+	if s.load == nil {
+		return nil, fmt.Errorf("%w: unable to load referenced schema %s", ErrInvalidSchema, name)
+	}
+	data, err := s.load(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to load referenced schema %s: %s", ErrInvalidSchema, name, err)
+	}
+	document, err := decodeSchema(data)
+	if err != nil {
+		return nil, err
+	}
+	s.documents[name] = document
+	err = s.compile(name, document)
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func resolvePointer(document any, pointer string) (any, error) {
+	pointer, err := url.PathUnescape(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if pointer == "" {
+		return document, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("only JSON pointer fragments are supported")
+	}
+	current := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[token]
+			if !ok {
+				return nil, fmt.Errorf("%s not found", token)
+			}
+			current = next
+		case []any:
+			index, ok := arrayIndex(token, len(value))
+			if !ok {
+				return nil, fmt.Errorf("index %s out of range", token)
+			}
+			current = value[index]
+		default:
+			return nil, fmt.Errorf("%s not found", token)
+		}
+	}
+	return current, nil
+}
+
+func arrayIndex(token string, length int) (int, bool) {
+	index := 0
+	_, err := fmt.Sscanf(token, "%d", &index)
+	return index, err == nil && index >= 0 && index < length
+}
+
+// refChain is the chain of references which are resolved to validate an instance, every reference
+// is identified by the referenced schema and the pointer of the validated instance.
+type refChain struct {
+	key    string
+	parent *refChain
+}
+
+func (c *refChain) contains(key string) bool {
+	for ; c != nil; c = c.parent {
+		if c.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schema) validate(document string, schema any, instance any, pointer string, refs *refChain) []*Violation {
+	switch schema := schema.(type) {
+	case bool:
+		if schema {
+			return nil
+		}
+		return []*Violation{{Pointer: pointer, Keyword: "false", Message: "is not allowed"}}
+	case map[string]any:
+		sc := scope{schema: s, document: document, object: schema, pointer: pointer, refs: refs}
+		violations := []*Violation{}
+		for _, keyword := range sortedKeys(schema) {
+			validateKeyword, ok := s.keywords[keyword]
+			if !ok {
+				continue
+			}
+			violations = append(violations, validateKeyword(sc, schema[keyword], instance)...)
+		}
+		return violations
+	default:
+		return nil
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// appendPointer appends a reference token to a JSON pointer.
+func appendPointer(pointer string, token any) string {
+	escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(token))
+	return pointer + "/" + escaped
+}
@@ -0,0 +1,481 @@
+package jsonschema
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name               string
+		schema             string
+		instance           string
+		expectedViolations []*Violation
+	}{
+		{
+			name:     "type valid",
+			schema:   `{"type": "string"}`,
+			instance: `"value"`,
+		},
+		{
+			name:     "type invalid",
+			schema:   `{"type": "string"}`,
+			instance: `1`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "type", Message: "has type integer, expected string"},
+			},
+		},
+		{
+			name:     "type integer accepts integral numbers",
+			schema:   `{"type": "integer"}`,
+			instance: `1.0`,
+		},
+		{
+			name:     "type number accepts integers",
+			schema:   `{"type": "number"}`,
+			instance: `1`,
+		},
+		{
+			name:     "type list",
+			schema:   `{"type": ["string", "null"]}`,
+			instance: `null`,
+		},
+		{
+			name:     "type list invalid",
+			schema:   `{"type": ["string", "null"]}`,
+			instance: `1.5`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "type", Message: "has type number, expected string or null"},
+			},
+		},
+		{
+			name:     "nullable",
+			schema:   `{"type": "string", "nullable": true}`,
+			instance: `null`,
+		},
+		{
+			name:     "enum valid",
+			schema:   `{"enum": ["a", 1, {"b": true}]}`,
+			instance: `{"b": true}`,
+		},
+		{
+			name:     "enum invalid",
+			schema:   `{"enum": ["a", "b"]}`,
+			instance: `"c"`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "enum", Message: `has value "c", expected one of ["a","b"]`},
+			},
+		},
+		{
+			name:     "const invalid",
+			schema:   `{"const": 1}`,
+			instance: `2`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "const", Message: "has value 2, expected 1"},
+			},
+		},
+		{
+			name:     "multipleOf valid",
+			schema:   `{"multipleOf": 0.1}`,
+			instance: `0.3`,
+		},
+		{
+			name:     "multipleOf invalid",
+			schema:   `{"multipleOf": 2}`,
+			instance: `3`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "multipleOf", Message: "has value 3, expected a multiple of 2"},
+			},
+		},
+		{
+			name:     "number bounds valid",
+			schema:   `{"minimum": 1, "maximum": 3, "exclusiveMinimum": 0, "exclusiveMaximum": 4}`,
+			instance: `3`,
+		},
+		{
+			name:     "number bounds invalid",
+			schema:   `{"minimum": 1, "exclusiveMaximum": 0}`,
+			instance: `0`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "exclusiveMaximum", Message: "has value 0, expected less than 0"},
+				{Pointer: "", Keyword: "minimum", Message: "has value 0, expected at least 1"},
+			},
+		},
+		{
+			name:     "string length counts characters",
+			schema:   `{"minLength": 2, "maxLength": 2}`,
+			instance: `"äö"`,
+		},
+		{
+			name:     "string length invalid",
+			schema:   `{"maxLength": 2}`,
+			instance: `"abc"`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "maxLength", Message: "has length 3, expected at most 2"},
+			},
+		},
+		{
+			name:     "pattern invalid",
+			schema:   `{"pattern": "^[a-z]+$"}`,
+			instance: `"abc1"`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "pattern", Message: "has value abc1, expected to match ^[a-z]+$"},
+			},
+		},
+		{
+			name:     "keywords of other types are ignored",
+			schema:   `{"pattern": "^[a-z]+$", "minimum": 10, "required": ["a"], "minItems": 1}`,
+			instance: `"abc"`,
+		},
+		{
+			name:     "format is an annotation",
+			schema:   `{"type": "string", "format": "email"}`,
+			instance: `"not an email"`,
+		},
+		{
+			name:     "array length invalid",
+			schema:   `{"minItems": 2}`,
+			instance: `[1]`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "minItems", Message: "has 1 items, expected at least 2"},
+			},
+		},
+		{
+			name:     "uniqueItems invalid",
+			schema:   `{"uniqueItems": true}`,
+			instance: `[1, {"a": 1}, {"a": 1}]`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "uniqueItems", Message: "has duplicate items at 1 and 2"},
+			},
+		},
+		{
+			name:     "contains valid",
+			schema:   `{"contains": {"type": "string"}, "maxContains": 2}`,
+			instance: `[1, "a", "b"]`,
+		},
+		{
+			name:     "contains invalid",
+			schema:   `{"contains": {"type": "string"}, "minContains": 2}`,
+			instance: `[1, "a"]`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "contains", Message: "has 1 items matching contains, expected at least 2"},
+			},
+		},
+		{
+			name:     "object size invalid",
+			schema:   `{"maxProperties": 1}`,
+			instance: `{"a": 1, "b": 2}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "maxProperties", Message: "has 2 properties, expected at most 1"},
+			},
+		},
+		{
+			name:     "required valid",
+			schema:   `{"required": ["a", "b"]}`,
+			instance: `{"a": null, "b": 1}`,
+		},
+		{
+			name:     "required invalid",
+			schema:   `{"required": ["a", "b"]}`,
+			instance: `{"b": 1}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "required", Message: "is missing required property a"},
+			},
+		},
+		{
+			name:     "dependentRequired invalid",
+			schema:   `{"dependentRequired": {"card": ["address"]}}`,
+			instance: `{"card": "1234"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "dependentRequired", Message: "is missing property address, required by property card"},
+			},
+		},
+		{
+			name:     "properties valid",
+			schema:   `{"properties": {"name": {"type": "string"}, "age": {"type": "integer"}}}`,
+			instance: `{"name": "bob", "other": true}`,
+		},
+		{
+			name:     "properties invalid",
+			schema:   `{"properties": {"user": {"properties": {"a/b": {"type": "string"}}}}}`,
+			instance: `{"user": {"a/b": 1}}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/user/a~1b", Keyword: "type", Message: "has type integer, expected string"},
+			},
+		},
+		{
+			name:     "patternProperties invalid",
+			schema:   `{"patternProperties": {"^x-": {"type": "string"}}}`,
+			instance: `{"x-id": 1, "id": 1}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/x-id", Keyword: "type", Message: "has type integer, expected string"},
+			},
+		},
+		{
+			name:     "additionalProperties false",
+			schema:   `{"properties": {"a": true}, "patternProperties": {"^x-": true}, "additionalProperties": false}`,
+			instance: `{"a": 1, "x-b": 2, "c": 3}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "additionalProperties", Message: "has unexpected property c"},
+			},
+		},
+		{
+			name:     "additionalProperties schema",
+			schema:   `{"additionalProperties": {"type": "integer"}}`,
+			instance: `{"a": 1, "b": "2"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/b", Keyword: "type", Message: "has type string, expected integer"},
+			},
+		},
+		{
+			name:     "propertyNames invalid",
+			schema:   `{"propertyNames": {"maxLength": 2}}`,
+			instance: `{"ab": 1, "abc": 2}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/abc", Keyword: "maxLength", Message: "has length 3, expected at most 2"},
+			},
+		},
+		{
+			name:     "items valid",
+			schema:   `{"items": {"type": "integer"}}`,
+			instance: `[1, 2, 3]`,
+		},
+		{
+			name:     "items invalid",
+			schema:   `{"items": {"type": "integer"}}`,
+			instance: `[1, "2", 3]`,
+			expectedViolations: []*Violation{
+				{Pointer: "/1", Keyword: "type", Message: "has type string, expected integer"},
+			},
+		},
+		{
+			name:     "prefixItems and items",
+			schema:   `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`,
+			instance: `[1, "a"]`,
+			expectedViolations: []*Violation{
+				{Pointer: "/0", Keyword: "type", Message: "has type integer, expected string"},
+				{Pointer: "/1", Keyword: "type", Message: "has type string, expected integer"},
+			},
+		},
+		{
+			name:     "items false",
+			schema:   `{"prefixItems": [true], "items": false}`,
+			instance: `[1, 2]`,
+			expectedViolations: []*Violation{
+				{Pointer: "/1", Keyword: "false", Message: "is not allowed"},
+			},
+		},
+		{
+			name:     "allOf valid",
+			schema:   `{"allOf": [{"type": "integer"}, {"minimum": 1}]}`,
+			instance: `1`,
+		},
+		{
+			name:     "allOf invalid",
+			schema:   `{"allOf": [{"type": "integer"}, {"minimum": 1}]}`,
+			instance: `0.5`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "type", Message: "has type number, expected integer"},
+				{Pointer: "", Keyword: "minimum", Message: "has value 0.5, expected at least 1"},
+			},
+		},
+		{
+			name:     "anyOf valid",
+			schema:   `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`,
+			instance: `"a"`,
+		},
+		{
+			name:     "anyOf invalid",
+			schema:   `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`,
+			instance: `true`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "anyOf", Message: "does not match any of the schemas in anyOf"},
+			},
+		},
+		{
+			name:     "oneOf valid",
+			schema:   `{"oneOf": [{"type": "integer"}, {"type": "string"}]}`,
+			instance: `1`,
+		},
+		{
+			name:     "oneOf matches several schemas",
+			schema:   `{"oneOf": [{"type": "integer"}, {"minimum": 0}]}`,
+			instance: `1`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "oneOf", Message: "matches 2 of the schemas in oneOf, expected exactly one"},
+			},
+		},
+		{
+			name:     "not invalid",
+			schema:   `{"not": {"type": "null"}}`,
+			instance: `null`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "not", Message: "matches the schema in not"},
+			},
+		},
+		{
+			name:     "if then",
+			schema:   `{"if": {"properties": {"type": {"const": "card"}}}, "then": {"required": ["number"]}, "else": {"required": ["iban"]}}`,
+			instance: `{"type": "card"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "required", Message: "is missing required property number"},
+			},
+		},
+		{
+			name:     "if else",
+			schema:   `{"if": {"properties": {"type": {"const": "card"}}}, "then": {"required": ["number"]}, "else": {"required": ["iban"]}}`,
+			instance: `{"type": "bank"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "required", Message: "is missing required property iban"},
+			},
+		},
+		{
+			name:     "dependentSchemas invalid",
+			schema:   `{"dependentSchemas": {"card": {"required": ["address"]}}}`,
+			instance: `{"card": "1234"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "required", Message: "is missing required property address"},
+			},
+		},
+		{
+			name:     "false schema",
+			schema:   `false`,
+			instance: `{}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "false", Message: "is not allowed"},
+			},
+		},
+		{
+			name:     "$ref to definition",
+			schema:   `{"properties": {"id": {"$ref": "#/$defs/id"}}, "$defs": {"id": {"type": "integer"}}}`,
+			instance: `{"id": "1"}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/id", Keyword: "type", Message: "has type string, expected integer"},
+			},
+		},
+		{
+			name:     "$ref to escaped pointer",
+			schema:   `{"$ref": "#/$defs/a~1b", "$defs": {"a/b": {"type": "integer"}}}`,
+			instance: `1`,
+		},
+		{
+			name:     "recursive $ref consuming the instance",
+			schema:   `{"type": "object", "properties": {"child": {"$ref": "#"}}}`,
+			instance: `{"child": {"child": {"child": 1}}}`,
+			expectedViolations: []*Violation{
+				{Pointer: "/child/child/child", Keyword: "type", Message: "has type integer, expected object"},
+			},
+		},
+		{
+			name:     "$ref to itself",
+			schema:   `{"$ref": "#"}`,
+			instance: `{}`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "$ref", Message: "circular reference #"},
+			},
+		},
+		{
+			name:     "$ref cycle",
+			schema:   `{"$ref": "#/$defs/a", "$defs": {"a": {"allOf": [{"$ref": "#/$defs/b"}]}, "b": {"$ref": "#/$defs/a"}}}`,
+			instance: `1`,
+			expectedViolations: []*Violation{
+				{Pointer: "", Keyword: "$ref", Message: "circular reference #/$defs/a"},
+			},
+		},
+		{
+			name:     "repeated $ref without cycle",
+			schema:   `{"allOf": [{"$ref": "#/$defs/a"}, {"$ref": "#/$defs/a"}], "$defs": {"a": {"type": "integer"}}}`,
+			instance: `1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Compile([]byte(tt.schema), nil)
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			violations, err := schema.Validate([]byte(tt.instance))
+			assert.NoError(t, err)
+			if tt.expectedViolations == nil {
+				tt.expectedViolations = []*Violation{}
+			}
+			assert.ElementsMatch(t, tt.expectedViolations, violations)
+		})
+	}
+}
+
+func TestValidateReferencedDocument(t *testing.T) {
+	documents := map[string]string{
+		"user.schema.json":    `{"type": "object", "required": ["address"], "properties": {"address": {"$ref": "#/$defs/address"}}, "$defs": {"address": {"$ref": "address.schema.json"}}}`,
+		"address.schema.json": `{"type": "object", "required": ["city"]}`,
+		"cycle.schema.json":   `{"$ref": "#"}`,
+	}
+	load := func(name string) ([]byte, error) {
+		document, ok := documents[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return []byte(document), nil
+	}
+
+	schema, err := Compile([]byte(`{"$ref": "user.schema.json"}`), load)
+	assert.NoError(t, err)
+	violations, err := schema.Validate([]byte(`{"address": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Violation{{Pointer: "/address", Keyword: "required", Message: "is missing required property city"}}, violations)
+
+	schema, err = Compile([]byte(`{"$ref": "cycle.schema.json"}`), load)
+	assert.NoError(t, err)
+	violations, err = schema.Validate([]byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Violation{{Pointer: "", Keyword: "$ref", Message: "circular reference #"}}, violations)
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name:   "invalid JSON",
+			schema: `{`,
+		},
+		{
+			name:   "schema is no object",
+			schema: `{"properties": {"a": 1}}`,
+		},
+		{
+			name:   "invalid pattern",
+			schema: `{"pattern": "("}`,
+		},
+		{
+			name:   "invalid pattern property",
+			schema: `{"patternProperties": {"(": true}}`,
+		},
+		{
+			name:   "unresolved reference",
+			schema: `{"$ref": "#/$defs/missing"}`,
+		},
+		{
+			name:   "reference to other document without loader",
+			schema: `{"$ref": "other.schema.json"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.schema), nil)
+			assert.ErrorIs(t, err, ErrInvalidSchema)
+		})
+	}
+}
+
+func TestValidateInvalidInstance(t *testing.T) {
+	schema, err := Compile([]byte(`true`), nil)
+	assert.NoError(t, err)
+
+	_, err = schema.Validate([]byte(`{`))
+	assert.ErrorIs(t, err, ErrInvalidInstance)
+}
@@ -0,0 +1,474 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// scope is the schema object in which a keyword is validated.
+type scope struct {
+	schema   *Schema
+	document string
+	object   map[string]any
+	pointer  string
+	refs     *refChain
+}
+
+func (s scope) violation(keyword, format string, args ...any) []*Violation {
+	return []*Violation{{Pointer: s.pointer, Keyword: keyword, Message: fmt.Sprintf(format, args...)}}
+}
+
+func (s scope) validate(schema any, instance any, pointer string) []*Violation {
+	return s.schema.validate(s.document, schema, instance, pointer, s.refs)
+}
+
+func (s scope) valid(schema any, instance any) bool {
+	return len(s.validate(schema, instance, s.pointer)) == 0
+}
+
+// keywordFunc validates an instance against the value of a keyword.
+type keywordFunc func(s scope, value any, instance any) []*Violation
+
+func keywords() map[string]keywordFunc {
+	return map[string]keywordFunc{
+		"$ref":                 validateRef,
+		"type":                 validateType,
+		"enum":                 validateEnum,
+		"const":                validateConst,
+		"multipleOf":           validateMultipleOf,
+		"maximum":              numberBound("maximum", func(n, bound float64) bool { return n <= bound }, "at most"),
+		"exclusiveMaximum":     numberBound("exclusiveMaximum", func(n, bound float64) bool { return n < bound }, "less than"),
+		"minimum":              numberBound("minimum", func(n, bound float64) bool { return n >= bound }, "at least"),
+		"exclusiveMinimum":     numberBound("exclusiveMinimum", func(n, bound float64) bool { return n > bound }, "greater than"),
+		"maxLength":            lengthBound("maxLength", stringLength, func(n, bound int) bool { return n <= bound }, "has length %d, expected at most %d"),
+		"minLength":            lengthBound("minLength", stringLength, func(n, bound int) bool { return n >= bound }, "has length %d, expected at least %d"),
+		"pattern":              validatePattern,
+		"maxItems":             lengthBound("maxItems", arrayLength, func(n, bound int) bool { return n <= bound }, "has %d items, expected at most %d"),
+		"minItems":             lengthBound("minItems", arrayLength, func(n, bound int) bool { return n >= bound }, "has %d items, expected at least %d"),
+		"uniqueItems":          validateUniqueItems,
+		"contains":             validateContains,
+		"maxProperties":        lengthBound("maxProperties", objectLength, func(n, bound int) bool { return n <= bound }, "has %d properties, expected at most %d"),
+		"minProperties":        lengthBound("minProperties", objectLength, func(n, bound int) bool { return n >= bound }, "has %d properties, expected at least %d"),
+		"required":             validateRequired,
+		"dependentRequired":    validateDependentRequired,
+		"allOf":                validateAllOf,
+		"anyOf":                validateAnyOf,
+		"oneOf":                validateOneOf,
+		"not":                  validateNot,
+		"if":                   validateIf,
+		"dependentSchemas":     validateDependentSchemas,
+		"properties":           validateProperties,
+		"patternProperties":    validatePatternProperties,
+		"additionalProperties": validateAdditionalProperties,
+		"propertyNames":        validatePropertyNames,
+		"prefixItems":          validatePrefixItems,
+		"items":                validateItems,
+	}
+}
+
+func validateRef(s scope, value any, instance any) []*Violation {
+	ref, _ := value.(string)
+	document, schema, err := s.schema.resolve(s.document, ref)
+	if err != nil {
+		return s.violation("$ref", "%s", err)
+	}
+	// a reference which is resolved again for the same instance does not terminate, e.g. {"$ref": "#"}.
+	_, fragment, _ := strings.Cut(ref, "#")
+	key := document + "#" + fragment + " " + s.pointer
+	if s.refs.contains(key) {
+		return s.violation("$ref", "circular reference %s", ref)
+	}
+	return s.schema.validate(document, schema, instance, s.pointer, &refChain{key: key, parent: s.refs})
+}
+
+func validateType(s scope, value any, instance any) []*Violation {
+	if instance == nil && s.object["nullable"] == true {
+		return nil
+	}
+	types := []string{}
+	switch value := value.(type) {
+	case string:
+		types = append(types, value)
+	case []any:
+		for _, t := range value {
+			types = append(types, fmt.Sprint(t))
+		}
+	}
+	for _, t := range types {
+		if hasType(instance, t) {
+			return nil
+		}
+	}
+	return s.violation("type", "has type %s, expected %s", typeName(instance), strings.Join(types, " or "))
+}
+
+func hasType(instance any, t string) bool {
+	switch t {
+	case "integer":
+		n, ok := instance.(float64)
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	default:
+		return typeName(instance) == t
+	}
+}
+
+func typeName(instance any) string {
+	switch instance := instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if instance == math.Trunc(instance) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func validateEnum(s scope, value any, instance any) []*Violation {
+	values, _ := value.([]any)
+	for _, v := range values {
+		if reflect.DeepEqual(v, instance) {
+			return nil
+		}
+	}
+	return s.violation("enum", "has value %s, expected one of %s", formatValue(instance), formatValue(values))
+}
+
+func validateConst(s scope, value any, instance any) []*Violation {
+	if reflect.DeepEqual(value, instance) {
+		return nil
+	}
+	return s.violation("const", "has value %s, expected %s", formatValue(instance), formatValue(value))
+}
+
+func validateMultipleOf(s scope, value any, instance any) []*Violation {
+	n, ok := instance.(float64)
+	divisor, isNumber := value.(float64)
+	if !ok || !isNumber || divisor == 0 {
+		return nil
+	}
+	quotient := n / divisor
+	if math.Abs(quotient-math.Round(quotient)) < 1e-9 {
+		return nil
+	}
+	return s.violation("multipleOf", "has value %v, expected a multiple of %v", n, divisor)
+}
+
+func numberBound(keyword string, inBound func(n, bound float64) bool, description string) keywordFunc {
+	return func(s scope, value any, instance any) []*Violation {
+		n, ok := instance.(float64)
+		bound, isNumber := value.(float64)
+		if !ok || !isNumber || inBound(n, bound) {
+			return nil
+		}
+		return s.violation(keyword, "has value %v, expected %s %v", n, description, bound)
+	}
+}
+
+func stringLength(instance any) (int, bool) {
+	str, ok := instance.(string)
+	return utf8.RuneCountInString(str), ok
+}
+
+func arrayLength(instance any) (int, bool) {
+	array, ok := instance.([]any)
+	return len(array), ok
+}
+
+func objectLength(instance any) (int, bool) {
+	object, ok := instance.(map[string]any)
+	return len(object), ok
+}
+
+func lengthBound(keyword string, length func(instance any) (int, bool), inBound func(n, bound int) bool, format string) keywordFunc {
+	return func(s scope, value any, instance any) []*Violation {
+		n, ok := length(instance)
+		bound, isNumber := value.(float64)
+		if !ok || !isNumber || inBound(n, int(bound)) {
+			return nil
+		}
+		return s.violation(keyword, format, n, int(bound))
+	}
+}
+
+func validatePattern(s scope, value any, instance any) []*Violation {
+	str, ok := instance.(string)
+	pattern, _ := value.(string)
+	re, compiled := s.schema.patterns[pattern]
+	if !ok || !compiled || re.MatchString(str) {
+		return nil
+	}
+	return s.violation("pattern", "has value %s, expected to match %s", str, pattern)
+}
+
+func validateUniqueItems(s scope, value any, instance any) []*Violation {
+	array, ok := instance.([]any)
+	if !ok || value != true {
+		return nil
+	}
+	for i := range array {
+		for j := i + 1; j < len(array); j++ {
+			if reflect.DeepEqual(array[i], array[j]) {
+				return s.violation("uniqueItems", "has duplicate items at %d and %d", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func validateContains(s scope, value any, instance any) []*Violation {
+	array, ok := instance.([]any)
+	if !ok {
+		return nil
+	}
+	matches := 0
+	for _, item := range array {
+		if s.valid(value, item) {
+			matches++
+		}
+	}
+	minContains := 1
+	if n, ok := s.object["minContains"].(float64); ok {
+		minContains = int(n)
+	}
+	if matches < minContains {
+		return s.violation("contains", "has %d items matching contains, expected at least %d", matches, minContains)
+	}
+	if n, ok := s.object["maxContains"].(float64); ok && matches > int(n) {
+		return s.violation("contains", "has %d items matching contains, expected at most %d", matches, int(n))
+	}
+	return nil
+}
+
+func validateRequired(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	required, _ := value.([]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, name := range required {
+		if _, ok := object[fmt.Sprint(name)]; !ok {
+			violations = append(violations, s.violation("required", "is missing required property %s", name)...)
+		}
+	}
+	return violations
+}
+
+func validateDependentRequired(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	dependencies, _ := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(dependencies) {
+		if _, ok := object[property]; !ok {
+			continue
+		}
+		required, _ := dependencies[property].([]any)
+		for _, name := range required {
+			if _, ok := object[fmt.Sprint(name)]; !ok {
+				violations = append(violations, s.violation("dependentRequired", "is missing property %s, required by property %s", name, property)...)
+			}
+		}
+	}
+	return violations
+}
+
+func validateAllOf(s scope, value any, instance any) []*Violation {
+	schemas, _ := value.([]any)
+	violations := []*Violation{}
+	for _, schema := range schemas {
+		violations = append(violations, s.validate(schema, instance, s.pointer)...)
+	}
+	return violations
+}
+
+func validateAnyOf(s scope, value any, instance any) []*Violation {
+	schemas, _ := value.([]any)
+	for _, schema := range schemas {
+		if s.valid(schema, instance) {
+			return nil
+		}
+	}
+	return s.violation("anyOf", "does not match any of the schemas in anyOf")
+}
+
+func validateOneOf(s scope, value any, instance any) []*Violation {
+	schemas, _ := value.([]any)
+	matches := 0
+	for _, schema := range schemas {
+		if s.valid(schema, instance) {
+			matches++
+		}
+	}
+	if matches == 1 {
+		return nil
+	}
+	return s.violation("oneOf", "matches %d of the schemas in oneOf, expected exactly one", matches)
+}
+
+func validateNot(s scope, value any, instance any) []*Violation {
+	if !s.valid(value, instance) {
+		return nil
+	}
+	return s.violation("not", "matches the schema in not")
+}
+
+func validateIf(s scope, value any, instance any) []*Violation {
+	keyword := "else"
+	if s.valid(value, instance) {
+		keyword = "then"
+	}
+	schema, ok := s.object[keyword]
+	if !ok {
+		return nil
+	}
+	return s.validate(schema, instance, s.pointer)
+}
+
+func validateDependentSchemas(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	schemas, _ := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(schemas) {
+		if _, ok := object[property]; ok {
+			violations = append(violations, s.validate(schemas[property], instance, s.pointer)...)
+		}
+	}
+	return violations
+}
+
+func validateProperties(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	schemas, _ := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(schemas) {
+		if propertyValue, ok := object[property]; ok {
+			violations = append(violations, s.validate(schemas[property], propertyValue, appendPointer(s.pointer, property))...)
+		}
+	}
+	return violations
+}
+
+func validatePatternProperties(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	schemas, _ := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(object) {
+		for _, pattern := range sortedKeys(schemas) {
+			re, ok := s.schema.patterns[pattern]
+			if ok && re.MatchString(property) {
+				violations = append(violations, s.validate(schemas[pattern], object[property], appendPointer(s.pointer, property))...)
+			}
+		}
+	}
+	return violations
+}
+
+func validateAdditionalProperties(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(object) {
+		if s.isDefinedProperty(property) {
+			continue
+		}
+		if value == false {
+			violations = append(violations, s.violation("additionalProperties", "has unexpected property %s", property)...)
+			continue
+		}
+		violations = append(violations, s.validate(value, object[property], appendPointer(s.pointer, property))...)
+	}
+	return violations
+}
+
+// isDefinedProperty returns whether a property is covered by the properties
+// or patternProperties of the schema object.
+func (s scope) isDefinedProperty(property string) bool {
+	if properties, ok := s.object["properties"].(map[string]any); ok {
+		if _, ok := properties[property]; ok {
+			return true
+		}
+	}
+	patternProperties, _ := s.object["patternProperties"].(map[string]any)
+	for pattern := range patternProperties {
+		if re, ok := s.schema.patterns[pattern]; ok && re.MatchString(property) {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePropertyNames(s scope, value any, instance any) []*Violation {
+	object, ok := instance.(map[string]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for _, property := range sortedKeys(object) {
+		violations = append(violations, s.validate(value, property, appendPointer(s.pointer, property))...)
+	}
+	return violations
+}
+
+func validatePrefixItems(s scope, value any, instance any) []*Violation {
+	array, ok := instance.([]any)
+	schemas, _ := value.([]any)
+	if !ok {
+		return nil
+	}
+	violations := []*Violation{}
+	for i := 0; i < len(schemas) && i < len(array); i++ {
+		violations = append(violations, s.validate(schemas[i], array[i], appendPointer(s.pointer, i))...)
+	}
+	return violations
+}
+
+func validateItems(s scope, value any, instance any) []*Violation {
+	array, ok := instance.([]any)
+	if !ok {
+		return nil
+	}
+	prefixItems, _ := s.object["prefixItems"].([]any)
+	violations := []*Violation{}
+	for i := len(prefixItems); i < len(array); i++ {
+		violations = append(violations, s.validate(value, array[i], appendPointer(s.pointer, i))...)
+	}
+	return violations
+}
+
+func formatValue(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
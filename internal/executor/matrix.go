@@ -0,0 +1,181 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/http"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// error definitions.
+var (
+	ErrEmptyMatrix              = fmt.Errorf("matrix does not contain any rows")
+	ErrUnsupportedDatasetFormat = fmt.Errorf("unsupported dataset format, expected .csv or .json")
+)
+
+// matrixApp plays a scenario once for every row of its matrix.
+type matrixApp struct {
+	name string
+	apps []*app
+}
+
+func newMatrixApp(name string, definitions []*rowDefinition, options *options) (*matrixApp, error) {
+	matrixApp := &matrixApp{
+		name: name,
+	}
+	for _, definition := range definitions {
+		rowApp, err := newAppForTestDefinition(fmt.Sprintf("%s [%s]", name, rowDescription(definition.row)), definition.testSpec, definition.scenario, options)
+		if err != nil {
+			return nil, err
+		}
+		matrixApp.apps = append(matrixApp.apps, rowApp)
+	}
+	return matrixApp, nil
+}
+
+// Play plays the scenario for every row of the matrix, the result of every row is
+// reported as sub result of the scenario.
+func (m *matrixApp) Play(ctx context.Context) (*http.ExecuteResult, error) {
+	executeResult := &http.ExecuteResult{
+		Name:             m.name,
+		AssertionResults: []*http.AssertionResult{},
+		SetupResults:     []*http.ExecuteStepResult{},
+		StepResults:      []*http.ExecuteStepResult{},
+		TeardownResults:  []*http.ExecuteStepResult{},
+		SubResults:       []*http.ExecuteResult{},
+		Success:          true,
+	}
+	for _, a := range m.apps {
+		subResult, err := a.Play(ctx)
+		if err != nil {
+			return nil, err
+		}
+		executeResult.SubResults = append(executeResult.SubResults, subResult)
+		executeResult.TotalExecutionTime += subResult.TotalExecutionTime
+		executeResult.TotalAssertions += subResult.TotalAssertions
+		executeResult.TimedOut = subResult.TimedOut || executeResult.TimedOut
+		executeResult.Success = subResult.Success && executeResult.Success
+	}
+	return executeResult, nil
+}
+
+func matrixRows(matrix *yaml.Matrix, resourceReader ResourceReader) ([]map[string]string, error) {
+	rows := []map[string]string{}
+	rows = append(rows, matrix.Rows...)
+	if matrix.Dataset != "" {
+		datasetRows, err := readDataset(matrix.Dataset, resourceReader)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, datasetRows...)
+	}
+	if len(matrix.Parameters) == 0 {
+		return rows, nil
+	}
+	if len(rows) == 0 {
+		rows = append(rows, map[string]string{})
+	}
+	return expandParameters(rows, matrix.Parameters), nil
+}
+
+// expandParameters combines every row with every combination of the parameter values.
+func expandParameters(rows []map[string]string, parameters map[string][]string) []map[string]string {
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		expanded := []map[string]string{}
+		for _, row := range rows {
+			for _, value := range parameters[name] {
+				expandedRow := map[string]string{name: value}
+				for k, v := range row {
+					expandedRow[k] = v
+				}
+				expanded = append(expanded, expandedRow)
+			}
+		}
+		rows = expanded
+	}
+	return rows
+}
+
+func readDataset(name string, resourceReader ResourceReader) ([]map[string]string, error) {
+	data, err := resourceReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset %s: %w", name, err)
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return csvRows(data)
+	case ".json":
+		return jsonRows(data)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDatasetFormat, name)
+	}
+}
+
+// csvRows parses a CSV dataset, the first record contains the variable names.
+func csvRows(data []byte) ([]map[string]string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv dataset: %w", err)
+	}
+	rows := []map[string]string{}
+	if len(records) == 0 {
+		return rows, nil
+	}
+	header := records[0]
+	for _, record := range records[1:] {
+		row := map[string]string{}
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// jsonRows parses a JSON dataset, which consists of an array of objects.
+// Values which are not strings are used as their JSON representation.
+func jsonRows(data []byte) ([]map[string]string, error) {
+	objects := []map[string]json.RawMessage{}
+	err := json.Unmarshal(data, &objects)
+	if err != nil {
+		return nil, fmt.Errorf("invalid json dataset: %w", err)
+	}
+	rows := []map[string]string{}
+	for _, object := range objects {
+		row := map[string]string{}
+		for name, raw := range object {
+			var value string
+			if json.Unmarshal(raw, &value) != nil {
+				value = string(raw)
+			}
+			row[name] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func rowDescription(row map[string]string) string {
+	names := make([]string, 0, len(row))
+	for name := range row {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(row))
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=%s", name, row[name]))
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,325 @@
+package executor
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+func TestCSVRows(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		expectedRows []map[string]string
+		expectedErr  error
+	}{
+		{
+			name: "rows",
+			data: "id,name\n1,alice\n2,\"bob, jr\"\n",
+			expectedRows: []map[string]string{
+				{"id": "1", "name": "alice"},
+				{"id": "2", "name": "bob, jr"},
+			},
+		},
+		{
+			name:         "header only",
+			data:         "id,name\n",
+			expectedRows: []map[string]string{},
+		},
+		{
+			name:         "empty",
+			data:         "",
+			expectedRows: []map[string]string{},
+		},
+		{
+			name:        "row with missing field",
+			data:        "id,name\n1\n",
+			expectedErr: csv.ErrFieldCount,
+		},
+		{
+			name:        "row with extra field",
+			data:        "id,name\n1,alice,admin\n",
+			expectedErr: csv.ErrFieldCount,
+		},
+		{
+			name:        "unterminated quote",
+			data:        "id,name\n1,\"alice\n",
+			expectedErr: csv.ErrQuote,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := csvRows([]byte(tt.data))
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRows, rows)
+		})
+	}
+}
+
+func TestJSONRows(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		expectedRows []map[string]string
+		expectedErr  bool
+	}{
+		{
+			name: "rows",
+			data: `[{"id": 1, "name": "alice", "admin": true, "tags": ["a"], "manager": null}, {"name": "bob"}]`,
+			expectedRows: []map[string]string{
+				{"id": "1", "name": "alice", "admin": "true", "tags": `["a"]`, "manager": ""},
+				{"name": "bob"},
+			},
+		},
+		{
+			name:         "empty",
+			data:         `[]`,
+			expectedRows: []map[string]string{},
+		},
+		{
+			name:        "object",
+			data:        `{"id": 1}`,
+			expectedErr: true,
+		},
+		{
+			name:        "array of values",
+			data:        `[1, 2]`,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid json",
+			data:        `[{"id": 1}`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := jsonRows([]byte(tt.data))
+			if tt.expectedErr {
+				assert.ErrorContains(t, err, "invalid json dataset")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedRows, rows)
+		})
+	}
+}
+
+func TestExpandParameters(t *testing.T) {
+	tests := []struct {
+		name         string
+		rows         []map[string]string
+		parameters   map[string][]string
+		expectedRows []map[string]string
+	}{
+		{
+			name:       "single parameter",
+			rows:       []map[string]string{{}},
+			parameters: map[string][]string{"region": {"eu", "us"}},
+			expectedRows: []map[string]string{
+				{"region": "eu"},
+				{"region": "us"},
+			},
+		},
+		{
+			name:       "combinations in order of the parameter names",
+			rows:       []map[string]string{{}},
+			parameters: map[string][]string{"region": {"eu", "us"}, "format": {"json", "xml"}},
+			expectedRows: []map[string]string{
+				{"format": "json", "region": "eu"},
+				{"format": "json", "region": "us"},
+				{"format": "xml", "region": "eu"},
+				{"format": "xml", "region": "us"},
+			},
+		},
+		{
+			name:       "rows are combined with the parameters",
+			rows:       []map[string]string{{"id": "1"}, {"id": "2"}},
+			parameters: map[string][]string{"region": {"eu", "us"}},
+			expectedRows: []map[string]string{
+				{"id": "1", "region": "eu"},
+				{"id": "1", "region": "us"},
+				{"id": "2", "region": "eu"},
+				{"id": "2", "region": "us"},
+			},
+		},
+		{
+			name:       "values of the rows override the parameters",
+			rows:       []map[string]string{{"region": "ap"}},
+			parameters: map[string][]string{"region": {"eu"}},
+			expectedRows: []map[string]string{
+				{"region": "ap"},
+			},
+		},
+		{
+			name:         "parameter without values",
+			rows:         []map[string]string{{"id": "1"}},
+			parameters:   map[string][]string{"region": {}},
+			expectedRows: []map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedRows, expandParameters(tt.rows, tt.parameters))
+		})
+	}
+}
+
+func TestMatrixRows(t *testing.T) {
+	resources := map[string][]byte{
+		"users.csv":  []byte("id\n2\n"),
+		"users.json": []byte(`[{"id": "3"}]`),
+		"users.xml":  []byte("<users/>"),
+		"bad.csv":    []byte("id,name\n1\n"),
+	}
+	resourceReader := func(name string) ([]byte, error) {
+		data, ok := resources[name]
+		if !ok {
+			return noResourceReader(name)
+		}
+		return data, nil
+	}
+
+	tests := []struct {
+		name         string
+		matrix       *yaml.Matrix
+		expectedRows []map[string]string
+		expectedErr  error
+	}{
+		{
+			name:         "empty",
+			matrix:       &yaml.Matrix{},
+			expectedRows: []map[string]string{},
+		},
+		{
+			name:   "rows and csv dataset",
+			matrix: &yaml.Matrix{Rows: []map[string]string{{"id": "1"}}, Dataset: "users.csv"},
+			expectedRows: []map[string]string{
+				{"id": "1"},
+				{"id": "2"},
+			},
+		},
+		{
+			name:   "json dataset with parameters",
+			matrix: &yaml.Matrix{Dataset: "users.json", Parameters: map[string][]string{"region": {"eu", "us"}}},
+			expectedRows: []map[string]string{
+				{"id": "3", "region": "eu"},
+				{"id": "3", "region": "us"},
+			},
+		},
+		{
+			name:   "parameters only",
+			matrix: &yaml.Matrix{Parameters: map[string][]string{"region": {"eu"}}},
+			expectedRows: []map[string]string{
+				{"region": "eu"},
+			},
+		},
+		{
+			name:        "missing dataset",
+			matrix:      &yaml.Matrix{Dataset: "missing.csv"},
+			expectedErr: ErrResourceNotFound,
+		},
+		{
+			name:        "unsupported dataset format",
+			matrix:      &yaml.Matrix{Dataset: "users.xml"},
+			expectedErr: ErrUnsupportedDatasetFormat,
+		},
+		{
+			name:        "malformed dataset",
+			matrix:      &yaml.Matrix{Dataset: "bad.csv"},
+			expectedErr: csv.ErrFieldCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := matrixRows(tt.matrix, resourceReader)
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedRows, rows)
+		})
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/2" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name               string
+		matrix             string
+		expectedErr        error
+		expectedSubResults map[string]bool
+		expectedSuccess    bool
+		expectedAssertions int
+	}{
+		{
+			name:   "every row succeeds",
+			matrix: "  rows:\n    - id: \"1\"\n    - id: \"3\"\n",
+			expectedSubResults: map[string]bool{
+				"users [id=1]": true,
+				"users [id=3]": true,
+			},
+			expectedSuccess:    true,
+			expectedAssertions: 2,
+		},
+		{
+			name:   "a failing row fails the scenario",
+			matrix: "  rows:\n    - id: \"1\"\n    - id: \"2\"\n  parameters:\n    region: [eu]\n",
+			expectedSubResults: map[string]bool{
+				"users [id=1, region=eu]": true,
+				"users [id=2, region=eu]": false,
+			},
+			expectedSuccess:    false,
+			expectedAssertions: 2,
+		},
+		{
+			name:        "without rows",
+			matrix:      "  rows: []\n",
+			expectedErr: ErrEmptyMatrix,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenario := fmt.Sprintf(`version: v1
+type: http
+matrix:
+%ssteps:
+  - name: user
+    request:
+      method: GET
+      url: %s/users/${variables.id}
+    validation:
+      status:
+        assertion: equal
+        value: "200"
+`, tt.matrix, server.URL)
+			app, err := newTestApp("users", scenario)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			result, err := app.Play(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "users", result.Name)
+			assert.Equal(t, tt.expectedSuccess, result.Success)
+			assert.Equal(t, tt.expectedAssertions, result.TotalAssertions)
+			subResults := map[string]bool{}
+			for _, subResult := range result.SubResults {
+				subResults[subResult.Name] = subResult.Success
+			}
+			assert.Equal(t, tt.expectedSubResults, subResults)
+		})
+	}
+}
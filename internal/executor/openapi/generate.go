@@ -0,0 +1,388 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+// Grouping determines how generated steps are grouped into scenarios.
+type Grouping string
+
+// Different groupings.
+const (
+	GroupByOperation Grouping = "operation"
+	GroupByTag       Grouping = "tag"
+)
+
+// ErrUnknownGrouping is returned when generating scenarios with an unknown grouping.
+var ErrUnknownGrouping = fmt.Errorf("unknown grouping, expected operation or tag")
+
+const (
+	baseURLVariable  = "base_url"
+	maxExampleDepth  = 5
+	defaultGroupName = "default"
+)
+
+func methods() []string {
+	return []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+}
+
+// GeneratedScenario is a scenario skeleton generated from the document.
+type GeneratedScenario struct {
+	Name     string
+	TestSpec *yaml.TestSpec
+	Scenario *yaml.Scenario
+}
+
+type operation struct {
+	path    string
+	method  string
+	pointer string
+	object  map[string]any
+}
+
+// Generate generates a scenario skeleton for every operation, or for every tag, of the document.
+// Every step is pre-filled with the request of the operation, an example request body and
+// assertions on the status and the required properties of the documented response.
+func (d *Document) Generate(grouping Grouping) ([]*GeneratedScenario, error) {
+	if grouping != GroupByOperation && grouping != GroupByTag {
+		return nil, ErrUnknownGrouping
+	}
+	scenarios := []*GeneratedScenario{}
+	byName := map[string]*GeneratedScenario{}
+	for _, op := range d.operations() {
+		name := op.name()
+		if grouping == GroupByTag {
+			name = op.tag()
+		}
+		scenario, ok := byName[name]
+		if !ok {
+			scenario = d.newGeneratedScenario(name)
+			byName[name] = scenario
+			scenarios = append(scenarios, scenario)
+		}
+		step, variables := d.generateStep(op)
+		scenario.Scenario.Steps = append(scenario.Scenario.Steps, step)
+		scenario.TestSpec.Variables = mergeVariables(scenario.TestSpec.Variables, variables)
+	}
+	return scenarios, nil
+}
+
+func (d *Document) newGeneratedScenario(name string) *GeneratedScenario {
+	baseURL := "http://localhost"
+	servers, _ := d.document["servers"].([]any)
+	if len(servers) > 0 {
+		server, _ := servers[0].(map[string]any)
+		if serverURL, ok := server["url"].(string); ok && serverURL != "" {
+			baseURL = strings.TrimSuffix(serverURL, "/")
+		}
+	}
+	return &GeneratedScenario{
+		Name: name,
+		TestSpec: &yaml.TestSpec{
+			Version:   "v1",
+			Type:      yaml.TestTypeHTTP,
+			OpenAPI:   d.name,
+			Variables: []*yaml.Variable{{Name: baseURLVariable, Value: baseURL}},
+		},
+		Scenario: &yaml.Scenario{
+			Steps: []*yaml.Step{},
+		},
+	}
+}
+
+// operations returns the operations of the document, ordered by path and method.
+func (d *Document) operations() []*operation {
+	operations := []*operation{}
+	paths, _ := d.document["paths"].(map[string]any)
+	for _, path := range sortedKeys(paths) {
+		for _, method := range methods() {
+			pointer := fmt.Sprintf("/paths/%s/%s", escape(path), method)
+			object, _ := get(d.document, pointer).(map[string]any)
+			if object == nil {
+				continue
+			}
+			operations = append(operations, &operation{path: path, method: method, pointer: pointer, object: object})
+		}
+	}
+	return operations
+}
+
+func (o *operation) name() string {
+	if operationID, ok := o.object["operationId"].(string); ok && operationID != "" {
+		return slug(operationID)
+	}
+	return slug(o.method + "_" + o.path)
+}
+
+func (o *operation) tag() string {
+	tags, _ := o.object["tags"].([]any)
+	if len(tags) == 0 {
+		return defaultGroupName
+	}
+	return slug(fmt.Sprint(tags[0]))
+}
+
+// slug converts a name, e.g. listProjects or get_/users/{id}, to a snake case file name.
+func slug(s string) string {
+	s = regexp.MustCompile(`([a-z0-9])([A-Z])`).ReplaceAllString(s, "${1}_${2}")
+	s = regexp.MustCompile(`[^a-zA-Z0-9]+`).ReplaceAllString(s, "_")
+	return strings.ToLower(strings.Trim(s, "_"))
+}
+
+func (d *Document) generateStep(op *operation) (*yaml.Step, []*yaml.Variable) {
+	url, variables := d.generateURL(op)
+	step := &yaml.Step{
+		Name: op.name(),
+		Request: &yaml.Request{
+			Method: strings.ToUpper(op.method),
+			URL:    url,
+		},
+		Validation: &yaml.Validation{},
+	}
+	if body, ok := d.exampleRequestBody(op); ok {
+		step.Request.JSON = body
+	}
+	status, responsePointer := d.successResponse(op)
+	if status != "" {
+		step.Validation.Status = &yaml.Assertion{Assertion: yaml.AssertionMethodEqual, Value: status}
+		step.Validation.Body = d.bodyAssertions(responsePointer)
+	}
+	return step, variables
+}
+
+// generateURL returns the URL of the operation, path parameters and required
+// query parameters are replaced by variables.
+func (d *Document) generateURL(op *operation) (string, []*yaml.Variable) {
+	path := op.path
+	variables := []*yaml.Variable{}
+	query := []string{}
+	for _, parameter := range d.parameters(op) {
+		name, _ := parameter["name"].(string)
+		variable := &yaml.Variable{Name: slug(name), Value: d.parameterExample(parameter)}
+		placeholder := fmt.Sprintf("${variables.%s}", variable.Name)
+		switch parameter["in"] {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", placeholder)
+		case "query":
+			if parameter["required"] != true {
+				continue
+			}
+			query = append(query, fmt.Sprintf("%s=%s", name, placeholder))
+		default:
+			continue
+		}
+		variables = append(variables, variable)
+	}
+	url := fmt.Sprintf("${variables.%s}%s", baseURLVariable, path)
+	if len(query) > 0 {
+		url += "?" + strings.Join(query, "&")
+	}
+	return url, variables
+}
+
+// parameters returns the parameters of the path item and the operation.
+func (d *Document) parameters(op *operation) []map[string]any {
+	result := []map[string]any{}
+	pathPointer := strings.TrimSuffix(op.pointer, "/"+op.method)
+	for _, pointer := range []string{pathPointer + "/parameters", op.pointer + "/parameters"} {
+		parameters, _ := get(d.document, pointer).([]any)
+		for i := range parameters {
+			if parameter, _ := d.lookup(fmt.Sprintf("%s/%d", pointer, i)); parameter != nil {
+				result = append(result, parameter)
+			}
+		}
+	}
+	return result
+}
+
+func (d *Document) parameterExample(parameter map[string]any) string {
+	if example, ok := parameter["example"]; ok {
+		return fmt.Sprint(example)
+	}
+	schema, _ := parameter["schema"].(map[string]any)
+	example := d.exampleFromSchema(schema, 0)
+	if str, ok := example.(string); ok {
+		return str
+	}
+	return fmt.Sprint(example)
+}
+
+// exampleRequestBody returns an example JSON request body for the operation, if
+// the operation accepts a JSON request body.
+func (d *Document) exampleRequestBody(op *operation) (yaml.JSON, bool) {
+	requestBody, requestBodyPointer := d.lookup(op.pointer + "/requestBody")
+	if requestBody == nil {
+		return nil, false
+	}
+	media, ok := get(d.document, requestBodyPointer+"/content/application~1json").(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	example, ok := media["example"]
+	if !ok {
+		example = d.exampleFromSchema(d.resolveSchema(media["schema"]), 0)
+	}
+	b, err := json.Marshal(example)
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+// successResponse returns the lowest documented 2XX status of the operation and the pointer of its response.
+func (d *Document) successResponse(op *operation) (string, string) {
+	responses, _ := op.object["responses"].(map[string]any)
+	statuses := []string{}
+	for status := range responses {
+		if code, err := strconv.Atoi(status); err == nil && code >= 200 && code < 300 {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 {
+		return "", ""
+	}
+	sort.Strings(statuses)
+	_, pointer := d.lookup(fmt.Sprintf("%s/responses/%s", op.pointer, statuses[0]))
+	return statuses[0], pointer
+}
+
+// bodyAssertions returns not_empty assertions for the required properties of the
+// JSON response, or of the first item if the response is an array.
+func (d *Document) bodyAssertions(responsePointer string) []*yaml.Assertion {
+	assertions := []*yaml.Assertion{}
+	if responsePointer == "" {
+		return assertions
+	}
+	schema := d.resolveSchema(get(d.document, responsePointer+"/content/application~1json/schema"))
+	prefix := ""
+	if schema["type"] == "array" {
+		schema = d.resolveSchema(schema["items"])
+		prefix = "0."
+	}
+	required, _ := schema["required"].([]any)
+	for _, property := range required {
+		assertions = append(assertions, &yaml.Assertion{
+			Key:       prefix + fmt.Sprint(property),
+			Assertion: yaml.AssertionMethodNotEmpty,
+		})
+	}
+	return assertions
+}
+
+// resolveSchema returns the schema object, following references within the document.
+func (d *Document) resolveSchema(schema any) map[string]any {
+	for i := 0; i < maxExampleDepth; i++ {
+		object, ok := schema.(map[string]any)
+		if !ok {
+			return map[string]any{}
+		}
+		ref, isRef := object["$ref"].(string)
+		if !isRef || !strings.HasPrefix(ref, "#") {
+			return object
+		}
+		schema = get(d.document, strings.TrimPrefix(ref, "#"))
+	}
+	return map[string]any{}
+}
+
+// exampleFromSchema generates an example value for a schema, using the documented
+// examples, defaults and enums where available.
+func (d *Document) exampleFromSchema(schema map[string]any, depth int) any {
+	schema = d.resolveSchema(schema)
+	for _, keyword := range []string{"example", "default", "const"} {
+		if value, ok := schema[keyword]; ok {
+			return value
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok && len(enum) > 0 {
+		return enum[0]
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if schemas, ok := schema[keyword].([]any); ok && len(schemas) > 0 {
+			return d.exampleFromSchemas(keyword, schemas, depth)
+		}
+	}
+	return d.exampleFromType(schema, depth)
+}
+
+func (d *Document) exampleFromSchemas(keyword string, schemas []any, depth int) any {
+	if keyword != "allOf" {
+		return d.exampleFromSchema(d.resolveSchema(schemas[0]), depth)
+	}
+	merged := map[string]any{}
+	for _, schema := range schemas {
+		if example, ok := d.exampleFromSchema(d.resolveSchema(schema), depth).(map[string]any); ok {
+			for k, v := range example {
+				merged[k] = v
+			}
+		}
+	}
+	return merged
+}
+
+func (d *Document) exampleFromType(schema map[string]any, depth int) any {
+	switch schema["type"] {
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		if depth >= maxExampleDepth {
+			return []any{}
+		}
+		return []any{d.exampleFromSchema(d.resolveSchema(schema["items"]), depth+1)}
+	case "object", nil:
+		properties, ok := schema["properties"].(map[string]any)
+		if !ok && schema["type"] == nil {
+			return exampleString(schema)
+		}
+		example := map[string]any{}
+		if depth >= maxExampleDepth {
+			return example
+		}
+		for name, property := range properties {
+			example[name] = d.exampleFromSchema(d.resolveSchema(property), depth+1)
+		}
+		return example
+	default:
+		return exampleString(schema)
+	}
+}
+
+func exampleString(schema map[string]any) string {
+	switch schema["format"] {
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "date-time":
+		return "1970-01-01T00:00:00Z"
+	case "date":
+		return "1970-01-01"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	default:
+		return "string"
+	}
+}
+
+// mergeVariables appends the variables which are not yet defined.
+func mergeVariables(variables, additional []*yaml.Variable) []*yaml.Variable {
+	for _, variable := range additional {
+		defined := false
+		for _, v := range variables {
+			defined = defined || v.Name == variable.Name
+		}
+		if !defined {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
+)
+
+const generateDocument = `openapi: 3.0.0
+info:
+  title: test
+  version: 1.0.0
+servers:
+  - url: http://localhost:3000/v1/
+paths:
+  /projects:
+    get:
+      operationId: listProjects
+      tags: [projects]
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: integer
+            default: 10
+        - name: cursor
+          in: query
+          schema:
+            type: string
+        - name: X-Request-ID
+          in: header
+          schema:
+            type: string
+      responses:
+        200:
+          description: projects
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Project'
+    post:
+      operationId: createProject
+      tags: [projects]
+      requestBody:
+        $ref: '#/components/requestBodies/Project'
+      responses:
+        400:
+          description: invalid
+        204:
+          description: created
+        201:
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Project'
+  /projects/{projectId}:
+    parameters:
+      - name: projectId
+        in: path
+        required: true
+        schema:
+          type: string
+          format: uuid
+    delete:
+      responses:
+        default:
+          description: error
+components:
+  requestBodies:
+    Project:
+      content:
+        application/json:
+          schema:
+            allOf:
+              - $ref: '#/components/schemas/Project'
+              - type: object
+                properties:
+                  visibility:
+                    enum: [private, public]
+  schemas:
+    Project:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: string
+          format: uuid
+        name:
+          type: string
+          example: inquiry
+        tags:
+          type: array
+          items:
+            type: string
+        archived:
+          type: boolean
+`
+
+func TestGenerate(t *testing.T) {
+	document, err := NewDocument("api.yaml", []byte(generateDocument))
+	assert.NoError(t, err)
+
+	scenarios, err := document.Generate(GroupByOperation)
+	assert.NoError(t, err)
+	if !assert.Len(t, scenarios, 3) {
+		return
+	}
+
+	list := scenarios[0]
+	assert.Equal(t, "list_projects", list.Name)
+	assert.Equal(t, "api.yaml", list.TestSpec.OpenAPI)
+	assert.Equal(t, []*yaml.Variable{
+		{Name: "base_url", Value: "http://localhost:3000/v1"},
+		{Name: "limit", Value: "10"},
+	}, list.TestSpec.Variables)
+	assert.Equal(t, []*yaml.Step{{
+		Name: "list_projects",
+		Request: &yaml.Request{
+			Method: "GET",
+			URL:    "${variables.base_url}/projects?limit=${variables.limit}",
+		},
+		Validation: &yaml.Validation{
+			Status: &yaml.Assertion{Assertion: yaml.AssertionMethodEqual, Value: "200"},
+			Body: []*yaml.Assertion{
+				{Key: "0.id", Assertion: yaml.AssertionMethodNotEmpty},
+				{Key: "0.name", Assertion: yaml.AssertionMethodNotEmpty},
+			},
+		},
+	}}, list.Scenario.Steps)
+
+	create := scenarios[1]
+	assert.Equal(t, "create_project", create.Name)
+	if assert.Len(t, create.Scenario.Steps, 1) {
+		step := create.Scenario.Steps[0]
+		assert.Equal(t, "POST", step.Request.Method)
+		assert.Equal(t, "${variables.base_url}/projects", step.Request.URL)
+		assert.JSONEq(t, `{
+			"id": "00000000-0000-0000-0000-000000000000",
+			"name": "inquiry",
+			"tags": ["string"],
+			"archived": false,
+			"visibility": "private"
+		}`, string(step.Request.JSON))
+		assert.Equal(t, &yaml.Assertion{Assertion: yaml.AssertionMethodEqual, Value: "201"}, step.Validation.Status)
+		assert.Equal(t, []*yaml.Assertion{
+			{Key: "id", Assertion: yaml.AssertionMethodNotEmpty},
+			{Key: "name", Assertion: yaml.AssertionMethodNotEmpty},
+		}, step.Validation.Body)
+	}
+
+	remove := scenarios[2]
+	assert.Equal(t, "delete_projects_project_id", remove.Name)
+	assert.Equal(t, []*yaml.Variable{
+		{Name: "base_url", Value: "http://localhost:3000/v1"},
+		{Name: "project_id", Value: "00000000-0000-0000-0000-000000000000"},
+	}, remove.TestSpec.Variables)
+	if assert.Len(t, remove.Scenario.Steps, 1) {
+		step := remove.Scenario.Steps[0]
+		assert.Equal(t, "DELETE", step.Request.Method)
+		assert.Equal(t, "${variables.base_url}/projects/${variables.project_id}", step.Request.URL)
+		assert.Nil(t, step.Request.JSON)
+		assert.Nil(t, step.Validation.Status)
+	}
+}
+
+func TestGenerateGroupByTag(t *testing.T) {
+	document, err := NewDocument("../api.yaml", []byte(generateDocument))
+	assert.NoError(t, err)
+
+	scenarios, err := document.Generate(GroupByTag)
+	assert.NoError(t, err)
+	if !assert.Len(t, scenarios, 2) {
+		return
+	}
+
+	assert.Equal(t, "projects", scenarios[0].Name)
+	assert.Equal(t, "../api.yaml", scenarios[0].TestSpec.OpenAPI)
+	assert.Len(t, scenarios[0].Scenario.Steps, 2)
+	assert.Equal(t, []*yaml.Variable{
+		{Name: "base_url", Value: "http://localhost:3000/v1"},
+		{Name: "limit", Value: "10"},
+	}, scenarios[0].TestSpec.Variables)
+
+	assert.Equal(t, "default", scenarios[1].Name)
+	assert.Len(t, scenarios[1].Scenario.Steps, 1)
+}
+
+func TestGenerateWithoutServers(t *testing.T) {
+	document, err := NewDocument("api.yaml", []byte(`{"openapi": "3.1.0", "paths": {"/health": {"head": {}}}}`))
+	assert.NoError(t, err)
+
+	scenarios, err := document.Generate(GroupByOperation)
+	assert.NoError(t, err)
+	if assert.Len(t, scenarios, 1) {
+		assert.Equal(t, "head_health", scenarios[0].Name)
+		assert.Equal(t, []*yaml.Variable{{Name: "base_url", Value: "http://localhost"}}, scenarios[0].TestSpec.Variables)
+	}
+}
+
+func TestGenerateUnknownGrouping(t *testing.T) {
+	document, err := NewDocument("api.yaml", []byte(generateDocument))
+	assert.NoError(t, err)
+
+	_, err = document.Generate("path")
+	assert.ErrorIs(t, err, ErrUnknownGrouping)
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		expected string
+	}{
+		{name: "camel case", in: "listProjects", expected: "list_projects"},
+		{name: "path", in: "get_/users/{id}", expected: "get_users_id"},
+		{name: "snake case", in: "list_users", expected: "list_users"},
+		{name: "trimmed", in: "/health/", expected: "health"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, slug(tt.in))
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Package openapi validates requests and their responses against the operations
+// of an OpenAPI 3 document.
+//
+// The operation is matched on the method and the path of the request, after which
+// the status, the headers and the body of the response are validated against the
+// documented responses of the operation. Schemas are validated as JSON Schema.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/inquiryproj/inquiry/internal/executor/jsonschema"
+)
+
+// ErrInvalidDocument is returned when a document is not a valid OpenAPI 3 document.
+var ErrInvalidDocument = fmt.Errorf("invalid OpenAPI document")
+
+// Different kinds of violations.
+const (
+	ViolationOperation = "operation"
+	ViolationStatus    = "status"
+	ViolationHeader    = "header"
+	ViolationBody      = "body"
+)
+
+// Violation describes a part of a request or response which does not conform to the document.
+// Key is the name of the violated header or the JSON pointer of the violating value of the body.
+type Violation struct {
+	Kind    string
+	Key     string
+	Message string
+}
+
+// Exchange is a request and its response, which are validated against the document.
+type Exchange struct {
+	Method  string
+	URL     string
+	Status  int
+	Headers http.Header
+	Body    []byte
+}
+
+// Document is a parsed OpenAPI 3 document.
+type Document struct {
+	name      string
+	document  map[string]any
+	json      []byte
+	basePaths []string
+	paths     []*pathTemplate
+
+	mu      sync.Mutex
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewDocument parses an OpenAPI 3 document in either YAML or JSON format.
+// The name of the document is used to reference its schemas.
+func NewDocument(name string, data []byte) (*Document, error) {
+	var document any
+	err := yaml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+	}
+	object, ok := normalise(document).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: document must be an object", ErrInvalidDocument)
+	}
+	version, _ := object["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("%w: unsupported version %q, expected OpenAPI 3", ErrInvalidDocument, version)
+	}
+	b, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDocument, err)
+	}
+	return &Document{
+		name:      name,
+		document:  object,
+		json:      b,
+		basePaths: basePaths(object),
+		paths:     pathTemplates(object),
+		schemas:   map[string]*jsonschema.Schema{},
+	}, nil
+}
+
+// normalise converts YAML mappings with non string keys, such as response
+// status codes, to mappings with string keys.
+func normalise(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for k, v := range value {
+			value[k] = normalise(v)
+		}
+		return value
+	case map[any]any:
+		result := map[string]any{}
+		for k, v := range value {
+			result[fmt.Sprint(k)] = normalise(v)
+		}
+		return result
+	case []any:
+		for i, v := range value {
+			value[i] = normalise(v)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// basePaths returns the paths of the server URLs, which prefix the paths of the operations.
+// The longest base path is returned first.
+func basePaths(document map[string]any) []string {
+	result := []string{}
+	servers, _ := document["servers"].([]any)
+	for _, server := range servers {
+		serverObject, _ := server.(map[string]any)
+		rawURL, _ := serverObject["url"].(string)
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		if basePath := strings.TrimSuffix(u.Path, "/"); basePath != "" {
+			result = append(result, basePath)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return len(result[i]) > len(result[j]) })
+	return result
+}
+
+// Validate validates the exchange against the matching operation of the document. It returns
+// the matched operation, e.g. GET /users/{id}, and every violation of the document.
+func (d *Document) Validate(exchange *Exchange) (string, []*Violation) {
+	path := d.path(exchange.URL)
+	operationName := fmt.Sprintf("%s %s", strings.ToUpper(exchange.Method), path)
+	template := d.match(path)
+	if template == nil {
+		return operationName, []*Violation{{
+			Kind:    ViolationOperation,
+			Key:     operationName,
+			Message: fmt.Sprintf("path %s is not documented", path),
+		}}
+	}
+	operationName = fmt.Sprintf("%s %s", strings.ToUpper(exchange.Method), template.path)
+	method := strings.ToLower(exchange.Method)
+	if _, ok := template.item[method]; !ok {
+		return operationName, []*Violation{{
+			Kind:    ViolationOperation,
+			Key:     operationName,
+			Message: fmt.Sprintf("operation %s is not documented", operationName),
+		}}
+	}
+	operationPointer := fmt.Sprintf("/paths/%s/%s", escape(template.path), method)
+	responsePointer, response := d.response(operationPointer, exchange.Status)
+	if response == nil {
+		return operationName, []*Violation{{
+			Kind:    ViolationStatus,
+			Key:     operationName,
+			Message: fmt.Sprintf("status %d is not documented for %s", exchange.Status, operationName),
+		}}
+	}
+	violations := d.validateHeaders(responsePointer, response, exchange.Headers)
+	return operationName, append(violations, d.validateBody(responsePointer, response, exchange)...)
+}
+
+// path returns the path of the URL relative to the base path of the servers.
+func (d *Document) path(rawURL string) string {
+	path := rawURL
+	u, err := url.Parse(rawURL)
+	if err == nil {
+		path = u.Path
+	}
+	for _, basePath := range d.basePaths {
+		if path == basePath || strings.HasPrefix(path, basePath+"/") {
+			return strings.TrimPrefix(path, basePath)
+		}
+	}
+	return path
+}
+
+// response returns the documented response for a status of an operation and its pointer,
+// falling back to the status range, e.g. 2XX, and the default response.
+func (d *Document) response(operationPointer string, status int) (string, map[string]any) {
+	for _, key := range []string{fmt.Sprint(status), fmt.Sprintf("%dXX", status/100), "default"} {
+		pointer := fmt.Sprintf("%s/responses/%s", operationPointer, escape(key))
+		if response, resolvedPointer := d.lookup(pointer); response != nil {
+			return resolvedPointer, response
+		}
+	}
+	return "", nil
+}
+
+// lookup returns the object at the pointer and its pointer, references to other
+// parts of the document are followed.
+func (d *Document) lookup(pointer string) (map[string]any, string) {
+	for i := 0; i < 10; i++ {
+		object, ok := get(d.document, pointer).(map[string]any)
+		if !ok {
+			return nil, ""
+		}
+		ref, isRef := object["$ref"].(string)
+		if !isRef || !strings.HasPrefix(ref, "#") {
+			return object, pointer
+		}
+		pointer = strings.TrimPrefix(ref, "#")
+	}
+	return nil, ""
+}
+
+func get(document any, pointer string) any {
+	current := document
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		switch value := current.(type) {
+		case map[string]any:
+			current = value[strings.NewReplacer("~1", "/", "~0", "~").Replace(token)]
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			current = value[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+func escape(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// schema returns the compiled schema at the pointer.
+func (d *Document) schema(pointer string) (*jsonschema.Schema, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if schema, ok := d.schemas[pointer]; ok {
+		return schema, nil
+	}
+	ref, err := json.Marshal(map[string]string{"$ref": d.name + "#" + strings.ReplaceAll(pointer, "%", "%25")})
+	if err != nil {
+		return nil, err
+	}
+	schema, err := jsonschema.Compile(ref, func(name string) ([]byte, error) {
+		if name != d.name {
+			return nil, fmt.Errorf("external reference %s is not supported", name)
+		}
+		return d.json, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.schemas[pointer] = schema
+	return schema, nil
+}
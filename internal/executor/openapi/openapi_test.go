@@ -0,0 +1,300 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDocument = `openapi: 3.0.0
+info:
+  title: test
+  version: 1.0.0
+servers:
+  - url: http://localhost:3000/v1
+paths:
+  /users:
+    get:
+      responses:
+        200:
+          description: users
+          headers:
+            X-Total:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/User'
+  /users/me:
+    get:
+      responses:
+        2XX:
+          $ref: '#/components/responses/User'
+  /users/{id}:
+    get:
+      responses:
+        200:
+          $ref: '#/components/responses/User'
+        default:
+          description: error
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                required: [title]
+    delete:
+      responses:
+        204:
+          description: deleted
+components:
+  responses:
+    User:
+      description: user
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/User'
+  schemas:
+    User:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+`
+
+func jsonHeaders(headers ...string) http.Header {
+	h := http.Header{"Content-Type": []string{"application/json; charset=utf-8"}}
+	for i := 0; i+1 < len(headers); i += 2 {
+		h.Set(headers[i], headers[i+1])
+	}
+	return h
+}
+
+func TestNewDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expectErr bool
+	}{
+		{
+			name: "yaml document",
+			data: testDocument,
+		},
+		{
+			name: "json document",
+			data: `{"openapi": "3.1.0", "paths": {}}`,
+		},
+		{
+			name:      "swagger document",
+			data:      `{"swagger": "2.0", "paths": {}}`,
+			expectErr: true,
+		},
+		{
+			name:      "no object",
+			data:      `- openapi`,
+			expectErr: true,
+		},
+		{
+			name:      "invalid yaml",
+			data:      `openapi: [`,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDocument("api.yaml", []byte(tt.data))
+			if tt.expectErr {
+				assert.ErrorIs(t, err, ErrInvalidDocument)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	document, err := NewDocument("api.yaml", []byte(testDocument))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		exchange           *Exchange
+		expectedOperation  string
+		expectedViolations []*Violation
+	}{
+		{
+			name: "matching response",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users?limit=10",
+				Status:  http.StatusOK,
+				Headers: jsonHeaders("X-Total", "1"),
+				Body:    []byte(`[{"id": 1, "name": "a"}]`),
+			},
+			expectedOperation:  "GET /users",
+			expectedViolations: []*Violation{},
+		},
+		{
+			name: "matching response of a path with parameters",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users/1",
+				Status:  http.StatusOK,
+				Headers: jsonHeaders(),
+				Body:    []byte(`{"id": 1, "name": "a"}`),
+			},
+			expectedOperation:  "GET /users/{id}",
+			expectedViolations: []*Violation{},
+		},
+		{
+			name: "concrete path is matched before path with parameters",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users/me",
+				Status:  http.StatusCreated,
+				Headers: jsonHeaders(),
+				Body:    []byte(`{"id": 1, "name": "a"}`),
+			},
+			expectedOperation:  "GET /users/me",
+			expectedViolations: []*Violation{},
+		},
+		{
+			name: "default response",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users/1",
+				Status:  http.StatusNotFound,
+				Headers: http.Header{"Content-Type": []string{"application/problem+json"}},
+				Body:    []byte(`{"detail": "not found"}`),
+			},
+			expectedOperation: "GET /users/{id}",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationBody,
+				Key:     "",
+				Message: "body at / is missing required property title",
+			}},
+		},
+		{
+			name: "response without content",
+			exchange: &Exchange{
+				Method:  http.MethodDelete,
+				URL:     "http://localhost:3000/v1/users/1",
+				Status:  http.StatusNoContent,
+				Headers: http.Header{},
+			},
+			expectedOperation:  "DELETE /users/{id}",
+			expectedViolations: []*Violation{},
+		},
+		{
+			name: "undocumented path",
+			exchange: &Exchange{
+				Method: http.MethodGet,
+				URL:    "http://localhost:3000/v1/projects",
+				Status: http.StatusOK,
+			},
+			expectedOperation: "GET /projects",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationOperation,
+				Key:     "GET /projects",
+				Message: "path /projects is not documented",
+			}},
+		},
+		{
+			name: "undocumented method",
+			exchange: &Exchange{
+				Method: http.MethodPost,
+				URL:    "http://localhost:3000/v1/users",
+				Status: http.StatusCreated,
+			},
+			expectedOperation: "POST /users",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationOperation,
+				Key:     "POST /users",
+				Message: "operation POST /users is not documented",
+			}},
+		},
+		{
+			name: "undocumented status",
+			exchange: &Exchange{
+				Method: http.MethodGet,
+				URL:    "http://localhost:3000/v1/users",
+				Status: http.StatusInternalServerError,
+			},
+			expectedOperation: "GET /users",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationStatus,
+				Key:     "GET /users",
+				Message: "status 500 is not documented for GET /users",
+			}},
+		},
+		{
+			name: "missing required header and invalid body",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users",
+				Status:  http.StatusOK,
+				Headers: jsonHeaders(),
+				Body:    []byte(`[{"id": "1", "name": "a"}]`),
+			},
+			expectedOperation: "GET /users",
+			expectedViolations: []*Violation{
+				{
+					Kind:    ViolationHeader,
+					Key:     "X-Total",
+					Message: "required header X-Total is missing",
+				},
+				{
+					Kind:    ViolationBody,
+					Key:     "/0/id",
+					Message: "body at /0/id has type string, expected integer",
+				},
+			},
+		},
+		{
+			name: "invalid header",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users",
+				Status:  http.StatusOK,
+				Headers: jsonHeaders("X-Total", "many"),
+				Body:    []byte(`[]`),
+			},
+			expectedOperation: "GET /users",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationHeader,
+				Key:     "X-Total",
+				Message: "header X-Total has type string, expected integer",
+			}},
+		},
+		{
+			name: "undocumented content type",
+			exchange: &Exchange{
+				Method:  http.MethodGet,
+				URL:     "http://localhost:3000/v1/users/1",
+				Status:  http.StatusOK,
+				Headers: http.Header{"Content-Type": []string{"text/html"}},
+				Body:    []byte(`<html></html>`),
+			},
+			expectedOperation: "GET /users/{id}",
+			expectedViolations: []*Violation{{
+				Kind:    ViolationBody,
+				Message: "content type text/html is not documented",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operation, violations := document.Validate(tt.exchange)
+			assert.Equal(t, tt.expectedOperation, operation)
+			assert.Equal(t, tt.expectedViolations, violations)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// pathTemplate is a documented path, e.g. /users/{id}, and its path item.
+type pathTemplate struct {
+	path       string
+	segments   []string
+	parameters int
+	item       map[string]any
+}
+
+// pathTemplates returns the documented paths, paths with fewer parameters are
+// returned first such that concrete paths are matched before templated paths.
+func pathTemplates(document map[string]any) []*pathTemplate {
+	templates := []*pathTemplate{}
+	paths, _ := document["paths"].(map[string]any)
+	for path, value := range paths {
+		item, _ := value.(map[string]any)
+		template := &pathTemplate{
+			path:     path,
+			segments: strings.Split(strings.Trim(path, "/"), "/"),
+			item:     item,
+		}
+		for _, segment := range template.segments {
+			if isParameter(segment) {
+				template.parameters++
+			}
+		}
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].parameters != templates[j].parameters {
+			return templates[i].parameters < templates[j].parameters
+		}
+		return templates[i].path < templates[j].path
+	})
+	return templates
+}
+
+func isParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func (t *pathTemplate) matches(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) != len(t.segments) {
+		return false
+	}
+	for i, segment := range t.segments {
+		if isParameter(segment) {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if segment != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// match returns the documented path matching the path, or nil if the path is not documented.
+func (d *Document) match(path string) *pathTemplate {
+	for _, template := range d.paths {
+		if template.matches(path) {
+			return template
+		}
+	}
+	return nil
+}
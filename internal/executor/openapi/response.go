@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// validateHeaders validates the headers of the response against the documented headers.
+// The Content-Type header is validated as part of the body.
+func (d *Document) validateHeaders(responsePointer string, response map[string]any, headers http.Header) []*Violation {
+	violations := []*Violation{}
+	documented, _ := response["headers"].(map[string]any)
+	for _, name := range sortedKeys(documented) {
+		if strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		header, headerPointer := d.lookup(fmt.Sprintf("%s/headers/%s", responsePointer, escape(name)))
+		if header == nil {
+			continue
+		}
+		values := headers.Values(name)
+		if len(values) == 0 {
+			if header["required"] == true {
+				violations = append(violations, &Violation{
+					Kind:    ViolationHeader,
+					Key:     name,
+					Message: fmt.Sprintf("required header %s is missing", name),
+				})
+			}
+			continue
+		}
+		schema, ok := header["schema"].(map[string]any)
+		if !ok {
+			continue
+		}
+		instance := headerInstance(values[0], schema["type"] == "string")
+		violations = append(violations, d.validateHeader(name, headerPointer+"/schema", instance)...)
+	}
+	return violations
+}
+
+func (d *Document) validateHeader(name, schemaPointer string, instance []byte) []*Violation {
+	schema, err := d.schema(schemaPointer)
+	if err != nil {
+		return []*Violation{{Kind: ViolationHeader, Key: name, Message: fmt.Sprintf("header %s has an invalid schema: %s", name, err)}}
+	}
+	schemaViolations, err := schema.Validate(instance)
+	if err != nil {
+		return []*Violation{{Kind: ViolationHeader, Key: name, Message: fmt.Sprintf("header %s %s", name, err)}}
+	}
+	violations := []*Violation{}
+	for _, violation := range schemaViolations {
+		violations = append(violations, &Violation{
+			Kind:    ViolationHeader,
+			Key:     name,
+			Message: fmt.Sprintf("header %s %s", name, violation.Message),
+		})
+	}
+	return violations
+}
+
+// headerInstance returns the JSON representation of a header value. Unless the
+// value is a string, values which are valid JSON, such as numbers, are used as is.
+func headerInstance(value string, isString bool) []byte {
+	if !isString && json.Valid([]byte(value)) {
+		return []byte(value)
+	}
+	b, _ := json.Marshal(value)
+	return b
+}
+
+// validateBody validates the body of the response against the schema of the
+// documented content type. Only JSON bodies are validated against their schema.
+func (d *Document) validateBody(responsePointer string, response map[string]any, exchange *Exchange) []*Violation {
+	content, _ := response["content"].(map[string]any)
+	if len(content) == 0 {
+		return []*Violation{}
+	}
+	contentType, _, err := mime.ParseMediaType(exchange.Headers.Get("Content-Type"))
+	if err != nil {
+		contentType = exchange.Headers.Get("Content-Type")
+	}
+	mediaType := documentedMediaType(content, contentType)
+	if mediaType == "" {
+		return []*Violation{{
+			Kind:    ViolationBody,
+			Message: fmt.Sprintf("content type %s is not documented", contentType),
+		}}
+	}
+	media, _ := content[mediaType].(map[string]any)
+	if _, ok := media["schema"]; !ok || !isJSON(contentType) {
+		return []*Violation{}
+	}
+	return d.validateJSONBody(fmt.Sprintf("%s/content/%s/schema", responsePointer, escape(mediaType)), exchange.Body)
+}
+
+func (d *Document) validateJSONBody(schemaPointer string, body []byte) []*Violation {
+	schema, err := d.schema(schemaPointer)
+	if err != nil {
+		return []*Violation{{Kind: ViolationBody, Message: fmt.Sprintf("body has an invalid schema: %s", err)}}
+	}
+	schemaViolations, err := schema.Validate(body)
+	if err != nil {
+		return []*Violation{{Kind: ViolationBody, Message: fmt.Sprintf("body %s", err)}}
+	}
+	violations := []*Violation{}
+	for _, violation := range schemaViolations {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		violations = append(violations, &Violation{
+			Kind:    ViolationBody,
+			Key:     violation.Pointer,
+			Message: fmt.Sprintf("body at %s %s", pointer, violation.Message),
+		})
+	}
+	return violations
+}
+
+// documentedMediaType returns the documented media type matching the content type,
+// falling back to wildcard media types such as application/* and */*.
+func documentedMediaType(content map[string]any, contentType string) string {
+	mainType, _, _ := strings.Cut(contentType, "/")
+	for _, mediaType := range []string{contentType, mainType + "/*", "*/*"} {
+		if _, ok := content[mediaType]; ok {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+func isJSON(contentType string) bool {
+	return contentType == "application/json" || strings.HasSuffix(contentType, "+json")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
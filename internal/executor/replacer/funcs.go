@@ -0,0 +1,172 @@
+package replacer
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	randomAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// maxRandomStringLength limits the length of random strings, such that
+	// a placeholder can not exhaust the memory of the process.
+	maxRandomStringLength = 4096
+)
+
+// function is a function which can be called in a placeholder, e.g. ${randomInt(1, 10)}.
+type function struct {
+	minArgs, maxArgs int
+	call             func(args []string) (string, error)
+}
+
+func (f *funcReplacer) functions() map[string]function {
+	return map[string]function{
+		"unix":         {0, 0, func([]string) (string, error) { return strconv.FormatInt(time.Now().Unix(), 10), nil }},
+		"unixNano":     {0, 0, func([]string) (string, error) { return strconv.FormatInt(time.Now().UnixNano(), 10), nil }},
+		"uuid":         {0, 0, func([]string) (string, error) { return uuid.NewString(), nil }},
+		"randomString": {1, 1, randomString},
+		"randomInt":    {2, 2, randomInt},
+		"randomEmail":  {0, 0, randomEmail},
+		"now":          {0, 1, now},
+		"dateAdd":      {1, 2, dateAdd},
+		"base64":       {1, 1, func(args []string) (string, error) { return base64.StdEncoding.EncodeToString([]byte(args[0])), nil }},
+		"sha256":       {1, 1, sha256Hex},
+		"hmac":         {2, 2, hmacSHA256},
+		"env":          {1, 1, f.env},
+		"upper":        {1, 1, func(args []string) (string, error) { return strings.ToUpper(args[0]), nil }},
+		"lower":        {1, 1, func(args []string) (string, error) { return strings.ToLower(args[0]), nil }},
+		"jsonEscape":   {1, 1, jsonEscape},
+	}
+}
+
+// IsFunction returns whether name is the name of a function which can be called in a placeholder.
+func IsFunction(name string) bool {
+	_, ok := (&funcReplacer{}).functions()[name]
+	return ok
+}
+
+func randomString(args []string) (string, error) {
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 0 || n > maxRandomStringLength {
+		return "", fmt.Errorf("invalid length %s, must be between 0 and %d", args[0], maxRandomStringLength)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(randomAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = randomAlphabet[index.Int64()]
+	}
+	return string(b), nil
+}
+
+// randomInt returns a random integer between both arguments, inclusive.
+func randomInt(args []string) (string, error) {
+	lower, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid integer %s", args[0])
+	}
+	upper, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil || upper < lower {
+		return "", fmt.Errorf("invalid upper bound %s", args[1])
+	}
+	// the size of the range is computed with big integers, as it overflows int64 for large ranges.
+	size := new(big.Int).Sub(big.NewInt(upper), big.NewInt(lower))
+	size.Add(size, big.NewInt(1))
+	n, err := rand.Int(rand.Reader, size)
+	if err != nil {
+		return "", err
+	}
+	return n.Add(n, big.NewInt(lower)).String(), nil
+}
+
+func randomEmail([]string) (string, error) {
+	local, err := randomString([]string{"12"})
+	if err != nil {
+		return "", err
+	}
+	return strings.ToLower(local) + "@example.com", nil
+}
+
+// now returns the current time in the given format, which is either a Go layout,
+// e.g. 2006-01-02, or one of the names RFC3339, RFC3339Nano, RFC1123 or DateOnly.
+func now(args []string) (string, error) {
+	return formatTime(time.Now().UTC(), args), nil
+}
+
+// dateAdd returns the current time plus the given duration, e.g. 1h30m or -7d.
+func dateAdd(args []string) (string, error) {
+	d, err := parseDuration(args[0])
+	if err != nil {
+		return "", err
+	}
+	return formatTime(time.Now().UTC().Add(d), args[1:]), nil
+}
+
+// parseDuration parses a duration as time.ParseDuration, with support for days, e.g. 7d.
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %s", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
+func formatTime(t time.Time, args []string) string {
+	if len(args) == 0 {
+		return t.Format(time.RFC3339)
+	}
+	layouts := map[string]string{
+		"RFC3339":     time.RFC3339,
+		"RFC3339Nano": time.RFC3339Nano,
+		"RFC1123":     time.RFC1123,
+		"DateOnly":    time.DateOnly,
+	}
+	if layout, ok := layouts[args[0]]; ok {
+		return t.Format(layout)
+	}
+	return t.Format(args[0])
+}
+
+func sha256Hex(args []string) (string, error) {
+	sum := sha256.Sum256([]byte(args[0]))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hmacSHA256 returns the hex encoded HMAC-SHA256 of the second argument with the first argument as key.
+func hmacSHA256(args []string) (string, error) {
+	mac := hmac.New(sha256.New, []byte(args[0]))
+	_, _ = mac.Write([]byte(args[1]))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (f *funcReplacer) env(args []string) (string, error) {
+	if f.lookupEnv == nil {
+		return "", fmt.Errorf("environment variables are not available")
+	}
+	value, _ := f.lookupEnv(args[0])
+	return value, nil
+}
+
+// jsonEscape escapes a value such that it can be embedded in a JSON string.
+func jsonEscape(args []string) (string, error) {
+	b, err := json.Marshal(args[0])
+	if err != nil {
+		return "", err
+	}
+	return string(b[1 : len(b)-1]), nil
+}
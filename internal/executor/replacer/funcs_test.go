@@ -0,0 +1,236 @@
+package replacer
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomInt(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr bool
+	}{
+		{
+			name: "small range",
+			args: []string{"1", "10"},
+		},
+		{
+			name: "single value",
+			args: []string{"5", "5"},
+		},
+		{
+			name: "negative range",
+			args: []string{"-10", "-1"},
+		},
+		{
+			name: "upper bound of int64",
+			args: []string{"0", strconv.FormatInt(math.MaxInt64, 10)},
+		},
+		{
+			name: "full range of int64",
+			args: []string{strconv.FormatInt(math.MinInt64, 10), strconv.FormatInt(math.MaxInt64, 10)},
+		},
+		{
+			name:        "upper bound lower than lower bound",
+			args:        []string{"10", "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid lower bound",
+			args:        []string{"a", "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "lower bound out of range",
+			args:        []string{"-9223372036854775809", "1"},
+			expectedErr: true,
+		},
+		{
+			name:        "upper bound out of range",
+			args:        []string{"0", "9223372036854775808"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := randomInt(tt.args)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			n, ok := new(big.Int).SetString(value, 10)
+			assert.True(t, ok)
+			lower, _ := new(big.Int).SetString(tt.args[0], 10)
+			upper, _ := new(big.Int).SetString(tt.args[1], 10)
+			assert.True(t, n.Cmp(lower) >= 0, "%s is lower than %s", n, lower)
+			assert.True(t, n.Cmp(upper) <= 0, "%s is greater than %s", n, upper)
+		})
+	}
+}
+
+func TestRandomString(t *testing.T) {
+	tests := []struct {
+		name           string
+		length         string
+		expectedLength int
+		expectedErr    bool
+	}{
+		{
+			name:           "empty string",
+			length:         "0",
+			expectedLength: 0,
+		},
+		{
+			name:           "short string",
+			length:         "12",
+			expectedLength: 12,
+		},
+		{
+			name:           "maximum length",
+			length:         "4096",
+			expectedLength: maxRandomStringLength,
+		},
+		{
+			name:        "length above the maximum",
+			length:      "10000000000",
+			expectedErr: true,
+		},
+		{
+			name:        "negative length",
+			length:      "-1",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid length",
+			length:      "ten",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := randomString([]string{tt.length})
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, value, tt.expectedLength)
+			assert.Regexp(t, "^[a-zA-Z0-9]*$", value)
+		})
+	}
+}
+
+func TestFuncReplacer(t *testing.T) {
+	lookupEnv := func(name string) (string, bool) {
+		if name == "TOKEN" {
+			return "secret", true
+		}
+		return "", false
+	}
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		opts     []FuncOpts
+	}{
+		{
+			name:     "base64",
+			input:    `${base64("user:pass")}`,
+			expected: "dXNlcjpwYXNz",
+		},
+		{
+			name:     "sha256",
+			input:    `${sha256("abc")}`,
+			expected: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:     "hmac",
+			input:    `${hmac("key", "The quick brown fox jumps over the lazy dog")}`,
+			expected: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			name:     "upper and lower",
+			input:    `${upper(abc)}-${lower('DEF')}`,
+			expected: "ABC-def",
+		},
+		{
+			name:     "json escape",
+			input:    `{"name": "${jsonEscape('say "hi"')}"}`,
+			expected: `{"name": "say \"hi\""}`,
+		},
+		{
+			name:     "nested calls",
+			input:    `${lower(base64("ab"))}`,
+			expected: "ywi=",
+		},
+		{
+			name:     "env with lookup",
+			input:    "Bearer ${env(TOKEN)}",
+			expected: "Bearer secret",
+			opts:     []FuncOpts{WithEnvLookup(lookupEnv)},
+		},
+		{
+			name:     "env without lookup is not replaced",
+			input:    "Bearer ${env(TOKEN)}",
+			expected: "Bearer ${env(TOKEN)}",
+		},
+		{
+			name:     "unknown function is not replaced",
+			input:    "${unknown()}",
+			expected: "${unknown()}",
+		},
+		{
+			name:     "invalid number of arguments is not replaced",
+			input:    `${upper("a", "b")}`,
+			expected: `${upper("a", "b")}`,
+		},
+		{
+			name:     "failing call is not replaced",
+			input:    "${randomString(10000000000)}",
+			expected: "${randomString(10000000000)}",
+		},
+		{
+			name:     "overflowing range is replaced",
+			input:    "${randomInt(9223372036854775807, 9223372036854775807)}",
+			expected: "9223372036854775807",
+		},
+		{
+			name:     "arguments with placeholders are not replaced",
+			input:    "${upper(${vars.name})}",
+			expected: "${upper(${vars.name})}",
+		},
+		{
+			name:     "variables are not replaced",
+			input:    "${variables.name}",
+			expected: "${variables.name}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewFuncReplacer(tt.opts...).Replace(tt.input))
+		})
+	}
+}
+
+func TestFuncReplacerRandomValues(t *testing.T) {
+	r := NewFuncReplacer()
+
+	assert.Regexp(t, "^[0-9a-f-]{36}$", r.Replace("${uuid()}"))
+	assert.Regexp(t, "^[a-z0-9]{12}@example.com$", r.Replace("${randomEmail()}"))
+	assert.NotEqual(t, r.Replace("${uuid()}"), r.Replace("${uuid()}"))
+	assert.Regexp(t, regexp.MustCompile("^[0-9]+$"), r.Replace("${unix()}"))
+
+	date, err := time.Parse(time.DateOnly, r.Replace("${dateAdd(7d, DateOnly)}"))
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().UTC().Add(7*24*time.Hour), date, 48*time.Hour)
+
+	assert.Equal(t, "${dateAdd(7x)}", r.Replace("${dateAdd(7x)}"))
+}
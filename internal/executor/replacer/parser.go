@@ -0,0 +1,168 @@
+package replacer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parser parses and evaluates a function call, e.g. hmac("key", upper(value)), starting at pos.
+type parser struct {
+	s         string
+	pos       int
+	functions map[string]function
+	resolve   func(string) (string, bool)
+}
+
+func (p *parser) call() (string, error) {
+	name := p.identifier()
+	fn, ok := p.functions[name]
+	if !ok {
+		return "", fmt.Errorf("unknown function %s", name)
+	}
+	if !p.consume('(') {
+		return "", fmt.Errorf("expected ( after %s", name)
+	}
+	args, err := p.args()
+	if err != nil {
+		return "", err
+	}
+	if len(args) < fn.minArgs || len(args) > fn.maxArgs {
+		return "", fmt.Errorf("invalid number of arguments for %s", name)
+	}
+	return fn.call(args)
+}
+
+func (p *parser) args() ([]string, error) {
+	p.skipSpaces()
+	if p.consume(')') {
+		return nil, nil
+	}
+	args := []string{}
+	for {
+		arg, err := p.arg()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		p.skipSpaces()
+		if p.consume(')') {
+			return args, nil
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("expected , or ) at %d", p.pos)
+		}
+	}
+}
+
+func (p *parser) arg() (string, error) {
+	p.skipSpaces()
+	if p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+		return p.quoted()
+	}
+	start := p.pos
+	if p.identifier() != "" && p.consume('(') {
+		p.pos = start
+		return p.call()
+	}
+	p.pos = start
+	return p.unquoted()
+}
+
+// quoted parses a string in single or double quotes, a backslash escapes the next character.
+func (p *parser) quoted() (string, error) {
+	quote := p.s[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return p.resolveArg(b.String())
+		case c == '\\' && p.pos+1 < len(p.s):
+			p.pos++
+			c = p.s[p.pos]
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+// unquoted parses a value up to the next comma or closing parenthesis, surrounding spaces are trimmed.
+func (p *parser) unquoted() (string, error) {
+	start := p.pos
+	for p.pos < len(p.s) && !strings.ContainsRune(",()}\n", rune(p.s[p.pos])) {
+		if strings.HasPrefix(p.s[p.pos:], "${") {
+			p.skipPlaceholder()
+			continue
+		}
+		p.pos++
+	}
+	arg := strings.TrimSpace(p.s[start:p.pos])
+	if arg == "" {
+		return "", fmt.Errorf("missing argument at %d", start)
+	}
+	return p.resolveArg(arg)
+}
+
+// skipPlaceholder skips a placeholder of an argument up to its closing brace, e.g. ${variables.name}.
+func (p *parser) skipPlaceholder() {
+	depth := 0
+	for p.pos += len("$"); p.pos < len(p.s); p.pos++ {
+		switch p.s[p.pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth == 0 {
+			p.pos++
+			return
+		}
+	}
+}
+
+// resolveArg replaces the placeholders of an argument. Arguments with placeholders which can not be
+// replaced return an error, such that the call is not evaluated.
+func (p *parser) resolveArg(arg string) (string, error) {
+	if !strings.Contains(arg, "${") {
+		return arg, nil
+	}
+	if p.resolve != nil {
+		if value, ok := p.resolve(arg); ok {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("argument %s contains a placeholder", arg)
+}
+
+func (p *parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.s) && (isLetter(p.s[p.pos]) || (p.pos > start && isDigit(p.s[p.pos]))) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
@@ -2,9 +2,7 @@
 package replacer
 
 import (
-	"fmt"
 	"strings"
-	"time"
 )
 
 // Replacer is an interface that replaces a string with a new string.
@@ -12,32 +10,118 @@ type Replacer interface {
 	Replace(string) string
 }
 
-// NewFuncReplacer returns a Replacer that replaces the following functions:
+// placeholderReplacer is implemented by the replacers of this package, which replace the placeholder
+// at the start of a string, e.g. ${uuid()}. It returns the value and the length of the placeholder,
+// resolve replaces the placeholders of the arguments of function calls.
+type placeholderReplacer interface {
+	replacePlaceholder(s string, resolve func(string) (string, bool)) (string, int, bool)
+}
+
+// Combine returns a Replacer that replaces the placeholders of the replacers in a single pass. The
+// first replacer which replaces a placeholder wins, and replaced values are not scanned again, such
+// that values which contain ${ are kept as they are. Arguments of function calls may contain the
+// placeholders of the other replacers, e.g. ${upper(${variables.name})}.
+func Combine(replacers ...Replacer) Replacer {
+	return &combinedReplacer{replacers: replacers}
+}
+
+type combinedReplacer struct {
+	replacers []Replacer
+}
+
+func (c *combinedReplacer) Replace(s string) string {
+	value, _ := c.replace(s)
+	return value
+}
+
+// replace replaces the placeholders of s and reports whether all of them were replaced.
+func (c *combinedReplacer) replace(s string) (string, bool) {
+	var b strings.Builder
+	replacedAll := true
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), replacedAll
+		}
+		b.WriteString(s[:start])
+		value, n, ok := c.replacePlaceholder(s[start:])
+		if ok {
+			b.WriteString(value)
+			s = s[start+n:]
+			continue
+		}
+		replacedAll = false
+		b.WriteString("${")
+		s = s[start+len("${"):]
+	}
+}
+
+func (c *combinedReplacer) replacePlaceholder(s string) (string, int, bool) {
+	for _, r := range c.replacers {
+		p, ok := r.(placeholderReplacer)
+		if !ok {
+			continue
+		}
+		if value, n, ok := p.replacePlaceholder(s, c.replace); ok {
+			return value, n, true
+		}
+	}
+	return "", 0, false
+}
+
+// NewFuncReplacer returns a Replacer that replaces function calls, e.g. ${uuid()}. Every
+// occurrence of a function call is evaluated separately. Arguments are quoted strings,
+// unquoted values or nested function calls, e.g. ${upper(sha256("value"))}.
 // - unix() -> unix timestamp
 // - unixNano() -> unix timestamp in nanoseconds
-// every function definition is replaced with a dynamic value at run time.
-func NewFuncReplacer() Replacer {
-	return &funcReplacer{
-		replacementMap: map[string]func() string{
-			"unix()": func() string {
-				return fmt.Sprintf("%d", time.Now().Unix())
-			},
-			"unixNano()": func() string {
-				return fmt.Sprintf("%d", time.Now().UnixNano())
-			},
-		},
+// - uuid() -> random UUID
+// - randomString(n) -> random alphanumeric string of length n, at most 4096
+// - randomInt(a, b) -> random integer between a and b, inclusive
+// - randomEmail() -> random email address
+// - now(format) -> current time in the optional format, RFC3339 by default
+// - dateAdd(duration, format) -> current time plus the duration, e.g. 24h or -7d
+// - base64(x) -> base64 encoded x
+// - sha256(x) -> hex encoded SHA-256 hash of x
+// - hmac(key, x) -> hex encoded HMAC-SHA256 of x
+// - env(NAME) -> value of the environment variable, if an environment lookup is set
+// - upper(x), lower(x) -> x in upper or lower case
+// - jsonEscape(x) -> x escaped for use in a JSON string
+// Calls of unknown functions and calls which can not be evaluated are not replaced.
+func NewFuncReplacer(opts ...FuncOpts) Replacer {
+	f := &funcReplacer{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// FuncOpts is a function for setting options on a function replacer.
+type FuncOpts func(*funcReplacer)
+
+// WithEnvLookup sets the lookup of environment variables for the env function,
+// e.g. os.LookupEnv. Without lookup, calls of env are not replaced.
+func WithEnvLookup(lookupEnv func(string) (string, bool)) FuncOpts {
+	return func(f *funcReplacer) {
+		f.lookupEnv = lookupEnv
 	}
 }
 
 type funcReplacer struct {
-	replacementMap map[string]func() string
+	lookupEnv func(string) (string, bool)
 }
 
 func (f *funcReplacer) Replace(s string) string {
-	for k, v := range f.replacementMap {
-		s = strings.ReplaceAll(s, formatKey(k), v())
+	return Combine(f).Replace(s)
+}
+
+func (f *funcReplacer) replacePlaceholder(s string, resolve func(string) (string, bool)) (string, int, bool) {
+	p := &parser{s: s, pos: len("${"), functions: f.functions(), resolve: resolve}
+	value, err := p.call()
+	if err != nil || !p.consume('}') {
+		return "", 0, false
 	}
-	return s
+	return value, p.pos, true
 }
 
 // NewMapReplacer returns a Replacer that replaces the following variables.
@@ -54,12 +138,14 @@ type variableReplacer struct {
 }
 
 func (v *variableReplacer) Replace(s string) string {
-	for k, v := range v.replacementMap {
-		s = strings.ReplaceAll(s, formatKey(k), v)
-	}
-	return s
+	return Combine(v).Replace(s)
 }
 
-func formatKey(key string) string {
-	return fmt.Sprintf("${%s}", key)
+func (v *variableReplacer) replacePlaceholder(s string, _ func(string) (string, bool)) (string, int, bool) {
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return "", 0, false
+	}
+	value, ok := v.replacementMap[s[len("${"):end]]
+	return value, end + 1, ok
 }
@@ -0,0 +1,68 @@
+package replacer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombine(t *testing.T) {
+	r := Combine(
+		NewMapReplacer(map[string]string{"secrets.token": "${uuid()}", "variables.name": "alice"}),
+		NewFuncReplacer(),
+		NewMapReplacer(map[string]string{"variables.name": "bob", "variables.greeting": "hello ${variables.name}"}),
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "first replacer wins",
+			input:    "${variables.name}",
+			expected: "alice",
+		},
+		{
+			name:     "replaced values are not replaced again",
+			input:    "${secrets.token} ${variables.greeting}",
+			expected: "${uuid()} hello ${variables.name}",
+		},
+		{
+			name:     "unquoted argument with placeholder",
+			input:    "${upper(${variables.name})}",
+			expected: "ALICE",
+		},
+		{
+			name:     "quoted argument with placeholders",
+			input:    `${base64("${variables.name}:${secrets.token}")}`,
+			expected: "YWxpY2U6JHt1dWlkKCl9",
+		},
+		{
+			name:     "nested call with placeholder",
+			input:    "${upper(${lower(${variables.name})})}",
+			expected: "ALICE",
+		},
+		{
+			name:     "argument with unknown placeholder",
+			input:    "${upper(${vars.token})}",
+			expected: "${upper(${vars.token})}",
+		},
+		{
+			name:     "unterminated placeholder",
+			input:    "${variables.name",
+			expected: "${variables.name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, r.Replace(tt.input))
+		})
+	}
+}
+
+func TestMapReplacer(t *testing.T) {
+	r := NewMapReplacer(map[string]string{"a": "${b}", "b": "${a}"})
+
+	assert.Equal(t, "${b} ${a} ${c}", r.Replace("${a} ${b} ${c}"))
+}
@@ -0,0 +1,26 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrResourceNotFound is returned when a resource referenced by a scenario can not be found.
+var ErrResourceNotFound = fmt.Errorf("resource not found")
+
+// ResourceReader reads the content of a resource referenced by a scenario,
+// such as the dataset of a matrix or a JSON Schema.
+type ResourceReader func(name string) ([]byte, error)
+
+// NewFileResourceReader returns a resource reader which reads resources from files
+// relative to the given directory.
+func NewFileResourceReader(dir string) ResourceReader {
+	return func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(dir, name))
+	}
+}
+
+func noResourceReader(name string) ([]byte, error) {
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, name)
+}
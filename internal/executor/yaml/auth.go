@@ -0,0 +1,142 @@
+package yaml
+
+import (
+	"fmt"
+	"time"
+)
+
+// Different authentication settings.
+const (
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypePassword          = "password"
+	AlgorithmHS256             = "HS256"
+	AlgorithmRS256             = "RS256"
+)
+
+// ErrInvalidAuth is an error for when the authentication of a scenario or a step is invalid.
+type ErrInvalidAuth struct {
+	StepName string
+	Msg      string
+}
+
+func (e ErrInvalidAuth) Error() string {
+	if e.StepName == "" {
+		return fmt.Sprintf("invalid auth for scenario: %s", e.Msg)
+	}
+	return fmt.Sprintf("invalid auth for step \"%s\": %s", e.StepName, e.Msg)
+}
+
+// Auth authenticates the requests of a scenario or a step with exactly one of the authentication
+// methods. Steps use the authentication of the scenario, unless they define their own or set None.
+type Auth struct {
+	None   bool       `yaml:"none,omitempty"`
+	Basic  *BasicAuth `yaml:"basic,omitempty"`
+	Bearer string     `yaml:"bearer,omitempty"`
+	OAuth2 *OAuth2    `yaml:"oauth2,omitempty"`
+	JWT    *JWT       `yaml:"jwt,omitempty"`
+	HMAC   *HMAC      `yaml:"hmac,omitempty"`
+}
+
+// BasicAuth authenticates requests with HTTP basic authentication.
+type BasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// OAuth2 authenticates requests with an access token of the client_credentials or password grant,
+// which is fetched once per execution of the scenario and refreshed before it expires.
+type OAuth2 struct {
+	GrantType    string   `yaml:"grant_type"`
+	TokenURL     string   `yaml:"token_url"`
+	ClientID     string   `yaml:"client_id,omitempty"`
+	ClientSecret string   `yaml:"client_secret,omitempty"`
+	Username     string   `yaml:"username,omitempty"`
+	Password     string   `yaml:"password,omitempty"`
+	Scopes       []string `yaml:"scopes,omitempty"`
+}
+
+// JWT authenticates requests with a JSON Web Token, which is signed for every request with the
+// HS256 algorithm and Secret, or the RS256 algorithm and the PEM encoded attachment PrivateKey.
+// The iat and, if ExpiresIn is set, exp claims are set unless they are defined as Claims.
+// The token is sent as bearer token, or as the value of Header if it is set.
+type JWT struct {
+	Algorithm  string        `yaml:"algorithm"`
+	Secret     string        `yaml:"secret,omitempty"`
+	PrivateKey string        `yaml:"private_key,omitempty"`
+	Claims     JSON          `yaml:"claims,omitempty"`
+	ExpiresIn  time.Duration `yaml:"expires_in,omitempty"`
+	Header     string        `yaml:"header,omitempty"`
+}
+
+// HMAC signs requests with an HMAC (sha256 or sha512) of the method, the request URI, the unix
+// timestamp, if TimestampHeader is set, and the body, separated by newlines. The signature is sent
+// encoded as hex or base64 in Header, which defaults to X-Signature.
+type HMAC struct {
+	Key             string `yaml:"key"`
+	Algorithm       string `yaml:"algorithm,omitempty"`
+	Encoding        string `yaml:"encoding,omitempty"`
+	Header          string `yaml:"header,omitempty"`
+	TimestampHeader string `yaml:"timestamp_header,omitempty"`
+}
+
+// validate returns a description of what is wrong with the authentication,
+// or an empty string if the authentication is valid.
+func (a Auth) validate() string {
+	methods := 0
+	for _, isSet := range []bool{a.None, a.Basic != nil, a.Bearer != "", a.OAuth2 != nil, a.JWT != nil, a.HMAC != nil} {
+		if isSet {
+			methods++
+		}
+	}
+	switch {
+	case methods != 1:
+		return "exactly one of none, basic, bearer, oauth2, jwt or hmac must be set"
+	case a.OAuth2 != nil:
+		return a.OAuth2.validate()
+	case a.JWT != nil:
+		return a.JWT.validate()
+	case a.HMAC != nil:
+		return a.HMAC.validate()
+	}
+	return ""
+}
+
+func (o OAuth2) validate() string {
+	switch {
+	case o.TokenURL == "":
+		return "oauth2 token_url is required"
+	case o.GrantType != GrantTypeClientCredentials && o.GrantType != GrantTypePassword:
+		return fmt.Sprintf("oauth2 grant_type %s must be one of client_credentials or password", o.GrantType)
+	case o.GrantType == GrantTypePassword && o.Username == "":
+		return "oauth2 username is required for the password grant"
+	}
+	return ""
+}
+
+func (j JWT) validate() string {
+	switch {
+	case j.Algorithm == AlgorithmHS256 && j.Secret == "":
+		return "jwt secret is required for HS256"
+	case j.Algorithm == AlgorithmRS256 && j.PrivateKey == "":
+		return "jwt private_key is required for RS256"
+	case j.Algorithm != AlgorithmHS256 && j.Algorithm != AlgorithmRS256:
+		return fmt.Sprintf("jwt algorithm %s must be one of HS256 or RS256", j.Algorithm)
+	case j.ExpiresIn < 0:
+		return "jwt expires_in must not be negative"
+	case len(j.Claims) > 0 && j.Claims[0] != '{':
+		return "jwt claims must be an object"
+	}
+	return ""
+}
+
+func (h HMAC) validate() string {
+	switch {
+	case h.Key == "":
+		return "hmac key is required"
+	case h.Algorithm != "" && h.Algorithm != "sha256" && h.Algorithm != "sha512":
+		return fmt.Sprintf("hmac algorithm %s must be one of sha256 or sha512", h.Algorithm)
+	case h.Encoding != "" && h.Encoding != "hex" && h.Encoding != "base64":
+		return fmt.Sprintf("hmac encoding %s must be one of hex or base64", h.Encoding)
+	}
+	return ""
+}
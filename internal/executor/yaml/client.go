@@ -0,0 +1,127 @@
+package yaml
+
+import (
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultMaxRedirects = 10
+
+// ErrInvalidClient is an error for when the client configuration of a scenario is invalid.
+type ErrInvalidClient struct {
+	Msg string
+}
+
+func (e ErrInvalidClient) Error() string {
+	return fmt.Sprintf("invalid client configuration: %s", e.Msg)
+}
+
+// Client configures the HTTP client of a scenario. The client configuration of a scenario
+// overrides the client configuration of its project, tls and redirects are overridden as a whole.
+// Proxy is the URL of an HTTP proxy, UnixSocket the path of a Unix domain socket all requests
+// are sent to. HTTP2 forces HTTP/2, also for http URLs.
+type Client struct {
+	TLS        *TLS       `yaml:"tls,omitempty"`
+	Proxy      string     `yaml:"proxy,omitempty"`
+	Redirects  *Redirects `yaml:"redirects,omitempty"`
+	HTTP2      *bool      `yaml:"http2,omitempty"`
+	UnixSocket string     `yaml:"unix_socket,omitempty"`
+}
+
+// TLS configures the TLS connections of a client. CA, Cert and Key reference PEM encoded
+// attachments: a CA bundle trusted in addition to the system certificates, and the client
+// certificate and its private key for mutual TLS.
+type TLS struct {
+	CA                 string `yaml:"ca,omitempty"`
+	Cert               string `yaml:"cert,omitempty"`
+	Key                string `yaml:"key,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// Redirects configures whether redirects are followed, which they are by default, up to
+// Max redirects (10 by default). Redirect responses which are not followed can be asserted
+// as any other response.
+type Redirects struct {
+	Follow *bool `yaml:"follow,omitempty"`
+	Max    int   `yaml:"max,omitempty"`
+}
+
+// NewClientFromBytes creates a new client configuration from a byte array representing a YAML file,
+// such as the client configuration of a project.
+func NewClientFromBytes(data []byte) (*Client, error) {
+	var client Client
+	err := yaml.Unmarshal(data, &client)
+	if err != nil {
+		return nil, err
+	}
+	msg := client.validate()
+	if msg != "" {
+		return nil, ErrInvalidClient{Msg: msg}
+	}
+	return &client, nil
+}
+
+// WithDefaults returns the client configuration, of which the settings which are not set are
+// taken from the defaults. Either of both may be nil.
+func (c *Client) WithDefaults(defaults *Client) *Client {
+	switch {
+	case c == nil:
+		return defaults
+	case defaults == nil:
+		return c
+	}
+	client := *c
+	if client.TLS == nil {
+		client.TLS = defaults.TLS
+	}
+	if client.Proxy == "" {
+		client.Proxy = defaults.Proxy
+	}
+	if client.Redirects == nil {
+		client.Redirects = defaults.Redirects
+	}
+	if client.HTTP2 == nil {
+		client.HTTP2 = defaults.HTTP2
+	}
+	if client.UnixSocket == "" {
+		client.UnixSocket = defaults.UnixSocket
+	}
+	return &client
+}
+
+// FollowRedirects returns whether redirects are followed.
+func (c Client) FollowRedirects() bool {
+	return c.Redirects == nil || c.Redirects.Follow == nil || *c.Redirects.Follow
+}
+
+// MaxRedirects returns the maximum number of redirects which are followed.
+func (c Client) MaxRedirects() int {
+	if c.Redirects == nil || c.Redirects.Max == 0 {
+		return defaultMaxRedirects
+	}
+	return c.Redirects.Max
+}
+
+// validate returns a description of what is wrong with the client configuration,
+// or an empty string if the client configuration is valid.
+func (c Client) validate() string {
+	if c.TLS != nil && (c.TLS.Cert == "") != (c.TLS.Key == "") {
+		return "tls cert and key must be set together"
+	}
+	if c.Redirects != nil && c.Redirects.Max < 0 {
+		return "redirects max must not be negative"
+	}
+	if c.Proxy == "" {
+		return ""
+	}
+	if c.HTTP2 != nil && *c.HTTP2 {
+		return "proxies are not supported with http2"
+	}
+	proxyURL, err := url.Parse(c.Proxy)
+	if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+		return fmt.Sprintf("proxy %s is not a valid URL", c.Proxy)
+	}
+	return ""
+}
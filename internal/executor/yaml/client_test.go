@@ -0,0 +1,143 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientFromBytes(t *testing.T) {
+	follow := false
+	tests := []struct {
+		name           string
+		data           string
+		expectedClient *Client
+		expectedErr    error
+	}{
+		{
+			name: "client",
+			data: `tls:
+  ca: ca.pem
+  cert: client.pem
+  key: client-key.pem
+proxy: http://proxy:3128
+redirects:
+  follow: false
+  max: 3
+unix_socket: /var/run/app.sock
+`,
+			expectedClient: &Client{
+				TLS:        &TLS{CA: "ca.pem", Cert: "client.pem", Key: "client-key.pem"},
+				Proxy:      "http://proxy:3128",
+				Redirects:  &Redirects{Follow: &follow, Max: 3},
+				UnixSocket: "/var/run/app.sock",
+			},
+		},
+		{
+			name:        "cert without key",
+			data:        "tls:\n  cert: client.pem\n",
+			expectedErr: ErrInvalidClient{Msg: "tls cert and key must be set together"},
+		},
+		{
+			name:        "negative max redirects",
+			data:        "redirects:\n  max: -1\n",
+			expectedErr: ErrInvalidClient{Msg: "redirects max must not be negative"},
+		},
+		{
+			name:        "proxy with http2",
+			data:        "proxy: http://proxy:3128\nhttp2: true\n",
+			expectedErr: ErrInvalidClient{Msg: "proxies are not supported with http2"},
+		},
+		{
+			name:        "invalid proxy",
+			data:        "proxy: proxy:3128\n",
+			expectedErr: ErrInvalidClient{Msg: "proxy proxy:3128 is not a valid URL"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientFromBytes([]byte(tt.data))
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedClient, client)
+		})
+	}
+}
+
+func TestClientWithDefaults(t *testing.T) {
+	enabled, disabled := true, false
+	defaults := &Client{
+		TLS:        &TLS{CA: "ca.pem"},
+		Proxy:      "http://proxy:3128",
+		Redirects:  &Redirects{Follow: &disabled},
+		HTTP2:      &enabled,
+		UnixSocket: "/var/run/app.sock",
+	}
+	tests := []struct {
+		name     string
+		client   *Client
+		defaults *Client
+		expected *Client
+	}{
+		{
+			name:     "without client",
+			defaults: defaults,
+			expected: defaults,
+		},
+		{
+			name:     "without defaults",
+			client:   &Client{Proxy: "http://other:3128"},
+			expected: &Client{Proxy: "http://other:3128"},
+		},
+		{
+			name:     "settings of the client override the defaults",
+			client:   &Client{TLS: &TLS{InsecureSkipVerify: true}, Redirects: &Redirects{Max: 2}, HTTP2: &disabled},
+			defaults: defaults,
+			expected: &Client{
+				TLS:        &TLS{InsecureSkipVerify: true},
+				Proxy:      "http://proxy:3128",
+				Redirects:  &Redirects{Max: 2},
+				HTTP2:      &disabled,
+				UnixSocket: "/var/run/app.sock",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.client.WithDefaults(tt.defaults))
+		})
+	}
+}
+
+func TestClientRedirects(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name                 string
+		client               Client
+		expectedFollow       bool
+		expectedMaxRedirects int
+	}{
+		{
+			name:                 "defaults",
+			expectedFollow:       true,
+			expectedMaxRedirects: 10,
+		},
+		{
+			name:                 "max",
+			client:               Client{Redirects: &Redirects{Max: 3}},
+			expectedFollow:       true,
+			expectedMaxRedirects: 3,
+		},
+		{
+			name:                 "not followed",
+			client:               Client{Redirects: &Redirects{Follow: &disabled}},
+			expectedFollow:       false,
+			expectedMaxRedirects: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedFollow, tt.client.FollowRedirects())
+			assert.Equal(t, tt.expectedMaxRedirects, tt.client.MaxRedirects())
+		})
+	}
+}
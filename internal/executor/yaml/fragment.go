@@ -0,0 +1,205 @@
+package yaml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/inquiryproj/inquiry/internal/executor/replacer"
+)
+
+const paramsPrefix = "params"
+
+// Fragment is a reusable sequence of steps, e.g. to log in or to clean up, which
+// is included in the setup, steps or teardown of scenarios by a use step:
+//
+//	setup:
+//	  - use: login
+//	    params:
+//	      username: admin
+//
+// The placeholders ${params.<name>} of the steps of the fragment are replaced with
+// the params of the use step. Fragments can include other fragments.
+type Fragment struct {
+	Params []*FragmentParam `yaml:"params,omitempty"`
+	Steps  yaml.Node        `yaml:"steps"`
+}
+
+// FragmentParam is a parameter of a fragment, parameters without default are required.
+type FragmentParam struct {
+	Name    string  `yaml:"name"`
+	Default *string `yaml:"default,omitempty"`
+}
+
+// useStep is a step which includes the steps of a fragment.
+type useStep struct {
+	Use    string            `yaml:"use"`
+	Params map[string]string `yaml:"params,omitempty"`
+}
+
+// ErrInvalidFragment is an error for when the fragment used by a step of
+// a scenario, or of another fragment, can not be expanded.
+type ErrInvalidFragment struct {
+	Scenario string
+	Step     string
+	Fragment string
+	Err      error
+}
+
+func (e ErrInvalidFragment) Error() string {
+	return fmt.Sprintf("invalid fragment \"%s\" used by step %s of \"%s\": %s", e.Fragment, e.Step, e.Scenario, e.Err)
+}
+
+func (e ErrInvalidFragment) Unwrap() error {
+	return e.Err
+}
+
+// ExpandFragments replaces the use steps of the setup, steps and teardown of the scenario
+// with the steps of the fragments they use, which are read with readFragment.
+// The data is returned as is if the scenario has no use steps.
+func ExpandFragments(name string, data []byte, readFragment func(name string) ([]byte, error)) ([]byte, error) {
+	root := &yaml.Node{}
+	err := yaml.Unmarshal(data, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return data, nil
+	}
+	e := &fragmentExpander{readFragment: readFragment}
+	mapping := root.Content[0]
+	expanded := false
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		section, steps := mapping.Content[i].Value, mapping.Content[i+1]
+		if section != "setup" && section != "steps" && section != "teardown" {
+			continue
+		}
+		sectionExpanded, err := e.expandSteps(name, section, steps, []string{name})
+		if err != nil {
+			return nil, err
+		}
+		expanded = expanded || sectionExpanded
+	}
+	if !expanded {
+		return data, nil
+	}
+	return yaml.Marshal(root)
+}
+
+type fragmentExpander struct {
+	readFragment func(name string) ([]byte, error)
+}
+
+// expandSteps expands the use steps of a sequence of steps in place, stack contains the
+// names of the scenario and the fragments which include the steps, to detect cycles.
+func (e *fragmentExpander) expandSteps(including, section string, steps *yaml.Node, stack []string) (bool, error) {
+	if steps.Kind != yaml.SequenceNode {
+		return false, nil
+	}
+	expanded := false
+	content := []*yaml.Node{}
+	for i, step := range steps.Content {
+		if !isUseStep(step) {
+			content = append(content, step)
+			continue
+		}
+		use := &useStep{}
+		err := step.Decode(use)
+		if err == nil {
+			var fragmentSteps []*yaml.Node
+			fragmentSteps, err = e.fragmentSteps(use, stack)
+			content = append(content, fragmentSteps...)
+		}
+		if err != nil {
+			return false, ErrInvalidFragment{
+				Scenario: including,
+				Step:     fmt.Sprintf("%s[%d]", section, i),
+				Fragment: use.Use,
+				Err:      err,
+			}
+		}
+		expanded = true
+	}
+	steps.Content = content
+	return expanded, nil
+}
+
+// fragmentSteps returns the steps of the fragment of a use step, with its params replaced
+// and the fragments it uses expanded.
+func (e *fragmentExpander) fragmentSteps(use *useStep, stack []string) ([]*yaml.Node, error) {
+	if slices.Contains(stack, use.Use) {
+		return nil, fmt.Errorf("cycle detected: %s -> %s", strings.Join(stack, " -> "), use.Use)
+	}
+	data, err := e.readFragment(use.Use)
+	if err != nil {
+		return nil, err
+	}
+	fragment := &Fragment{}
+	err = yaml.Unmarshal(data, fragment)
+	if err != nil {
+		return nil, err
+	}
+	params, err := fragment.params(use.Params)
+	if err != nil {
+		return nil, err
+	}
+	replaceScalars(&fragment.Steps, replacer.NewMapReplacer(params))
+	_, err = e.expandSteps(use.Use, "steps", &fragment.Steps, append(slices.Clone(stack), use.Use))
+	if err != nil {
+		return nil, err
+	}
+	return fragment.Steps.Content, nil
+}
+
+// params returns the replacements of the params of the fragment for the given values,
+// the values of params which are not given default to the defaults of the fragment.
+func (f *Fragment) params(values map[string]string) (map[string]string, error) {
+	params := map[string]string{}
+	for _, param := range f.Params {
+		value, ok := values[param.Name]
+		switch {
+		case ok:
+		case param.Default != nil:
+			value = *param.Default
+		default:
+			return nil, fmt.Errorf("missing param %s", param.Name)
+		}
+		params[fmt.Sprintf("%s.%s", paramsPrefix, param.Name)] = value
+	}
+	for name := range values {
+		if _, ok := params[fmt.Sprintf("%s.%s", paramsPrefix, name)]; !ok {
+			return nil, fmt.Errorf("unknown param %s", name)
+		}
+	}
+	return params, nil
+}
+
+func isUseStep(step *yaml.Node) bool {
+	if step.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i < len(step.Content); i += 2 {
+		if step.Content[i].Value == "use" {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceScalars replaces the placeholders of all scalar values of a node, such that
+// values which contain YAML syntax do not change the structure of the node.
+// The type of unquoted values is resolved again, e.g. for a timeout: ${params.timeout}.
+func replaceScalars(node *yaml.Node, r replacer.Replacer) {
+	if node.Kind == yaml.ScalarNode {
+		value := r.Replace(node.Value)
+		if value != node.Value && node.Style == 0 {
+			node.Tag = ""
+		}
+		node.Value = value
+	}
+	for _, child := range node.Content {
+		replaceScalars(child, r)
+	}
+}
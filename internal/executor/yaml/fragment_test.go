@@ -0,0 +1,188 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fragmentReader(fragments map[string]string) func(name string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		fragment, ok := fragments[name]
+		if !ok {
+			return nil, fmt.Errorf("fragment %s not found", name)
+		}
+		return []byte(fragment), nil
+	}
+}
+
+const loginFragment = `params:
+  - name: user
+  - name: timeout
+    default: 5s
+steps:
+  - name: login
+    timeout: ${params.timeout}
+    request:
+      method: POST
+      url: http://localhost/login
+      headers:
+        - name: X-User
+          value: ${params.user}
+`
+
+func TestExpandFragments(t *testing.T) {
+	fragments := map[string]string{
+		"login.yaml": loginFragment,
+		"session.yaml": `params:
+  - name: user
+steps:
+  - use: login.yaml
+    params:
+      user: ${params.user}
+      timeout: 1s
+  - name: session
+    request:
+      method: GET
+      url: http://localhost/session
+`,
+	}
+	data := []byte(`version: v1
+type: http
+setup:
+  - use: login.yaml
+    params:
+      user: admin
+steps:
+  - name: first
+    request:
+      method: GET
+      url: http://localhost
+  - use: session.yaml
+    params:
+      user: nested
+teardown:
+  - use: login.yaml
+    params:
+      user: admin
+      timeout: 2s
+`)
+	expanded, err := ExpandFragments("scenario.yaml", data, fragmentReader(fragments))
+	assert.NoError(t, err)
+	_, scenario, err := NewTestDefinitionFromBytes(expanded)
+	assert.NoError(t, err)
+	if err != nil {
+		return
+	}
+
+	assert.Len(t, scenario.Setup, 1)
+	assert.Equal(t, "login", scenario.Setup[0].Name)
+	assert.Equal(t, 5*time.Second, scenario.Setup[0].Timeout)
+	assert.Equal(t, "admin", scenario.Setup[0].Request.Headers[0].Value)
+
+	assert.Len(t, scenario.Steps, 3)
+	assert.Equal(t, "first", scenario.Steps[0].Name)
+	assert.Equal(t, "login", scenario.Steps[1].Name)
+	assert.Equal(t, time.Second, scenario.Steps[1].Timeout)
+	assert.Equal(t, "nested", scenario.Steps[1].Request.Headers[0].Value)
+	assert.Equal(t, "session", scenario.Steps[2].Name)
+
+	assert.Len(t, scenario.Teardown, 1)
+	assert.Equal(t, 2*time.Second, scenario.Teardown[0].Timeout)
+}
+
+func TestExpandFragmentsWithoutUseSteps(t *testing.T) {
+	data := []byte("version: v1\ntype: http\n# comment\nsteps: []\n")
+	expanded, err := ExpandFragments("scenario.yaml", data, fragmentReader(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, data, expanded)
+}
+
+func TestExpandFragmentsParamEscaping(t *testing.T) {
+	for _, value := range []string{"a: b", "a #b", `"a'`, "a\nb", "- a", "{a}", "[a, b]", "${variables.user}"} {
+		t.Run(value, func(t *testing.T) {
+			data := []byte(`version: v1
+type: http
+steps:
+  - use: login.yaml
+    params:
+      user: ` + fmt.Sprintf("%q", value) + `
+`)
+			expanded, err := ExpandFragments("scenario.yaml", data, fragmentReader(map[string]string{"login.yaml": loginFragment}))
+			assert.NoError(t, err)
+			_, scenario, err := NewTestDefinitionFromBytes(expanded)
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Len(t, scenario.Steps, 1)
+			assert.Equal(t, value, scenario.Steps[0].Request.Headers[0].Value)
+		})
+	}
+}
+
+func TestExpandFragmentsErrors(t *testing.T) {
+	fragments := map[string]string{
+		"login.yaml":   loginFragment,
+		"a.yaml":       "steps:\n  - use: b.yaml\n",
+		"b.yaml":       "steps:\n  - use: a.yaml\n",
+		"self.yaml":    "steps:\n  - use: self.yaml\n",
+		"invalid.yaml": "steps: [",
+	}
+	tests := []struct {
+		name        string
+		use         string
+		expectedErr string
+	}{
+		{
+			name:        "missing fragment",
+			use:         "use: missing.yaml",
+			expectedErr: `invalid fragment "missing.yaml" used by step steps[1] of "scenario.yaml": fragment missing.yaml not found`,
+		},
+		{
+			name:        "cycle",
+			use:         "use: a.yaml",
+			expectedErr: `invalid fragment "a.yaml" used by step steps[1] of "scenario.yaml": invalid fragment "b.yaml" used by step steps[0] of "a.yaml": invalid fragment "a.yaml" used by step steps[0] of "b.yaml": cycle detected: scenario.yaml -> a.yaml -> b.yaml -> a.yaml`,
+		},
+		{
+			name:        "fragment using itself",
+			use:         "use: self.yaml",
+			expectedErr: `invalid fragment "self.yaml" used by step steps[1] of "scenario.yaml": invalid fragment "self.yaml" used by step steps[0] of "self.yaml": cycle detected: scenario.yaml -> self.yaml -> self.yaml`,
+		},
+		{
+			name:        "missing param",
+			use:         "use: login.yaml",
+			expectedErr: `invalid fragment "login.yaml" used by step steps[1] of "scenario.yaml": missing param user`,
+		},
+		{
+			name:        "unknown param",
+			use:         "use: login.yaml\n    params:\n      user: admin\n      password: secret",
+			expectedErr: `invalid fragment "login.yaml" used by step steps[1] of "scenario.yaml": unknown param password`,
+		},
+		{
+			name:        "invalid fragment",
+			use:         "use: invalid.yaml",
+			expectedErr: `invalid fragment "invalid.yaml" used by step steps[1] of "scenario.yaml": yaml: line 1: did not find expected node content`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(`version: v1
+type: http
+steps:
+  - name: first
+    request:
+      method: GET
+      url: http://localhost
+  - ` + tt.use + `
+`)
+			_, err := ExpandFragments("scenario.yaml", data, fragmentReader(fragments))
+			assert.EqualError(t, err, tt.expectedErr)
+			invalidFragment := ErrInvalidFragment{}
+			assert.True(t, errors.As(err, &invalidFragment))
+		})
+	}
+}
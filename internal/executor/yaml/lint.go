@@ -0,0 +1,564 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/inquiryproj/inquiry/internal/executor/replacer"
+)
+
+// Issue is a problem of a scenario or fragment at a position of its YAML document.
+// Column is 0 if the issue applies to the whole line. Warnings are issues which may
+// be resolved when the scenario is executed, e.g. variables provided by an environment.
+type Issue struct {
+	Line    int
+	Column  int
+	Message string
+	Warning bool
+}
+
+func (i *Issue) String() string {
+	message := i.Message
+	if i.Warning {
+		message = "warning: " + message
+	}
+	if i.Column == 0 {
+		return fmt.Sprintf("line %d: %s", i.Line, message)
+	}
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, message)
+}
+
+// Errors returns the issues which are no warnings.
+func Errors(issues []*Issue) []*Issue {
+	return slices.DeleteFunc(slices.Clone(issues), func(issue *Issue) bool {
+		return issue.Warning
+	})
+}
+
+// scenarioDocument is the YAML document of a scenario, as read by NewTestDefinitionFromBytes.
+type scenarioDocument struct {
+	TestSpec `yaml:",inline"`
+	Scenario `yaml:",inline"`
+}
+
+// fragmentDocument is the YAML document of a fragment with its steps decoded.
+type fragmentDocument struct {
+	Params []*FragmentParam `yaml:"params,omitempty"`
+	Steps  []*Step          `yaml:"steps"`
+}
+
+// Lint validates a scenario strictly, without executing it, and returns its issues ordered by position.
+// Besides the validation when a scenario is executed, it reports unknown fields, steps without name or
+// request, invalid methods, unknown assertion methods, duplicate step names and placeholders which can
+// not be resolved, e.g. references to steps which are not executed before. Undeclared variables are
+// reported as warnings, as they may be provided by the environment the scenario is executed in.
+func Lint(data []byte) []*Issue {
+	l := &linter{variables: map[string]bool{}}
+	return l.lint(data, reflect.TypeOf(scenarioDocument{}), []string{"setup", "steps", "teardown"})
+}
+
+// LintFragment validates a fragment strictly, as Lint validates scenarios. Variables are
+// not reported as undeclared, as they are declared by the scenarios which use the fragment.
+func LintFragment(data []byte) []*Issue {
+	l := &linter{variables: map[string]bool{}, params: map[string]bool{}, anyVariable: true}
+	return l.lint(data, reflect.TypeOf(fragmentDocument{}), []string{"steps"})
+}
+
+// linter collects the issues of a document, steps are linted in the order in which they are executed.
+type linter struct {
+	issues []*Issue
+	// variables are the declared variables, or any variable is accepted if anyVariable is set.
+	variables   map[string]bool
+	anyVariable bool
+	// params are the declared params of a fragment, or nil for scenarios.
+	params    map[string]bool
+	stepNames map[string]bool
+	captures  map[string]bool
+	// usesFragments is set once a use step is linted, the steps and captures of fragments are unknown.
+	usesFragments bool
+	// inStep is set while steps are linted, captured variables and outputs of steps can only be used by steps.
+	inStep bool
+}
+
+func (l *linter) lint(data []byte, documentType reflect.Type, sections []string) []*Issue {
+	root := &yaml.Node{}
+	err := yaml.Unmarshal(data, root)
+	if err != nil {
+		return []*Issue{errorIssue(err)}
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return []*Issue{{Line: 1, Message: "document must be a mapping"}}
+	}
+	document := root.Content[0]
+	l.checkFields(document, documentType)
+	l.declare(document)
+	l.lintSections(document, sections)
+	err = document.Decode(reflect.New(documentType).Interface())
+	if err != nil {
+		l.addErrorIssues(err)
+	}
+	if len(Errors(l.issues)) == 0 {
+		l.validate(document, sections)
+	}
+	slices.SortStableFunc(l.issues, func(a, b *Issue) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return l.issues
+}
+
+func (l *linter) addIssue(node *yaml.Node, format string, args ...any) {
+	l.issues = append(l.issues, &Issue{
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// addErrorIssues adds the errors of decoding a document, which are prefixed with their line.
+// Values with placeholders are skipped, as their type is known once the placeholders are replaced.
+func (l *linter) addErrorIssues(err error) {
+	var typeError *yaml.TypeError
+	if !errors.As(err, &typeError) {
+		l.issues = append(l.issues, errorIssue(err))
+		return
+	}
+	for _, msg := range typeError.Errors {
+		if !strings.Contains(msg, "`${") {
+			l.issues = append(l.issues, errorIssue(errors.New(msg)))
+		}
+	}
+}
+
+// errorIssue returns the issue of a YAML error, the line is parsed from the message, e.g. yaml: line 3: ...
+func errorIssue(err error) *Issue {
+	matches := regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`).FindStringSubmatch(err.Error())
+	if matches == nil {
+		return &Issue{Line: 1, Message: err.Error()}
+	}
+	line, _ := strconv.Atoi(matches[1])
+	return &Issue{Line: line, Message: matches[2]}
+}
+
+// checkFields reports the fields of mappings which are not fields of the type they are decoded into.
+func (l *linter) checkFields(node *yaml.Node, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(yaml.Node{}) || reflect.PointerTo(t).Implements(reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()) {
+		return
+	}
+	switch {
+	case node.Kind == yaml.MappingNode:
+		l.checkMappingFields(node, t)
+	case node.Kind == yaml.SequenceNode && t.Kind() == reflect.Slice:
+		for _, child := range node.Content {
+			l.checkFields(child, t.Elem())
+		}
+	}
+}
+
+func (l *linter) checkMappingFields(node *yaml.Node, t reflect.Type) {
+	switch t.Kind() {
+	case reflect.Struct:
+		l.checkStructFields(node, t)
+	case reflect.Map:
+		for i := 1; i < len(node.Content); i += 2 {
+			l.checkFields(node.Content[i], t.Elem())
+		}
+	}
+}
+
+func (l *linter) checkStructFields(node *yaml.Node, t reflect.Type) {
+	if t == reflect.TypeOf(Step{}) && isUseStep(node) {
+		t = reflect.TypeOf(useStep{})
+	}
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		field, ok := fields[key.Value]
+		if !ok {
+			l.addIssue(key, "unknown field %s", key.Value)
+			continue
+		}
+		l.checkFields(node.Content[i+1], field.Type)
+	}
+}
+
+// yamlField is a field of a struct as it is decoded from YAML.
+type yamlField struct {
+	Type      reflect.Type
+	OmitEmpty bool
+}
+
+// yamlFields returns the fields of a struct by their YAML names, including the fields of inlined structs.
+func yamlFields(t reflect.Type) map[string]*yamlField {
+	fields := map[string]*yamlField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		switch {
+		case !field.IsExported() || name == "-":
+		case strings.Contains(opts, "inline"):
+			maps.Copy(fields, yamlFields(field.Type))
+		case name == "":
+			fields[strings.ToLower(field.Name)] = &yamlField{Type: field.Type, OmitEmpty: strings.Contains(opts, "omitempty")}
+		default:
+			fields[name] = &yamlField{Type: field.Type, OmitEmpty: strings.Contains(opts, "omitempty")}
+		}
+	}
+	return fields
+}
+
+// declare collects the variables declared by the variables and the matrix of a scenario, or the params of a fragment.
+func (l *linter) declare(document *yaml.Node) {
+	declareNames(l.variables, mappingValue(document, "variables"))
+	if l.params != nil {
+		declareNames(l.params, mappingValue(document, "params"))
+	}
+	matrix := mappingValue(document, "matrix")
+	l.anyVariable = l.anyVariable || mappingValue(matrix, "dataset") != nil
+	for _, row := range sequence(mappingValue(matrix, "rows")) {
+		declareKeys(l.variables, row)
+	}
+	declareKeys(l.variables, mappingValue(matrix, "parameters"))
+}
+
+// declareNames declares the names of the items of a sequence, e.g. of variables or captures.
+func declareNames(declared map[string]bool, node *yaml.Node) {
+	for _, item := range sequence(node) {
+		if name := mappingValue(item, "name"); name != nil {
+			declared[name.Value] = true
+		}
+	}
+}
+
+func declareKeys(declared map[string]bool, node *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		declared[node.Content[i].Value] = true
+	}
+}
+
+// lintSections lints the steps of the sections in order, and the placeholders of all other fields.
+func (l *linter) lintSections(document *yaml.Node, sections []string) {
+	l.stepNames = map[string]bool{}
+	l.captures = map[string]bool{}
+	for i := 0; i+1 < len(document.Content); i += 2 {
+		if !slices.Contains(sections, document.Content[i].Value) {
+			l.lintPlaceholders(document.Content[i+1])
+		}
+	}
+	l.inStep = true
+	for _, section := range sections {
+		for _, step := range sequence(mappingValue(document, section)) {
+			l.lintStep(step)
+		}
+	}
+	l.inStep = false
+}
+
+func (l *linter) lintStep(step *yaml.Node) {
+	if step.Kind != yaml.MappingNode {
+		l.addIssue(step, "step must be a mapping")
+		return
+	}
+	l.lintPlaceholders(step)
+	if isUseStep(step) {
+		if use := mappingValue(step, "use"); use == nil || use.Value == "" {
+			l.addIssue(step, "use step has no fragment")
+		}
+		l.usesFragments = true
+		return
+	}
+	l.lintStepName(step, mappingValue(step, "name"))
+	if request := mappingValue(step, "request"); request != nil {
+		l.lintRequest(request)
+	} else {
+		l.addIssue(step, "step has no request")
+	}
+	l.lintAssertions(mappingValue(step, "validation"))
+	l.lintAssertions(mappingValue(step, "until"))
+	declareNames(l.captures, mappingValue(step, "capture"))
+}
+
+func (l *linter) lintStepName(step, name *yaml.Node) {
+	switch {
+	case name == nil || name.Value == "":
+		l.addIssue(step, "step has no name")
+	case l.stepNames[name.Value]:
+		l.addIssue(name, "duplicate step name %s", name.Value)
+	default:
+		l.stepNames[name.Value] = true
+	}
+}
+
+func (l *linter) lintRequest(request *yaml.Node) {
+	if request.Kind != yaml.MappingNode {
+		return
+	}
+	if url := mappingValue(request, "url"); url == nil || url.Value == "" {
+		l.addIssue(request, "request has no url")
+	}
+	method := mappingValue(request, "method")
+	switch {
+	case method == nil || method.Value == "":
+		l.addIssue(request, "request has no method")
+	case strings.Contains(method.Value, "${"):
+	case !slices.Contains(httpMethods(), method.Value):
+		l.addIssue(method, "invalid method %s, expected one of %s", method.Value, strings.Join(httpMethods(), ", "))
+	}
+}
+
+func httpMethods() []string {
+	return []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "CONNECT", "TRACE"}
+}
+
+// lintAssertions reports the assertions of a validation with unknown assertion methods.
+func (l *linter) lintAssertions(validation *yaml.Node) {
+	assertions := []*yaml.Node{mappingValue(validation, "status"), mappingValue(validation, "latency")}
+	for _, key := range []string{"body", "headers", "cookies"} {
+		assertions = append(assertions, sequence(mappingValue(validation, key))...)
+	}
+	assertions = append(assertions, sequence(mappingValue(mappingValue(validation, "graphql"), "data"))...)
+	for _, assertion := range assertions {
+		l.lintAssertion(assertion)
+	}
+}
+
+func (l *linter) lintAssertion(assertion *yaml.Node) {
+	if assertion == nil || assertion.Kind != yaml.MappingNode {
+		return
+	}
+	method := mappingValue(assertion, "assertion")
+	switch {
+	case method == nil:
+		l.addIssue(assertion, "assertion has no assertion method")
+	case !slices.Contains(assertionMethods(), assertionMethod(method.Value)):
+		l.addIssue(method, "unknown assertion method %s", method.Value)
+	}
+}
+
+// lintPlaceholders reports the function calls and placeholders of all scalars of a node which can not be resolved.
+func (l *linter) lintPlaceholders(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		for _, call := range regexp.MustCompile(`\$\{\s*([A-Za-z_][A-Za-z0-9_]*)\(`).FindAllStringSubmatch(node.Value, -1) {
+			if !replacer.IsFunction(call[1]) {
+				l.addIssue(node, "unknown function %s", call[1])
+			}
+		}
+		for _, call := range runtimeFunctionCalls().FindAllStringSubmatch(node.Value, -1) {
+			l.addIssue(node, "%s", ErrRuntimeFunctionCall{Name: call[1]})
+		}
+		for _, placeholder := range regexp.MustCompile(`\$\{([^{}]*)\}`).FindAllStringSubmatch(node.Value, -1) {
+			l.lintPlaceholder(node, placeholder[0], strings.TrimSpace(placeholder[1]))
+		}
+	}
+	for _, child := range node.Content {
+		l.lintPlaceholders(child)
+	}
+}
+
+// lintPlaceholder reports a placeholder which can not be resolved, undeclared variables are reported as warnings.
+func (l *linter) lintPlaceholder(node *yaml.Node, placeholder, path string) {
+	msg := l.placeholderIssue(path)
+	if msg == "" {
+		return
+	}
+	l.addIssue(node, "unresolved placeholder %s: %s", placeholder, msg)
+	namespace, _, _ := strings.Cut(path, ".")
+	l.issues[len(l.issues)-1].Warning = namespace == variablesPrefix
+}
+
+// placeholderIssue returns why a placeholder can not be resolved, or an empty string if it can.
+// Function calls are reported by their names, as their arguments may contain placeholders.
+func (l *linter) placeholderIssue(placeholder string) string {
+	if regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\(`).MatchString(placeholder) {
+		return ""
+	}
+	namespace, name, _ := strings.Cut(placeholder, ".")
+	issue, ok := l.placeholderIssues()[namespace]
+	if !ok {
+		return "unknown placeholder"
+	}
+	return issue(name)
+}
+
+func (l *linter) placeholderIssues() map[string]func(name string) string {
+	return map[string]func(name string) string{
+		variablesPrefix: l.variableIssue,
+		secretsPrefix:   func(string) string { return "" },
+		paramsPrefix:    l.paramIssue,
+		"vars":          l.captureIssue,
+		"steps":         l.stepOutputIssue,
+	}
+}
+
+func (l *linter) variableIssue(name string) string {
+	if l.anyVariable || l.variables[name] {
+		return ""
+	}
+	return fmt.Sprintf("variable %s is not declared", name)
+}
+
+func (l *linter) paramIssue(name string) string {
+	switch {
+	case l.params == nil:
+		return "params can only be used by fragments"
+	case !l.params[name]:
+		return fmt.Sprintf("param %s is not declared", name)
+	}
+	return ""
+}
+
+func (l *linter) captureIssue(name string) string {
+	switch {
+	case !l.inStep:
+		return "captured variables can only be used by steps"
+	case l.captures[name] || l.usesFragments:
+		return ""
+	}
+	return fmt.Sprintf("variable %s is not captured by an earlier step", name)
+}
+
+func (l *linter) stepOutputIssue(path string) string {
+	segments := strings.Split(path, ".")
+	switch {
+	case !l.inStep:
+		return "outputs of steps can only be used by steps"
+	case len(segments) <= 3 && !(len(segments) == 2 && segments[1] == "status"):
+		return "expected steps.<name>.response.body.<key> or steps.<name>.status"
+	case !l.stepNames[segments[0]] && !l.usesFragments:
+		return fmt.Sprintf("step %s is not executed before", segments[0])
+	}
+	return ""
+}
+
+// validate validates the steps as they are validated when they are executed, with their functions
+// and variables replaced. The errors are reported at the position of the invalid steps.
+func (l *linter) validate(document *yaml.Node, sections []string) {
+	replacers := []replacer.Replacer{replacer.NewFuncReplacer()}
+	if l.params == nil {
+		resolved, err := resolve(document, replacers)
+		if err != nil {
+			return
+		}
+		testSpec, err := NewTestSpecFromBytes(resolved)
+		switch {
+		case err != nil && unresolvedPlaceholders().Match(resolved):
+			// the test spec is valid once the undeclared variables are provided.
+			testSpec = &TestSpec{}
+			if variables := mappingValue(document, variablesPrefix); variables != nil {
+				_ = variables.Decode(&testSpec.Variables)
+			}
+		case err != nil:
+			l.addIssue(document, "%s", err)
+			return
+		}
+		replacers = append([]replacer.Replacer{replacer.NewMapReplacer(testSpec.getVariablesMap())}, replacers...)
+		if auth := mappingValue(document, "auth"); auth != nil {
+			l.validateNode(auth, replacers, validateAuth)
+		}
+	}
+	for _, section := range sections {
+		for _, step := range sequence(mappingValue(document, section)) {
+			if !isUseStep(step) {
+				l.validateNode(step, replacers, validateStep)
+			}
+		}
+	}
+}
+
+// validateNode decodes a node with its placeholders replaced and reports its validation error.
+// Nodes with params or undeclared variables are not validated, as they are replaced when used.
+func (l *linter) validateNode(node *yaml.Node, replacers []replacer.Replacer, validate func(data []byte) error) {
+	resolved, err := resolve(node, replacers)
+	if err != nil || unresolvedPlaceholders().Match(resolved) {
+		return
+	}
+	err = validate(resolved)
+	var typeError *yaml.TypeError
+	switch {
+	case errors.As(err, &typeError):
+		for _, msg := range typeError.Errors {
+			l.addIssue(node, "%s", errorIssue(errors.New(msg)).Message)
+		}
+	case err != nil:
+		l.addIssue(node, "%s", err)
+	}
+}
+
+// unresolvedPlaceholders matches the placeholders of params and variables, which remain
+// in resolved nodes of fragments or if variables are not declared.
+func unresolvedPlaceholders() *regexp.Regexp {
+	return regexp.MustCompile(`\$\{(` + paramsPrefix + `|` + variablesPrefix + `)\.`)
+}
+
+// resolve returns a copy of a node with its placeholders replaced, the node itself is not changed.
+func resolve(node *yaml.Node, replacers []replacer.Replacer) ([]byte, error) {
+	b, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	resolved := &yaml.Node{}
+	err = yaml.Unmarshal(b, resolved)
+	if err != nil {
+		return nil, err
+	}
+	replaceNode(resolved, replacers)
+	return yaml.Marshal(resolved)
+}
+
+func validateStep(data []byte) error {
+	step := &Step{}
+	err := yaml.Unmarshal(data, step)
+	if err != nil {
+		return err
+	}
+	return step.validate()
+}
+
+func validateAuth(data []byte) error {
+	auth := &Auth{}
+	err := yaml.Unmarshal(data, auth)
+	if err != nil {
+		return err
+	}
+	if msg := auth.validate(); msg != "" {
+		return ErrInvalidAuth{Msg: msg}
+	}
+	return nil
+}
+
+// mappingValue returns the value of a key of a mapping, or nil if the node is no mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequence returns the items of a sequence, or nil if the node is no sequence.
+func sequence(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
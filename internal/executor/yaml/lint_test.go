@@ -0,0 +1,350 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []*Issue
+	}{
+		{
+			name: "valid",
+			data: `version: v1
+type: http
+variables:
+  - name: host
+    value: http://localhost
+steps:
+  - name: login
+    request:
+      method: POST
+      url: ${variables.host}/login
+    capture:
+      - name: token
+        body: token
+  - name: users
+    if: ${steps.login.status} == 200
+    request:
+      method: GET
+      url: ${variables.host}/users/${uuid()}
+      headers:
+        - name: Authorization
+          value: Bearer ${vars.token} ${secrets.key} ${steps.login.response.body.id}
+`,
+			expected: []*Issue{},
+		},
+		{
+			name:     "syntax error",
+			data:     "version: v1\nsteps: [\n",
+			expected: []*Issue{{Line: 2, Message: "did not find expected node content"}},
+		},
+		{
+			name:     "document is no mapping",
+			data:     "- step\n",
+			expected: []*Issue{{Line: 1, Message: "document must be a mapping"}},
+		},
+		{
+			name: "unknown fields",
+			data: `version: v1
+type: http
+foo: bar
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+      bodyy: x
+`,
+			expected: []*Issue{
+				{Line: 3, Column: 1, Message: "unknown field foo"},
+				{Line: 9, Column: 7, Message: "unknown field bodyy"},
+			},
+		},
+		{
+			name: "step without name and step without request",
+			data: `version: v1
+type: http
+steps:
+  - request:
+      method: GET
+      url: http://localhost
+  - name: b
+`,
+			expected: []*Issue{
+				{Line: 4, Column: 5, Message: "step has no name"},
+				{Line: 7, Column: 5, Message: "step has no request"},
+			},
+		},
+		{
+			name: "duplicate step name",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+`,
+			expected: []*Issue{{Line: 8, Column: 11, Message: "duplicate step name a"}},
+		},
+		{
+			name: "invalid method and request without method and url",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    request:
+      method: FETCH
+      url: http://localhost
+  - name: b
+    request:
+      url: ""
+`,
+			expected: []*Issue{
+				{Line: 6, Column: 15, Message: "invalid method FETCH, expected one of GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS, CONNECT, TRACE"},
+				{Line: 10, Column: 7, Message: "request has no url"},
+				{Line: 10, Column: 7, Message: "request has no method"},
+			},
+		},
+		{
+			name: "unknown assertion method and assertion without method",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+    validation:
+      status:
+        assertion: equals
+        value: "200"
+      body:
+        - path: id
+`,
+			expected: []*Issue{
+				{Line: 10, Column: 20, Message: "unknown assertion method equals"},
+				{Line: 13, Column: 11, Message: "unknown field path"},
+				{Line: 13, Column: 11, Message: "assertion has no assertion method"},
+			},
+		},
+		{
+			name: "unresolved placeholders",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost/${foo()}/${params.x}/${unknown.y}
+      headers:
+        - name: X
+          value: ${vars.token} ${steps.b.response.body.id} ${steps.a.response} ${steps.a.status}
+`,
+			expected: []*Issue{
+				{Line: 7, Column: 12, Message: "unknown function foo"},
+				{Line: 7, Column: 12, Message: "unresolved placeholder ${params.x}: params can only be used by fragments"},
+				{Line: 7, Column: 12, Message: "unresolved placeholder ${unknown.y}: unknown placeholder"},
+				{Line: 10, Column: 18, Message: "unresolved placeholder ${vars.token}: variable token is not captured by an earlier step"},
+				{Line: 10, Column: 18, Message: "unresolved placeholder ${steps.b.response.body.id}: step b is not executed before"},
+				{Line: 10, Column: 18, Message: "unresolved placeholder ${steps.a.response}: expected steps.<name>.response.body.<key> or steps.<name>.status"},
+				{Line: 10, Column: 18, Message: "unresolved placeholder ${steps.a.status}: step a is not executed before"},
+			},
+		},
+		{
+			name: "functions with outputs of steps",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+    capture:
+      - name: token
+        body: token
+  - name: b
+    request:
+      method: GET
+      url: http://localhost/${upper(${steps.a.response.body.id})}
+      headers:
+        - name: Authorization
+          value: Bearer ${sha256(${vars.token})} ${upper(${variables.missing})}
+`,
+			expected: []*Issue{
+				{Line: 14, Column: 12, Message: "function upper can not be called with vars or steps arguments"},
+				{Line: 17, Column: 18, Message: "function sha256 can not be called with vars or steps arguments"},
+				{Line: 17, Column: 18, Message: "unresolved placeholder ${variables.missing}: variable missing is not declared", Warning: true},
+			},
+		},
+		{
+			name: "outputs of steps outside of steps",
+			data: `version: v1
+type: http
+timeout: ${vars.timeout}
+openapi: ${steps.a.status}
+steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+`,
+			expected: []*Issue{
+				{Line: 3, Column: 10, Message: "unresolved placeholder ${vars.timeout}: captured variables can only be used by steps"},
+				{Line: 4, Column: 10, Message: "unresolved placeholder ${steps.a.status}: outputs of steps can only be used by steps"},
+			},
+		},
+		{
+			name: "undeclared variables are warnings",
+			data: `version: v1
+type: http
+timeout: ${variables.timeout}
+steps:
+  - name: a
+    request:
+      method: GET
+      url: ${variables.host}/users
+    retry:
+      attempts: ${variables.attempts}
+`,
+			expected: []*Issue{
+				{Line: 3, Column: 10, Message: "unresolved placeholder ${variables.timeout}: variable timeout is not declared", Warning: true},
+				{Line: 8, Column: 12, Message: "unresolved placeholder ${variables.host}: variable host is not declared", Warning: true},
+				{Line: 10, Column: 17, Message: "unresolved placeholder ${variables.attempts}: variable attempts is not declared", Warning: true},
+			},
+		},
+		{
+			name: "steps are validated with warnings",
+			data: `version: v1
+type: http
+variables:
+  - name: attempts
+    value: "0"
+steps:
+  - name: a
+    request:
+      method: GET
+      url: ${variables.host}
+  - name: b
+    request:
+      method: GET
+      url: http://localhost
+    retry:
+      attempts: ${variables.attempts}
+`,
+			expected: []*Issue{
+				{Line: 10, Column: 12, Message: "unresolved placeholder ${variables.host}: variable host is not declared", Warning: true},
+				{Line: 11, Column: 5, Message: `invalid retry for step "b": one of attempts or max_duration must be set`},
+			},
+		},
+		{
+			name: "type error",
+			data: `version: v1
+type: http
+steps:
+  - name: a
+    timeout: soon
+    request:
+      method: GET
+      url: http://localhost
+`,
+			expected: []*Issue{{Line: 5, Message: "cannot unmarshal !!str `soon` into time.Duration"}},
+		},
+		{
+			name: "steps and captures of fragments are unknown",
+			data: `version: v1
+type: http
+steps:
+  - use: login.yaml
+  - name: a
+    request:
+      method: GET
+      url: http://localhost/${steps.login.response.body.id}
+      headers:
+        - name: Authorization
+          value: Bearer ${vars.token}
+`,
+			expected: []*Issue{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := Lint([]byte(tt.data))
+			if len(tt.expected) == 0 {
+				assert.Empty(t, issues)
+				return
+			}
+			assert.Equal(t, tt.expected, issues)
+		})
+	}
+}
+
+func TestLintFragment(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []*Issue
+	}{
+		{
+			name: "variables and declared params",
+			data: `params:
+  - name: user
+steps:
+  - name: a
+    request:
+      method: GET
+      url: ${variables.host}/${params.user}
+    retry:
+      attempts: ${params.attempts}
+`,
+			expected: []*Issue{{Line: 9, Column: 17, Message: "unresolved placeholder ${params.attempts}: param attempts is not declared"}},
+		},
+		{
+			name: "invalid step",
+			data: `steps:
+  - name: a
+    request:
+      method: GET
+      url: http://localhost
+    retry:
+      attempts: 0
+`,
+			expected: []*Issue{{Line: 2, Column: 5, Message: `invalid retry for step "a": one of attempts or max_duration must be set`}},
+		},
+		{
+			name:     "unknown field",
+			data:     "version: v1\nsteps: []\n",
+			expected: []*Issue{{Line: 1, Column: 1, Message: "unknown field version"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, LintFragment([]byte(tt.data)))
+		})
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	assert.Equal(t, "line 2: invalid", (&Issue{Line: 2, Message: "invalid"}).String())
+	assert.Equal(t, "2:5: invalid", (&Issue{Line: 2, Column: 5, Message: "invalid"}).String())
+	assert.Equal(t, "2:5: warning: undeclared", (&Issue{Line: 2, Column: 5, Message: "undeclared", Warning: true}).String())
+}
+
+func TestErrors(t *testing.T) {
+	warning := &Issue{Line: 1, Message: "warning", Warning: true}
+	err := &Issue{Line: 2, Message: "error"}
+	issues := []*Issue{warning, err}
+
+	assert.Equal(t, []*Issue{err}, Errors(issues))
+	assert.Equal(t, []*Issue{warning, err}, issues)
+	assert.Empty(t, Errors([]*Issue{warning}))
+}
@@ -2,23 +2,66 @@
 package yaml
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/inquiryproj/inquiry/internal/executor/curl"
 	"github.com/inquiryproj/inquiry/internal/executor/replacer"
 )
 
-const variablesPrefix = "variables"
+const (
+	variablesPrefix = "variables"
+	secretsPrefix   = "secrets"
+)
 
 type assertionMethod string
 
 // Different assertion methods.
 const (
-	AssertionMethodEqual    assertionMethod = "equal"
-	AssertionMethodRegex    assertionMethod = "regex"
-	AssertionMethodNotEmpty assertionMethod = "not_empty"
+	AssertionMethodEqual              assertionMethod = "equal"
+	AssertionMethodNotEqual           assertionMethod = "not_equal"
+	AssertionMethodRegex              assertionMethod = "regex"
+	AssertionMethodNotEmpty           assertionMethod = "not_empty"
+	AssertionMethodContains           assertionMethod = "contains"
+	AssertionMethodStartsWith         assertionMethod = "starts_with"
+	AssertionMethodEndsWith           assertionMethod = "ends_with"
+	AssertionMethodGreaterThan        assertionMethod = "gt"
+	AssertionMethodGreaterThanOrEqual assertionMethod = "gte"
+	AssertionMethodLessThan           assertionMethod = "lt"
+	AssertionMethodLessThanOrEqual    assertionMethod = "lte"
+	AssertionMethodIn                 assertionMethod = "in"
+	AssertionMethodExists             assertionMethod = "exists"
+	AssertionMethodNotExists          assertionMethod = "not_exists"
+	AssertionMethodLength             assertionMethod = "length"
+	AssertionMethodType               assertionMethod = "type"
+)
+
+func assertionMethods() []assertionMethod {
+	return []assertionMethod{
+		AssertionMethodEqual, AssertionMethodNotEqual, AssertionMethodRegex, AssertionMethodNotEmpty,
+		AssertionMethodContains, AssertionMethodStartsWith, AssertionMethodEndsWith,
+		AssertionMethodGreaterThan, AssertionMethodGreaterThanOrEqual, AssertionMethodLessThan,
+		AssertionMethodLessThanOrEqual, AssertionMethodIn, AssertionMethodExists, AssertionMethodNotExists,
+		AssertionMethodLength, AssertionMethodType,
+	}
+}
+
+// Different value types which can be asserted with the type assertion method.
+const (
+	ValueTypeString = "string"
+	ValueTypeNumber = "number"
+	ValueTypeBool   = "bool"
+	ValueTypeArray  = "array"
+	ValueTypeObject = "object"
+	ValueTypeNull   = "null"
 )
 
 type testType string
@@ -29,15 +72,52 @@ const (
 )
 
 // Scenario represents a single test scenario represented in YAML.
+// Setup steps are executed before the steps of the scenario, teardown
+// steps are always executed afterwards, even if previous steps failed.
+// Auth authenticates the requests of all steps, which do not define their own authentication.
 type Scenario struct {
-	Steps []*Step `yaml:"steps"`
+	Auth     *Auth   `yaml:"auth,omitempty"`
+	Setup    []*Step `yaml:"setup,omitempty"`
+	Steps    []*Step `yaml:"steps"`
+	Teardown []*Step `yaml:"teardown,omitempty"`
+}
+
+func (s Scenario) allSteps() []*Step {
+	steps := []*Step{}
+	steps = append(steps, s.Setup...)
+	steps = append(steps, s.Steps...)
+	return append(steps, s.Teardown...)
 }
 
 // TestSpec for a single scenario.
+// Timeout limits the total execution time of the scenario, whereas
+// MaxDuration asserts the total execution time of the scenario.
+// OpenAPI references an OpenAPI 3 document, every request and response
+// of the scenario is validated against the matching operation of the document.
+// It overrides the contract of the project, i.e. its only OpenAPI document.
+// Cookies enables the cookie jar of the scenario, which stores the cookies of
+// responses and sends them with subsequent requests, it defaults to true.
+// Client configures the HTTP client of the scenario, such as TLS and redirects.
 type TestSpec struct {
-	Version   string      `yaml:"version"`
-	Type      testType    `yaml:"type"`
-	Variables []*Variable `yaml:"variables"`
+	Version     string        `yaml:"version"`
+	Type        testType      `yaml:"type"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	MaxDuration time.Duration `yaml:"max_duration,omitempty"`
+	OpenAPI     string        `yaml:"openapi,omitempty"`
+	Cookies     *bool         `yaml:"cookies,omitempty"`
+	Client      *Client       `yaml:"client,omitempty"`
+	Variables   []*Variable   `yaml:"variables,omitempty"`
+	Matrix      *Matrix       `yaml:"matrix,omitempty"`
+}
+
+// Matrix expands a scenario into multiple executions, each with its own set of
+// variables overriding the variables of the test spec.
+// Rows are defined inline or read from a CSV or JSON dataset, parameters are
+// expanded to every combination of their values.
+type Matrix struct {
+	Rows       []map[string]string `yaml:"rows,omitempty"`
+	Dataset    string              `yaml:"dataset,omitempty"`
+	Parameters map[string][]string `yaml:"parameters,omitempty"`
 }
 
 func (v TestSpec) getVariablesMap() map[string]string {
@@ -55,25 +135,182 @@ type Variable struct {
 }
 
 // Step for a single scenario.
+// Timeout limits the duration of every request of the step.
+// A step is skipped if Skip is set or its If condition evaluates to false, e.g.
+// ${steps.login.status} == 200. Outputs of steps which are not executed are empty in conditions.
+// If any of the steps of a scenario sets Only, all other steps are skipped,
+// setup and teardown steps are not affected by Only.
 type Step struct {
-	Name       string      `yaml:"name"`
-	Request    *Request    `yaml:"request"`
-	Validation *Validation `yaml:"validation"`
-	Retry      *Retry      `yaml:"retry"`
+	Name       string        `yaml:"name"`
+	If         string        `yaml:"if,omitempty"`
+	Skip       bool          `yaml:"skip,omitempty"`
+	Only       bool          `yaml:"only,omitempty"`
+	Timeout    time.Duration `yaml:"timeout,omitempty"`
+	Request    *Request      `yaml:"request,omitempty"`
+	Auth       *Auth         `yaml:"auth,omitempty"`
+	Validation *Validation   `yaml:"validation,omitempty"`
+	Until      *Validation   `yaml:"until,omitempty"`
+	Retry      *Retry        `yaml:"retry,omitempty"`
+	Capture    []*Capture    `yaml:"capture,omitempty"`
+}
+
+// Capture stores a value of the response of a step as a named variable,
+// which can be used in subsequent steps as ${vars.<name>}.
+// At most one of Body, Header, Status, Cookie or Data selects the source of the value,
+// if none is set the raw response body is used. Data is a path relative to the data
+// of a GraphQL response, e.g. user.id for data.user.id. Regex optionally extracts the
+// first capture group of the given regular expression from the selected value.
+type Capture struct {
+	Name   string `yaml:"name"`
+	Body   string `yaml:"body,omitempty"`
+	Header string `yaml:"header,omitempty"`
+	Status bool   `yaml:"status,omitempty"`
+	Cookie string `yaml:"cookie,omitempty"`
+	Data   string `yaml:"data,omitempty"`
+	Regex  string `yaml:"regex,omitempty"`
 }
 
 // Retry for a single step.
+// Attempts is the maximum number of retries after the first attempt, if it is
+// not set retries are only limited by MaxDuration, one of both must be set. Timeout is the delay before the
+// first retry, which is multiplied by Backoff for every subsequent retry up to MaxDelay.
+// Jitter randomises every delay by the given fraction of the delay.
+// If Statuses or NetworkErrors are set, only attempts failing with one of the given
+// statuses or with a network error are retried.
+// Steps with an until assertion set require a retry policy, they are retried until the assertions succeed.
 type Retry struct {
-	Attempts int           `yaml:"attempts"`
-	Timeout  time.Duration `yaml:"timeout"`
+	Attempts      int           `yaml:"attempts,omitempty"`
+	Timeout       time.Duration `yaml:"timeout,omitempty"`
+	Backoff       float64       `yaml:"backoff,omitempty"`
+	MaxDelay      time.Duration `yaml:"max_delay,omitempty"`
+	Jitter        float64       `yaml:"jitter,omitempty"`
+	MaxDuration   time.Duration `yaml:"max_duration,omitempty"`
+	Statuses      []int         `yaml:"statuses,omitempty"`
+	NetworkErrors bool          `yaml:"network_errors,omitempty"`
 }
 
-// Request for a single step.
+// Request for a single step. The body of the request is either a string or one of the
+// structured body types: JSON, a URL encoded form, a multipart body, a raw body with
+// its content type or a GraphQL operation posted to the URL of the GraphQL endpoint.
+// The Content-Type header is set according to the type of the body.
 type Request struct {
-	Method  string    `yaml:"method"`
-	URL     string    `yaml:"url"`
-	Headers []*Header `yaml:"headers"`
-	Body    string    `yaml:"body"`
+	Method    string            `yaml:"method"`
+	URL       string            `yaml:"url"`
+	Headers   []*Header         `yaml:"headers,omitempty"`
+	Body      string            `yaml:"body,omitempty"`
+	JSON      JSON              `yaml:"json,omitempty"`
+	Form      map[string]string `yaml:"form,omitempty"`
+	Multipart []*Part           `yaml:"multipart,omitempty"`
+	Raw       *RawBody          `yaml:"raw,omitempty"`
+	GraphQL   *GraphQL          `yaml:"graphql,omitempty"`
+}
+
+// Part of a multipart body, which is either a text field with a value or a file. The
+// content of a file is read from the attachment File, e.g. avatar.png, or defined inline
+// as Base64. FileName defaults to the name of the attachment.
+type Part struct {
+	Name        string `yaml:"name"`
+	Value       string `yaml:"value,omitempty"`
+	File        string `yaml:"file,omitempty"`
+	Base64      string `yaml:"base64,omitempty"`
+	FileName    string `yaml:"filename,omitempty"`
+	ContentType string `yaml:"content_type,omitempty"`
+}
+
+// RawBody is a body with an explicit content type.
+type RawBody struct {
+	ContentType string `yaml:"content_type"`
+	Data        string `yaml:"data"`
+}
+
+// GraphQL is a GraphQL operation, which is sent as a JSON body with the query, its
+// variables and the name of the operation to execute if the query defines several.
+type GraphQL struct {
+	Query         string `yaml:"query"`
+	Variables     JSON   `yaml:"variables,omitempty"`
+	OperationName string `yaml:"operation_name,omitempty"`
+}
+
+// Body returns the JSON body of the GraphQL request.
+func (g GraphQL) Body() string {
+	body := struct {
+		Query         string          `json:"query"`
+		Variables     json.RawMessage `json:"variables,omitempty"`
+		OperationName string          `json:"operationName,omitempty"`
+	}{Query: g.Query, Variables: json.RawMessage(g.Variables), OperationName: g.OperationName}
+	// the body can not fail to marshal, the variables are valid JSON.
+	b, _ := json.Marshal(body)
+	return string(b)
+}
+
+// Curl returns the curl command line performing the request.
+func (r Request) Curl() string {
+	request := &curl.Request{Method: r.Method, URL: r.URL}
+	for _, header := range r.Headers {
+		request.Headers = append(request.Headers, &curl.Header{Name: header.Name, Value: header.Value})
+	}
+	body, contentType := r.body()
+	request.Body = body
+	if contentType != "" && !r.hasHeader("Content-Type") {
+		request.Headers = append(request.Headers, &curl.Header{Name: "Content-Type", Value: contentType})
+	}
+	for _, part := range r.Multipart {
+		curlPart := &curl.Part{
+			Name:        part.Name,
+			Value:       part.Value,
+			File:        part.File,
+			FileName:    part.FileName,
+			ContentType: part.ContentType,
+		}
+		// Inline files are referenced by their file name, the file has to be created to reproduce the request.
+		if part.Base64 != "" {
+			curlPart.File = part.ResolvedFileName()
+		}
+		request.Parts = append(request.Parts, curlPart)
+	}
+	return curl.Command(request)
+}
+
+// body returns the body of the request and its content type, unless the body is a multipart body.
+func (r Request) body() (string, string) {
+	switch {
+	case len(r.JSON) > 0:
+		return string(r.JSON), "application/json"
+	case len(r.Form) > 0:
+		values := url.Values{}
+		for name, value := range r.Form {
+			values.Set(name, value)
+		}
+		return values.Encode(), "application/x-www-form-urlencoded"
+	case r.Raw != nil:
+		return r.Raw.Data, r.Raw.ContentType
+	case r.GraphQL != nil:
+		return r.GraphQL.Body(), "application/json"
+	default:
+		return r.Body, ""
+	}
+}
+
+func (r Request) hasHeader(name string) bool {
+	for _, header := range r.Headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedFileName returns the file name of a file part, which defaults to
+// the base name of the attachment or else the name of the part.
+func (p Part) ResolvedFileName() string {
+	switch {
+	case p.FileName != "":
+		return p.FileName
+	case p.File != "":
+		return path.Base(p.File)
+	default:
+		return p.Name
+	}
 }
 
 // Header for a request.
@@ -82,41 +319,258 @@ type Header struct {
 	Value string `yaml:"value"`
 }
 
-// Validation for a single step.
+// Validation for a single step. Latency asserts the duration of the request with one of the gt, gte, lt
+// or lte assertion methods and a duration value, e.g. 300ms. Schema validates the body against a JSON Schema.
+// Cookies asserts the cookies set by the response, the key is the name of the cookie, optionally followed
+// by one of the attributes value, domain, path, expires, max_age, secure, http_only or same_site,
+// e.g. session.http_only. GraphQL validates the data and errors of a GraphQL response.
 type Validation struct {
-	Body    []*Assertion `yaml:"body"`
-	Status  *Assertion   `yaml:"status"`
-	Headers []*Assertion `yaml:"headers"`
+	Body    []*Assertion       `yaml:"body,omitempty"`
+	Status  *Assertion         `yaml:"status,omitempty"`
+	Headers []*Assertion       `yaml:"headers,omitempty"`
+	Cookies []*Assertion       `yaml:"cookies,omitempty"`
+	Latency *Assertion         `yaml:"latency,omitempty"`
+	Schema  *Schema            `yaml:"schema,omitempty"`
+	GraphQL *GraphQLValidation `yaml:"graphql,omitempty"`
+}
+
+// GraphQLValidation validates a GraphQL response. Data asserts values of the data of
+// the response, the key is a path relative to data, e.g. user.name. NoErrors asserts that
+// the response has no errors, ErrorCodes asserts that the errors contain the given codes
+// of their extensions, e.g. UNAUTHENTICATED.
+type GraphQLValidation struct {
+	Data       []*Assertion `yaml:"data,omitempty"`
+	NoErrors   bool         `yaml:"no_errors,omitempty"`
+	ErrorCodes []string     `yaml:"error_codes,omitempty"`
+}
+
+// Schema is a JSON Schema (draft 2020-12), which is either defined inline or
+// read from the file referenced by Ref, e.g. user.schema.json#/$defs/user.
+type Schema struct {
+	Ref    string `yaml:"ref,omitempty"`
+	Inline JSON   `yaml:"inline,omitempty"`
+}
+
+// JSON is a YAML value which is kept in its JSON representation.
+type JSON []byte
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (j *JSON) UnmarshalYAML(node *yaml.Node) error {
+	var value any
+	err := node.Decode(&value)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("line %d: value can not be represented as JSON: %w", node.Line, err)
+	}
+	*j = b
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (j JSON) MarshalYAML() (any, error) {
+	var value any
+	err := json.Unmarshal(j, &value)
+	return value, err
 }
 
 // Assertion represents an assertion, as part of a validation.
+// Values is only used by the in assertion method, which checks
+// whether the asserted value is one of the given values.
 type Assertion struct {
-	Key       string          `yaml:"key"`
+	Key       string          `yaml:"key,omitempty"`
 	Assertion assertionMethod `yaml:"assertion"`
-	Value     string          `yaml:"value"`
+	Value     string          `yaml:"value,omitempty"`
+	Values    []string        `yaml:"values,omitempty"`
+}
+
+// NewTestSpecFromBytes creates a new test spec from a byte array representing a YAML file,
+// without parsing the scenario itself.
+func NewTestSpecFromBytes(data []byte) (*TestSpec, error) {
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, err
+	}
+	return newTestSpec(document)
+}
+
+func newTestSpec(document *yaml.Node) (*TestSpec, error) {
+	var testSpec TestSpec
+
+	err := decode(document, &testSpec)
+	if err != nil {
+		return nil, err
+	}
+	if testSpec.Client != nil {
+		msg := testSpec.Client.validate()
+		if msg != "" {
+			return nil, ErrInvalidClient{Msg: msg}
+		}
+	}
+	return &testSpec, nil
+}
+
+// decode decodes a document, an empty document is decoded as an empty value.
+func decode(document *yaml.Node, v any) error {
+	if document.Kind == 0 {
+		return nil
+	}
+	return document.Decode(v)
+}
+
+// MarshalTestDefinition marshals a test spec and its scenario into a single YAML document,
+// which can be read with NewTestDefinitionFromBytes.
+func MarshalTestDefinition(testSpec *TestSpec, scenario *Scenario) ([]byte, error) {
+	return yaml.Marshal(struct {
+		TestSpec `yaml:",inline"`
+		Scenario `yaml:",inline"`
+	}{*testSpec, *scenario})
+}
+
+// MarshalSteps marshals steps into a YAML sequence, which can be added to the steps of a scenario.
+func MarshalSteps(steps []*Step) ([]byte, error) {
+	return yaml.Marshal(steps)
+}
+
+// NewVariablesReplacer returns a replacer for the given variables, which
+// replaces ${variables.<name>} placeholders.
+func NewVariablesReplacer(variables map[string]string) replacer.Replacer {
+	replacementMap := make(map[string]string)
+	for k, v := range variables {
+		replacementMap[fmt.Sprintf("%s.%s", variablesPrefix, k)] = v
+	}
+	return replacer.NewMapReplacer(replacementMap)
+}
+
+// ErrUndefinedSecret is an error for when a scenario uses a secret which is not defined.
+type ErrUndefinedSecret struct {
+	Name string
+}
+
+func (e ErrUndefinedSecret) Error() string {
+	return fmt.Sprintf("secret %s is not defined", e.Name)
+}
+
+// NewSecretsReplacer returns a replacer for the given secrets, which
+// replaces ${secrets.<name>} placeholders.
+func NewSecretsReplacer(secrets map[string]string) replacer.Replacer {
+	replacementMap := make(map[string]string)
+	for k, v := range secrets {
+		replacementMap[fmt.Sprintf("%s.%s", secretsPrefix, k)] = v
+	}
+	return replacer.NewMapReplacer(replacementMap)
 }
 
 // NewTestDefinitionFromBytes creates a new test definition from a byte array representing a
 // YAML file.
+// Replacers are applied to the scalar values of the parsed document, such that replaced values
+// which contain YAML syntax, e.g. a secret abc #def, are not interpreted as YAML. The variables of
+// the test spec are replaced first, such that a function assigned to a variable is evaluated once.
+// The other values are replaced in a single pass with the replacers in the given order followed by
+// the variables, replaced values are not replaced again.
 func NewTestDefinitionFromBytes(data []byte, replacers ...replacer.Replacer) (*TestSpec, *Scenario, error) {
-	var testSpec TestSpec
-
-	err := yaml.Unmarshal(data, &testSpec)
+	document := &yaml.Node{}
+	err := yaml.Unmarshal(data, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	// functions are evaluated before the steps are executed and their outputs are known.
+	if name := runtimeFunctionCall(document); name != "" {
+		return nil, nil, ErrRuntimeFunctionCall{Name: name}
+	}
+	replaceDocument(document, replacers)
+	testSpec, err := newTestSpec(document)
 	if err != nil {
 		return nil, nil, err
 	}
-	fileContent := string(data)
-	replacers = append(replacers, replacer.NewMapReplacer(testSpec.getVariablesMap()))
-	for _, r := range replacers {
-		fileContent = r.Replace(fileContent)
+	// secrets which are not replaced would be sent to the target literally.
+	if name := undefinedSecret(document); name != "" {
+		return nil, nil, ErrUndefinedSecret{Name: name}
 	}
 
 	var scenario Scenario
 
-	err = yaml.Unmarshal([]byte(fileContent), &scenario)
+	err = decode(document, &scenario)
 	if err != nil {
 		return nil, nil, fmt.Errorf("invalid yaml definition for scenario after parsing variables %w", err)
 	}
 
-	return &testSpec, &scenario, nil
+	err = scenario.validate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return testSpec, &scenario, nil
+}
+
+// replaceDocument replaces the placeholders of a document. The variables are replaced in the order
+// they are declared, such that a variable can refer to the variables declared before it.
+func replaceDocument(document *yaml.Node, replacers []replacer.Replacer) {
+	root := document
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	variablesNode := mappingValue(root, variablesPrefix)
+	variables := map[string]string{}
+	for _, variable := range sequence(variablesNode) {
+		replaceScalars(variable, replacer.Combine(append(slices.Clone(replacers), replacer.NewMapReplacer(variables))...))
+		if name, value := mappingValue(variable, "name"), mappingValue(variable, "value"); name != nil && value != nil {
+			variables[fmt.Sprintf("%s.%s", variablesPrefix, name.Value)] = value.Value
+		}
+	}
+	r := replacer.Combine(append(slices.Clone(replacers), replacer.NewMapReplacer(variables))...)
+	for _, node := range root.Content {
+		if node != variablesNode {
+			replaceScalars(node, r)
+		}
+	}
+}
+
+// ErrRuntimeFunctionCall is an error for when a function is called with arguments which are only
+// known when the steps are executed, e.g. ${upper(${vars.token})}.
+type ErrRuntimeFunctionCall struct {
+	Name string
+}
+
+func (e ErrRuntimeFunctionCall) Error() string {
+	return fmt.Sprintf("function %s can not be called with vars or steps arguments", e.Name)
+}
+
+// runtimeFunctionCalls matches the function calls with vars or steps arguments, the submatch is the function name.
+func runtimeFunctionCalls() *regexp.Regexp {
+	return regexp.MustCompile(`\$\{\s*([A-Za-z_][A-Za-z0-9_]*)\([^}]*\$\{\s*(?:vars|steps)\.`)
+}
+
+// runtimeFunctionCall returns the name of the first function of a node which is called with vars or steps arguments, if any.
+func runtimeFunctionCall(node *yaml.Node) string {
+	return firstSubmatch(node, runtimeFunctionCalls())
+}
+
+// undefinedSecret returns the name of the first secret of a node which is not replaced, if any.
+func undefinedSecret(node *yaml.Node) string {
+	return firstSubmatch(node, regexp.MustCompile(`\$\{`+secretsPrefix+`\.([^}]*)\}`))
+}
+
+// firstSubmatch returns the first submatch of the regexp in the scalar values of a node, if any.
+func firstSubmatch(node *yaml.Node, re *regexp.Regexp) string {
+	if node.Kind == yaml.ScalarNode {
+		if match := re.FindStringSubmatch(node.Value); match != nil {
+			return match[1]
+		}
+	}
+	for _, child := range node.Content {
+		if match := firstSubmatch(child, re); match != "" {
+			return match
+		}
+	}
+	return ""
+}
+
+// replaceNode replaces the placeholders of the scalar values of a node in a single pass, the first
+// of the replacers in the given order which replaces a placeholder wins.
+func replaceNode(node *yaml.Node, replacers []replacer.Replacer) {
+	replaceScalars(node, replacer.Combine(replacers...))
 }
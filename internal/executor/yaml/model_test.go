@@ -0,0 +1,197 @@
+package yaml
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/inquiryproj/inquiry/internal/executor/replacer"
+)
+
+const replacedScenario = `version: v1
+type: http
+timeout: ${variables.timeout}
+steps:
+  - name: step
+    request:
+      method: POST
+      url: http://localhost/${variables.path}
+      headers:
+        - name: X-Value
+          value: ${variables.value}
+        - name: X-Quoted
+          value: "${variables.value}"
+      body: |
+        value=${variables.value}
+`
+
+func TestNewTestDefinitionFromBytesVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]string
+		replacers []replacer.Replacer
+	}{
+		{
+			name:      "replacer of the given variables",
+			variables: map[string]string{"value": "plain"},
+		},
+		{
+			name:      "comment",
+			variables: map[string]string{"value": "abc #def"},
+		},
+		{
+			name:      "mapping value",
+			variables: map[string]string{"value": "abc: def"},
+		},
+		{
+			name:      "quotes",
+			variables: map[string]string{"value": `"abc" 'def'`},
+		},
+		{
+			name:      "newline",
+			variables: map[string]string{"value": "abc\ndef"},
+		},
+		{
+			name:      "flow collection",
+			variables: map[string]string{"value": "[abc, {def: 1}]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variables := map[string]string{"timeout": "5s", "path": "users"}
+			for name, value := range tt.variables {
+				variables[name] = value
+			}
+			testSpec, scenario, err := NewTestDefinitionFromBytes([]byte(replacedScenario), NewVariablesReplacer(variables))
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, 5*time.Second, testSpec.Timeout)
+			request := scenario.Steps[0].Request
+			assert.Equal(t, "http://localhost/users", request.URL)
+			assert.Equal(t, tt.variables["value"], request.Headers[0].Value)
+			assert.Equal(t, tt.variables["value"], request.Headers[1].Value)
+			assert.Equal(t, "value="+tt.variables["value"]+"\n", request.Body)
+		})
+	}
+}
+
+func TestNewTestDefinitionFromBytesSpecVariables(t *testing.T) {
+	data := []byte(`version: v1
+type: http
+variables:
+  - name: host
+    value: http://localhost
+  - name: token
+    value: ${secrets.token}
+steps:
+  - name: step
+    request:
+      method: GET
+      url: ${variables.host}/${variables.path}
+      headers:
+        - name: Authorization
+          value: Bearer ${variables.token}
+`)
+	_, scenario, err := NewTestDefinitionFromBytes(data,
+		NewVariablesReplacer(map[string]string{"path": "a: b"}),
+		NewSecretsReplacer(map[string]string{"token": "abc #def"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/a: b", scenario.Steps[0].Request.URL)
+	assert.Equal(t, "Bearer abc #def", scenario.Steps[0].Request.Headers[0].Value)
+}
+
+func TestNewTestDefinitionFromBytesSecrets(t *testing.T) {
+	data := []byte(`version: v1
+type: http
+steps:
+  - name: step
+    request:
+      method: POST
+      url: http://localhost
+      headers:
+        - name: Authorization
+          value: Bearer ${secrets.token}
+      json:
+        password: ${secrets.password}
+`)
+	// secrets which contain placeholders are not replaced again.
+	for _, secret := range []string{"abc #def", "abc: def", `"abc'`, "abc\ndef", "- abc", "{abc}", "pa${ss", "${uuid()}", "${variables.host}"} {
+		t.Run(secret, func(t *testing.T) {
+			_, scenario, err := NewTestDefinitionFromBytes(data, NewSecretsReplacer(map[string]string{"token": secret, "password": secret}))
+			assert.NoError(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "Bearer "+secret, scenario.Steps[0].Request.Headers[0].Value)
+			password, err := json.Marshal(map[string]string{"password": secret})
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(password), string(scenario.Steps[0].Request.JSON))
+		})
+	}
+}
+
+func TestNewTestDefinitionFromBytesFunctionsOfVariables(t *testing.T) {
+	data := []byte(`version: v1
+type: http
+variables:
+  - name: user
+    value: alice
+  - name: email
+    value: ${variables.user}@example.com
+steps:
+  - name: step
+    request:
+      method: GET
+      url: http://localhost/${upper(${variables.email})}
+      headers:
+        - name: Authorization
+          value: Basic ${base64("${variables.user}:${secrets.password}")}
+`)
+	_, scenario, err := NewTestDefinitionFromBytes(data, replacer.NewFuncReplacer(), NewSecretsReplacer(map[string]string{"password": "${uuid()}"}))
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/ALICE@EXAMPLE.COM", scenario.Steps[0].Request.URL)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("alice:${uuid()}")), scenario.Steps[0].Request.Headers[0].Value)
+}
+
+func TestNewTestDefinitionFromBytesRuntimeFunctionCall(t *testing.T) {
+	data := []byte(`version: v1
+type: http
+steps:
+  - name: step
+    request:
+      method: GET
+      url: http://localhost
+      headers:
+        - name: Authorization
+          value: Bearer ${upper(${vars.token})}
+`)
+	_, _, err := NewTestDefinitionFromBytes(data, replacer.NewFuncReplacer())
+	assert.Equal(t, ErrRuntimeFunctionCall{Name: "upper"}, err)
+	assert.EqualError(t, err, "function upper can not be called with vars or steps arguments")
+}
+
+func TestNewTestDefinitionFromBytesUndefinedSecret(t *testing.T) {
+	data := []byte(`version: v1
+type: http
+steps:
+  - name: step
+    request:
+      method: GET
+      url: http://localhost
+      headers:
+        - name: Authorization
+          value: Bearer ${secrets.token}
+`)
+	_, _, err := NewTestDefinitionFromBytes(data, NewSecretsReplacer(map[string]string{"password": "abc"}))
+	assert.Equal(t, ErrUndefinedSecret{Name: "token"}, err)
+	assert.EqualError(t, err, "secret token is not defined")
+
+	_, _, err = NewTestDefinitionFromBytes(data)
+	assert.Equal(t, ErrUndefinedSecret{Name: "token"}, err)
+}
@@ -0,0 +1,137 @@
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaDefs    = "#/$defs/"
+	placeholderDef    = "Placeholder"
+)
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of the YAML format of scenarios, such that
+// editors can complete and validate scenario files. The schema is generated from the model, unknown
+// fields are not allowed. Fields which are not strings also accept placeholders, which are replaced
+// before the scenario is decoded, e.g. timeout: ${variables.timeout}.
+func JSONSchema() ([]byte, error) {
+	g := &schemaGenerator{
+		defs: map[string]any{
+			placeholderDef: map[string]any{"type": "string", "pattern": `\$\{.+\}`},
+		},
+	}
+	schema := g.object(reflect.TypeOf(scenarioDocument{}))
+	// the fields of the document are optional, as in the documents validated by Lint.
+	delete(schema, "required")
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = "Inquiry scenario"
+	schema["$defs"] = g.defs
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// schemaGenerator generates the schemas of types, structs are added as definitions.
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if schema, ok := typeSchemas()[t]; ok {
+		return schema
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.structSchema(t)
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	}
+	schema, ok := kindSchemas()[t.Kind()]
+	if !ok {
+		return map[string]any{}
+	}
+	return schema
+}
+
+// typeSchemas returns the schemas of types which are not derived from their kind.
+func typeSchemas() map[reflect.Type]map[string]any {
+	return map[reflect.Type]map[string]any{
+		reflect.TypeOf(JSON{}): {},
+		reflect.TypeOf(time.Duration(0)): withPlaceholder(map[string]any{
+			"type":    "string",
+			"pattern": `^[-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+)$`,
+		}),
+		reflect.TypeOf(assertionMethod("")): {"enum": assertionMethods()},
+		reflect.TypeOf(testType("")):        {"enum": []testType{TestTypeHTTP}},
+	}
+}
+
+// kindSchemas returns the schemas of scalars by their kind.
+func kindSchemas() map[reflect.Kind]map[string]any {
+	return map[reflect.Kind]map[string]any{
+		// unquoted numbers and booleans are decoded into strings as well, e.g. value: 200.
+		reflect.String:  {"type": []string{"string", "number", "boolean"}},
+		reflect.Bool:    withPlaceholder(map[string]any{"type": "boolean"}),
+		reflect.Int:     withPlaceholder(map[string]any{"type": "integer"}),
+		reflect.Float64: withPlaceholder(map[string]any{"type": "number"}),
+	}
+}
+
+func withPlaceholder(schema map[string]any) map[string]any {
+	return map[string]any{"anyOf": []any{schema, map[string]any{"$ref": jsonSchemaDefs + placeholderDef}}}
+}
+
+// structSchema returns a reference to the definition of a struct, steps are either steps or use steps.
+func (g *schemaGenerator) structSchema(t reflect.Type) map[string]any {
+	if t == reflect.TypeOf(Step{}) {
+		return map[string]any{"anyOf": []any{g.ref(t), g.ref(reflect.TypeOf(useStep{}))}}
+	}
+	return g.ref(t)
+}
+
+func (g *schemaGenerator) ref(t reflect.Type) map[string]any {
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	if _, ok := g.defs[name]; !ok {
+		// the definition is added before it is generated, such that recursive types terminate.
+		g.defs[name] = nil
+		g.defs[name] = g.object(t)
+	}
+	return map[string]any{"$ref": jsonSchemaDefs + name}
+}
+
+// object returns the schema of a struct, fields which are not omitted if empty are required.
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for name, field := range yamlFields(t) {
+		properties[name] = g.schema(field.Type)
+		if !field.OmitEmpty {
+			required = append(required, name)
+		}
+	}
+	// the request of steps is omitted when steps are marshalled without request, but required by Lint.
+	if t == reflect.TypeOf(Step{}) {
+		required = append(required, "request")
+	}
+	slices.Sort(required)
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
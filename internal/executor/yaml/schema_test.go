@@ -0,0 +1,125 @@
+package yaml
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/inquiryproj/inquiry/internal/executor/jsonschema"
+)
+
+func TestJSONSchemaIsPublished(t *testing.T) {
+	schema, err := JSONSchema()
+	assert.NoError(t, err)
+
+	published, err := os.ReadFile("../../../api/scenario-schema.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(schema), string(published), "api/scenario-schema.json is outdated, run make scenario-schema")
+}
+
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		name              string
+		scenario          string
+		expectViolations  bool
+		expectedViolation string
+	}{
+		{
+			name: "valid scenario",
+			scenario: `version: v1
+type: http
+timeout: ${variables.timeout}
+variables:
+  - name: timeout
+    value: 5s
+setup:
+  - use: login
+    params:
+      username: admin
+steps:
+  - name: create_project
+    timeout: 2s
+    skip: false
+    request:
+      method: POST
+      url: http://localhost:3000/v1/projects
+      headers:
+        - name: Authorization
+          value: Bearer ${vars.token}
+      json:
+        name: project
+        tags: [a, b]
+    validation:
+      status:
+        assertion: equal
+        value: 201
+      body:
+        - key: name
+          assertion: in
+          values: [project]
+    retry:
+      attempts: ${variables.attempts}
+      backoff: 1.5
+      statuses: [502, 503]
+    capture:
+      - name: id
+        body: id
+`,
+		},
+		{
+			name:              "unknown field",
+			scenario:          "steps:\n  - name: a\n    reqest: {}\n",
+			expectViolations:  true,
+			expectedViolation: "/steps/0",
+		},
+		{
+			name:              "unknown assertion method",
+			scenario:          "steps:\n  - name: a\n    request: {method: GET, url: x}\n    validation:\n      status: {assertion: equals}\n",
+			expectViolations:  true,
+			expectedViolation: "/steps/0",
+		},
+		{
+			name:              "invalid duration",
+			scenario:          "timeout: 5 seconds\nsteps: []\n",
+			expectViolations:  true,
+			expectedViolation: "/timeout",
+		},
+		{
+			name:              "invalid type",
+			scenario:          "steps:\n  - name: a\n    request: {method: GET, url: x}\n    retry: {attempts: many}\n",
+			expectViolations:  true,
+			expectedViolation: "/steps/0",
+		},
+	}
+
+	schemaData, err := JSONSchema()
+	assert.NoError(t, err)
+	schema, err := jsonschema.Compile(schemaData, nil)
+	assert.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document any
+			err := yaml.Unmarshal([]byte(tt.scenario), &document)
+			assert.NoError(t, err)
+			data, err := json.Marshal(document)
+			assert.NoError(t, err)
+
+			violations, err := schema.Validate(data)
+			assert.NoError(t, err)
+			if !tt.expectViolations {
+				assert.Empty(t, violations)
+				return
+			}
+			assert.NotEmpty(t, violations)
+			pointers := []string{}
+			for _, violation := range violations {
+				pointers = append(pointers, violation.Pointer)
+			}
+			assert.Contains(t, pointers, tt.expectedViolation)
+		})
+	}
+}
@@ -0,0 +1,372 @@
+package yaml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/inquiryproj/inquiry/internal/executor/condition"
+)
+
+// ErrInvalidAssertion is an error for when an assertion of a step
+// uses an unknown assertion method or an invalid value.
+type ErrInvalidAssertion struct {
+	StepName  string
+	Assertion assertionMethod
+	Msg       string
+}
+
+func (e ErrInvalidAssertion) Error() string {
+	return fmt.Sprintf("invalid assertion \"%s\" for step \"%s\": %s", e.Assertion, e.StepName, e.Msg)
+}
+
+// ErrInvalidCapture is an error for when a capture of a step is invalid.
+type ErrInvalidCapture struct {
+	StepName string
+	Name     string
+	Msg      string
+}
+
+func (e ErrInvalidCapture) Error() string {
+	return fmt.Sprintf("invalid capture \"%s\" for step \"%s\": %s", e.Name, e.StepName, e.Msg)
+}
+
+// ErrInvalidCondition is an error for when the if condition of a step can not be parsed.
+type ErrInvalidCondition struct {
+	StepName string
+	Msg      string
+}
+
+func (e ErrInvalidCondition) Error() string {
+	return fmt.Sprintf("invalid condition for step \"%s\": %s", e.StepName, e.Msg)
+}
+
+// ErrInvalidSchema is an error for when the JSON Schema of a step is invalid.
+type ErrInvalidSchema struct {
+	StepName string
+	Msg      string
+}
+
+func (e ErrInvalidSchema) Error() string {
+	return fmt.Sprintf("invalid schema for step \"%s\": %s", e.StepName, e.Msg)
+}
+
+// ErrInvalidRequest is an error for when the request of a step is invalid.
+type ErrInvalidRequest struct {
+	StepName string
+	Msg      string
+}
+
+func (e ErrInvalidRequest) Error() string {
+	return fmt.Sprintf("invalid request for step \"%s\": %s", e.StepName, e.Msg)
+}
+
+// ErrInvalidRetry is an error for when the retry policy of a step is invalid.
+type ErrInvalidRetry struct {
+	StepName string
+	Msg      string
+}
+
+func (e ErrInvalidRetry) Error() string {
+	return fmt.Sprintf("invalid retry for step \"%s\": %s", e.StepName, e.Msg)
+}
+
+func (s Scenario) validate() error {
+	if s.Auth != nil {
+		if msg := s.Auth.validate(); msg != "" {
+			return ErrInvalidAuth{Msg: msg}
+		}
+	}
+	for _, step := range s.allSteps() {
+		err := step.validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Step) validate() error {
+	if s.If != "" {
+		_, err := condition.Parse(s.If)
+		if err != nil {
+			return ErrInvalidCondition{
+				StepName: s.Name,
+				Msg:      err.Error(),
+			}
+		}
+	}
+	if s.Request != nil {
+		msg := s.Request.validate()
+		if msg != "" {
+			return ErrInvalidRequest{
+				StepName: s.Name,
+				Msg:      msg,
+			}
+		}
+	}
+	if s.Auth != nil {
+		if msg := s.Auth.validate(); msg != "" {
+			return ErrInvalidAuth{StepName: s.Name, Msg: msg}
+		}
+	}
+	for _, capture := range s.Capture {
+		msg := capture.validate()
+		if msg != "" {
+			return ErrInvalidCapture{
+				StepName: s.Name,
+				Name:     capture.Name,
+				Msg:      msg,
+			}
+		}
+	}
+	if msg := s.validateRetry(); msg != "" {
+		return ErrInvalidRetry{
+			StepName: s.Name,
+			Msg:      msg,
+		}
+	}
+	assertions := append(s.Validation.assertions(), s.Until.assertions()...)
+	for _, assertion := range assertions {
+		msg := assertion.validate()
+		if msg != "" {
+			return ErrInvalidAssertion{
+				StepName:  s.Name,
+				Assertion: assertion.Assertion,
+				Msg:       msg,
+			}
+		}
+	}
+	for _, v := range []*Validation{s.Validation, s.Until} {
+		err := v.validate(s.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate validates the latency and schema of the validation, the
+// assertions of the validation are validated separately.
+func (v *Validation) validate(stepName string) error {
+	if v == nil {
+		return nil
+	}
+	if v.Latency != nil {
+		msg := v.Latency.validateDuration()
+		if msg != "" {
+			return ErrInvalidAssertion{
+				StepName:  stepName,
+				Assertion: v.Latency.Assertion,
+				Msg:       msg,
+			}
+		}
+	}
+	for _, cookie := range v.Cookies {
+		if cookie.Key == "" {
+			return ErrInvalidAssertion{
+				StepName:  stepName,
+				Assertion: cookie.Assertion,
+				Msg:       "cookie assertions require the name of the cookie as key",
+			}
+		}
+	}
+	if v.Schema != nil && (v.Schema.Ref == "") == (len(v.Schema.Inline) == 0) {
+		return ErrInvalidSchema{
+			StepName: stepName,
+			Msg:      "exactly one of ref or inline must be set",
+		}
+	}
+	return nil
+}
+
+func (v *Validation) assertions() []*Assertion {
+	assertions := []*Assertion{}
+	if v == nil {
+		return assertions
+	}
+	if v.Status != nil {
+		assertions = append(assertions, v.Status)
+	}
+	assertions = append(assertions, v.Headers...)
+	assertions = append(assertions, v.Cookies...)
+	if v.GraphQL != nil {
+		assertions = append(assertions, v.GraphQL.Data...)
+	}
+	return append(assertions, v.Body...)
+}
+
+// validate returns a description of what is wrong with the body of the request,
+// or an empty string if the request is valid.
+func (r Request) validate() string {
+	if r.bodies() > 1 {
+		return "only one of body, json, form, multipart, raw or graphql can be set"
+	}
+	if r.GraphQL != nil && r.GraphQL.Query == "" {
+		return "graphql requests require a query"
+	}
+	for _, part := range r.Multipart {
+		msg := part.validate()
+		if msg != "" {
+			return msg
+		}
+	}
+	return ""
+}
+
+// bodies returns the number of bodies set on the request.
+func (r Request) bodies() int {
+	bodies := 0
+	for _, isSet := range []bool{r.Body != "", len(r.JSON) > 0, len(r.Form) > 0, len(r.Multipart) > 0, r.Raw != nil, r.GraphQL != nil} {
+		if isSet {
+			bodies++
+		}
+	}
+	return bodies
+}
+
+// validate returns a description of what is wrong with the multipart part,
+// or an empty string if the part is valid.
+func (p Part) validate() string {
+	if p.Name == "" {
+		return "multipart parts must have a name"
+	}
+	sources := 0
+	for _, source := range []string{p.Value, p.File, p.Base64} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return fmt.Sprintf("multipart part %s must have only one of value, file or base64", p.Name)
+	}
+	if _, err := base64.StdEncoding.DecodeString(p.Base64); err != nil {
+		return fmt.Sprintf("multipart part %s has invalid base64 content: %s", p.Name, err)
+	}
+	return ""
+}
+
+// validateRetry returns a description of what is wrong with the retry policy of the step,
+// or an empty string if the retry policy is valid. Steps with an until assertion are only
+// polled with a retry policy.
+func (s Step) validateRetry() string {
+	switch {
+	case s.Retry != nil:
+		return s.Retry.validate()
+	case s.Until != nil:
+		return "until requires a retry policy with attempts or max_duration"
+	}
+	return ""
+}
+
+// validate returns a description of what is wrong with the retry policy,
+// or an empty string if the retry policy is valid.
+func (r Retry) validate() string {
+	switch {
+	case r.Attempts == 0 && r.MaxDuration == 0:
+		return "one of attempts or max_duration must be set"
+	case r.Attempts < 0:
+		return "attempts must not be negative"
+	case r.Timeout < 0 || r.MaxDelay < 0 || r.MaxDuration < 0:
+		return "durations must not be negative"
+	case r.Backoff != 0 && r.Backoff < 1:
+		return fmt.Sprintf("backoff %v must be at least 1", r.Backoff)
+	case r.Jitter < 0 || r.Jitter > 1:
+		return fmt.Sprintf("jitter %v must be between 0 and 1", r.Jitter)
+	}
+	for _, status := range r.Statuses {
+		if status < 100 || status > 599 {
+			return fmt.Sprintf("status %d is not a valid HTTP status", status)
+		}
+	}
+	return ""
+}
+
+// validate returns a description of what is wrong with the assertion,
+// or an empty string if the assertion is valid.
+func (a Assertion) validate() string {
+	switch a.Assertion {
+	case AssertionMethodEqual, AssertionMethodNotEqual, AssertionMethodNotEmpty,
+		AssertionMethodContains, AssertionMethodStartsWith, AssertionMethodEndsWith,
+		AssertionMethodExists, AssertionMethodNotExists:
+		return ""
+	case AssertionMethodRegex:
+		_, err := regexp.Compile(a.Value)
+		if err != nil {
+			return fmt.Sprintf("value %s is not a valid regular expression: %s", a.Value, err)
+		}
+	case AssertionMethodGreaterThan, AssertionMethodGreaterThanOrEqual,
+		AssertionMethodLessThan, AssertionMethodLessThanOrEqual:
+		_, err := strconv.ParseFloat(a.Value, 64)
+		if err != nil {
+			return fmt.Sprintf("value %s is not a number", a.Value)
+		}
+	case AssertionMethodIn:
+		if len(a.Values) == 0 {
+			return "values must contain at least one value"
+		}
+	case AssertionMethodLength:
+		length, err := strconv.Atoi(a.Value)
+		if err != nil || length < 0 {
+			return fmt.Sprintf("value %s is not a valid length", a.Value)
+		}
+	case AssertionMethodType:
+		return validateValueType(a.Value)
+	default:
+		return "unknown assertion method"
+	}
+	return ""
+}
+
+// validateDuration returns a description of what is wrong with an assertion
+// on a duration, or an empty string if the assertion is valid.
+func (a Assertion) validateDuration() string {
+	switch a.Assertion {
+	case AssertionMethodGreaterThan, AssertionMethodGreaterThanOrEqual,
+		AssertionMethodLessThan, AssertionMethodLessThanOrEqual:
+	default:
+		return "durations can only be asserted with gt, gte, lt or lte"
+	}
+	_, err := time.ParseDuration(a.Value)
+	if err != nil {
+		return fmt.Sprintf("value %s is not a valid duration", a.Value)
+	}
+	return ""
+}
+
+func validateValueType(valueType string) string {
+	switch valueType {
+	case ValueTypeString, ValueTypeNumber, ValueTypeBool,
+		ValueTypeArray, ValueTypeObject, ValueTypeNull:
+		return ""
+	default:
+		return fmt.Sprintf("value %s is not a known type", valueType)
+	}
+}
+
+// validate returns a description of what is wrong with the capture,
+// or an empty string if the capture is valid.
+func (c Capture) validate() string {
+	if c.Name == "" {
+		return "name is required"
+	}
+	sources := 0
+	for _, isSet := range []bool{c.Body != "", c.Header != "", c.Status, c.Cookie != "", c.Data != ""} {
+		if isSet {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "only one of body, header, status, cookie or data can be set"
+	}
+	if c.Regex == "" {
+		return ""
+	}
+	_, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return fmt.Sprintf("regex %s is not a valid regular expression: %s", c.Regex, err)
+	}
+	return ""
+}
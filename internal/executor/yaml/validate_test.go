@@ -0,0 +1,203 @@
+package yaml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepValidateRetry(t *testing.T) {
+	until := &Validation{Body: []*Assertion{{Key: "state", Assertion: AssertionMethodEqual, Value: "done"}}}
+	tests := []struct {
+		name        string
+		step        Step
+		expectedErr error
+	}{
+		{
+			name: "without retry",
+			step: Step{Name: "step"},
+		},
+		{
+			name: "retry with attempts",
+			step: Step{Name: "step", Retry: &Retry{Attempts: 3}},
+		},
+		{
+			name: "retry with max duration",
+			step: Step{Name: "step", Retry: &Retry{MaxDuration: time.Minute}},
+		},
+		{
+			name: "until with retry",
+			step: Step{Name: "step", Until: until, Retry: &Retry{Attempts: 10, Timeout: time.Second}},
+		},
+		{
+			name:        "until without retry",
+			step:        Step{Name: "step", Until: until},
+			expectedErr: ErrInvalidRetry{StepName: "step", Msg: "until requires a retry policy with attempts or max_duration"},
+		},
+		{
+			name:        "until with unbounded retry",
+			step:        Step{Name: "step", Until: until, Retry: &Retry{Timeout: time.Second}},
+			expectedErr: ErrInvalidRetry{StepName: "step", Msg: "one of attempts or max_duration must be set"},
+		},
+		{
+			name:        "retry without bound",
+			step:        Step{Name: "step", Retry: &Retry{Statuses: []int{503}}},
+			expectedErr: ErrInvalidRetry{StepName: "step", Msg: "one of attempts or max_duration must be set"},
+		},
+		{
+			name:        "negative attempts",
+			step:        Step{Name: "step", Retry: &Retry{Attempts: -1}},
+			expectedErr: ErrInvalidRetry{StepName: "step", Msg: "attempts must not be negative"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, tt.step.validate())
+		})
+	}
+}
+
+func TestStepValidateRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *Request
+		expectedErr error
+	}{
+		{
+			name:    "json body",
+			request: &Request{JSON: JSON(`{"name":"test"}`)},
+		},
+		{
+			name:    "graphql",
+			request: &Request{GraphQL: &GraphQL{Query: "{ users { id } }"}},
+		},
+		{
+			name: "multipart body",
+			request: &Request{Multipart: []*Part{
+				{Name: "description", Value: "avatar"},
+				{Name: "avatar", File: "avatar.png"},
+				{Name: "data", Base64: "AAE="},
+			}},
+		},
+		{
+			name:        "several bodies",
+			request:     &Request{Body: "test", Form: map[string]string{"user": "test"}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "only one of body, json, form, multipart, raw or graphql can be set"},
+		},
+		{
+			name:        "graphql with body",
+			request:     &Request{Body: "test", GraphQL: &GraphQL{Query: "{ users { id } }"}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "only one of body, json, form, multipart, raw or graphql can be set"},
+		},
+		{
+			name:        "graphql without query",
+			request:     &Request{GraphQL: &GraphQL{OperationName: "users"}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "graphql requests require a query"},
+		},
+		{
+			name:        "part without name",
+			request:     &Request{Multipart: []*Part{{Value: "avatar"}}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "multipart parts must have a name"},
+		},
+		{
+			name:        "part with several sources",
+			request:     &Request{Multipart: []*Part{{Name: "avatar", File: "avatar.png", Base64: "AAE="}}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "multipart part avatar must have only one of value, file or base64"},
+		},
+		{
+			name:        "part with invalid base64",
+			request:     &Request{Multipart: []*Part{{Name: "avatar", Base64: "a"}}},
+			expectedErr: ErrInvalidRequest{StepName: "step", Msg: "multipart part avatar has invalid base64 content: illegal base64 data at input byte 0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := Step{Name: "step", Request: tt.request}
+			assert.Equal(t, tt.expectedErr, step.validate())
+		})
+	}
+}
+
+func TestPartResolvedFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		part     Part
+		expected string
+	}{
+		{name: "file name", part: Part{Name: "avatar", File: "files/me.png", FileName: "avatar.png"}, expected: "avatar.png"},
+		{name: "name of the attachment", part: Part{Name: "avatar", File: "files/me.png"}, expected: "me.png"},
+		{name: "name of the part", part: Part{Name: "avatar", Base64: "AAE="}, expected: "avatar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.part.ResolvedFileName())
+		})
+	}
+}
+
+func TestStepValidateCapture(t *testing.T) {
+	tests := []struct {
+		name        string
+		capture     *Capture
+		expectedErr error
+	}{
+		{
+			name:    "graphql data",
+			capture: &Capture{Name: "id", Data: "user.id"},
+		},
+		{
+			name:    "raw body with regex",
+			capture: &Capture{Name: "id", Regex: `id=(\d+)`},
+		},
+		{
+			name:        "without name",
+			capture:     &Capture{Data: "user.id"},
+			expectedErr: ErrInvalidCapture{StepName: "step", Msg: "name is required"},
+		},
+		{
+			name:        "several sources",
+			capture:     &Capture{Name: "id", Body: "data.user.id", Data: "user.id"},
+			expectedErr: ErrInvalidCapture{StepName: "step", Name: "id", Msg: "only one of body, header, status, cookie or data can be set"},
+		},
+		{
+			name:        "invalid regex",
+			capture:     &Capture{Name: "id", Header: "Location", Regex: "("},
+			expectedErr: ErrInvalidCapture{StepName: "step", Name: "id", Msg: "regex ( is not a valid regular expression: error parsing regexp: missing closing ): `(`"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := Step{Name: "step", Capture: []*Capture{tt.capture}}
+			assert.Equal(t, tt.expectedErr, step.validate())
+		})
+	}
+}
+
+func TestGraphQLBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		graphQL  GraphQL
+		expected string
+	}{
+		{
+			name:     "query",
+			graphQL:  GraphQL{Query: "{ users { id } }"},
+			expected: `{"query":"{ users { id } }"}`,
+		},
+		{
+			name: "variables and operation name",
+			graphQL: GraphQL{
+				Query:         "query user($id: ID!) { user(id: $id) { id } } query users { users { id } }",
+				Variables:     JSON(`{"id":"42"}`),
+				OperationName: "user",
+			},
+			expected: `{"query":"query user($id: ID!) { user(id: $id) { id } } query users { users { id } }","variables":{"id":"42"},"operationName":"user"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.graphQL.Body())
+		})
+	}
+}
@@ -1,14 +1,21 @@
 package executor
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
 	"github.com/inquiryproj/inquiry/internal/executor/http"
 	"github.com/inquiryproj/inquiry/internal/executor/yaml"
 )
 
 func yamlScenarioToHTTPScenario(name string, yamlScenario *yaml.Scenario) *http.Scenario {
 	return &http.Scenario{
-		Name:  name,
-		Steps: yamlStepsToHTTPSteps(yamlScenario.Steps),
+		Name:     name,
+		Auth:     yamlAuthToHTTPAuth(yamlScenario.Auth),
+		Setup:    yamlStepsToHTTPSteps(yamlScenario.Setup),
+		Steps:    yamlStepsToHTTPSteps(yamlScenario.Steps),
+		Teardown: yamlStepsToHTTPSteps(yamlScenario.Teardown),
 	}
 }
 
@@ -17,21 +24,50 @@ func yamlStepsToHTTPSteps(yamlSteps []*yaml.Step) []*http.Step {
 	for _, s := range yamlSteps {
 		steps = append(steps, &http.Step{
 			Name:       s.Name,
+			If:         s.If,
+			Skip:       s.Skip,
+			Only:       s.Only,
+			Timeout:    s.Timeout,
 			Request:    yamlRequestToHTTPRequest(s.Request),
+			Auth:       yamlAuthToHTTPAuth(s.Auth),
 			Validation: yamlValidationToHTTPValidation(s.Validation),
+			Until:      yamlValidationToHTTPValidation(s.Until),
 			Retry:      yamlRetryToHTTPRetry(s.Retry),
+			Capture:    yamlCapturesToHTTPCaptures(s.Capture),
 		})
 	}
 	return steps
 }
 
+func yamlCapturesToHTTPCaptures(yamlCaptures []*yaml.Capture) []*http.Capture {
+	captures := []*http.Capture{}
+	for _, c := range yamlCaptures {
+		captures = append(captures, &http.Capture{
+			Name:   c.Name,
+			Body:   c.Body,
+			Header: c.Header,
+			Status: c.Status,
+			Cookie: c.Cookie,
+			Data:   c.Data,
+			Regex:  c.Regex,
+		})
+	}
+	return captures
+}
+
 func yamlRetryToHTTPRetry(yamlRetry *yaml.Retry) *http.Retry {
 	if yamlRetry == nil {
 		return nil
 	}
 	return &http.Retry{
-		Attempts: yamlRetry.Attempts,
-		Timeout:  yamlRetry.Timeout,
+		Attempts:      yamlRetry.Attempts,
+		Timeout:       yamlRetry.Timeout,
+		Backoff:       yamlRetry.Backoff,
+		MaxDelay:      yamlRetry.MaxDelay,
+		Jitter:        yamlRetry.Jitter,
+		MaxDuration:   yamlRetry.MaxDuration,
+		Statuses:      yamlRetry.Statuses,
+		NetworkErrors: yamlRetry.NetworkErrors,
 	}
 }
 
@@ -39,12 +75,47 @@ func yamlRequestToHTTPRequest(yamlRequest *yaml.Request) *http.Request {
 	if yamlRequest == nil {
 		return nil
 	}
-	return &http.Request{
-		Method:  yamlRequest.Method,
-		URL:     yamlRequest.URL,
-		Headers: yamlHeadersToHTTPHeaders(yamlRequest.Headers),
-		Body:    yamlRequest.Body,
+	request := &http.Request{
+		Method:    yamlRequest.Method,
+		URL:       yamlRequest.URL,
+		Headers:   yamlHeadersToHTTPHeaders(yamlRequest.Headers),
+		Body:      yamlRequest.Body,
+		Form:      yamlRequest.Form,
+		Multipart: yamlPartsToHTTPParts(yamlRequest.Multipart),
+	}
+	switch {
+	case len(yamlRequest.JSON) > 0:
+		request.Body = string(yamlRequest.JSON)
+		request.ContentType = "application/json"
+	case yamlRequest.Raw != nil:
+		request.Body = yamlRequest.Raw.Data
+		request.ContentType = yamlRequest.Raw.ContentType
+	case yamlRequest.GraphQL != nil:
+		request.Body = yamlRequest.GraphQL.Body()
+		request.ContentType = "application/json"
 	}
+	return request
+}
+
+// yamlPartsToHTTPParts converts multipart parts, the content of inline files is decoded,
+// the content of attached files is loaded when the executor is created.
+func yamlPartsToHTTPParts(yamlParts []*yaml.Part) []*http.Part {
+	parts := []*http.Part{}
+	for _, p := range yamlParts {
+		part := &http.Part{
+			Name:        p.Name,
+			Value:       p.Value,
+			File:        p.File,
+			ContentType: p.ContentType,
+		}
+		if p.File != "" || p.Base64 != "" {
+			part.FileName = p.ResolvedFileName()
+			// the base64 content is validated when the test definition is read.
+			part.Content, _ = base64.StdEncoding.DecodeString(p.Base64)
+		}
+		parts = append(parts, part)
+	}
+	return parts
 }
 
 func yamlHeadersToHTTPHeaders(yamlHeaders []*yaml.Header) []*http.Header {
@@ -66,6 +137,31 @@ func yamlValidationToHTTPValidation(yamlValidation *yaml.Validation) *http.Valid
 		Body:    yamlAssertionsToHTTPAssertions(yamlValidation.Body),
 		Status:  yamlAssertionToHTTPAssertion(yamlValidation.Status),
 		Headers: yamlAssertionsToHTTPAssertions(yamlValidation.Headers),
+		Cookies: yamlAssertionsToHTTPAssertions(yamlValidation.Cookies),
+		Latency: yamlAssertionToHTTPAssertion(yamlValidation.Latency),
+		Schema:  yamlSchemaToHTTPSchema(yamlValidation.Schema),
+		GraphQL: yamlGraphQLValidationToHTTPGraphQLValidation(yamlValidation.GraphQL),
+	}
+}
+
+func yamlGraphQLValidationToHTTPGraphQLValidation(yamlValidation *yaml.GraphQLValidation) *http.GraphQLValidation {
+	if yamlValidation == nil {
+		return nil
+	}
+	return &http.GraphQLValidation{
+		Data:       yamlAssertionsToHTTPAssertions(yamlValidation.Data),
+		NoErrors:   yamlValidation.NoErrors,
+		ErrorCodes: yamlValidation.ErrorCodes,
+	}
+}
+
+func yamlSchemaToHTTPSchema(yamlSchema *yaml.Schema) *http.Schema {
+	if yamlSchema == nil {
+		return nil
+	}
+	return &http.Schema{
+		Ref:    yamlSchema.Ref,
+		Inline: yamlSchema.Inline,
 	}
 }
 
@@ -85,5 +181,114 @@ func yamlAssertionToHTTPAssertion(yamlAssertion *yaml.Assertion) *http.Assertion
 		Key:       yamlAssertion.Key,
 		Assertion: http.AssertionMethod(string(yamlAssertion.Assertion)),
 		Value:     yamlAssertion.Value,
+		Values:    yamlAssertion.Values,
+	}
+}
+
+// newHTTPClient creates the HTTP client for the client configuration of a scenario and the
+// client defaults, or returns nil if neither is set. Certificates are read as resources.
+func newHTTPClient(yamlClient *yaml.Client, options *options) (http.Client, error) {
+	var defaults *yaml.Client
+	if len(options.ClientDefaults) > 0 {
+		var err error
+		defaults, err = yaml.NewClientFromBytes(options.ClientDefaults)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client defaults: %w", err)
+		}
+	}
+	yamlClient = yamlClient.WithDefaults(defaults)
+	if yamlClient == nil {
+		return nil, nil
+	}
+	config := &http.ClientConfig{
+		Proxy:           yamlClient.Proxy,
+		FollowRedirects: yamlClient.FollowRedirects(),
+		MaxRedirects:    yamlClient.MaxRedirects(),
+		HTTP2:           yamlClient.HTTP2 != nil && *yamlClient.HTTP2,
+		UnixSocket:      yamlClient.UnixSocket,
+	}
+	err := readTLSResources(config, yamlClient.TLS, options.ResourceReader)
+	if err != nil {
+		return nil, err
+	}
+	client, err := http.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// readTLSResources reads the CA bundle, client certificate and key of the TLS configuration.
+func readTLSResources(config *http.ClientConfig, tls *yaml.TLS, resourceReader ResourceReader) error {
+	if tls == nil {
+		return nil
+	}
+	config.InsecureSkipVerify = tls.InsecureSkipVerify
+	resources := []struct {
+		name string
+		data *[]byte
+	}{{tls.CA, &config.RootCAs}, {tls.Cert, &config.Certificate}, {tls.Key, &config.Key}}
+	for _, resource := range resources {
+		if resource.name == "" {
+			continue
+		}
+		data, err := resourceReader(resource.name)
+		if err != nil {
+			return fmt.Errorf("failed to read TLS resource %s: %w", resource.name, err)
+		}
+		*resource.data = data
+	}
+	return nil
+}
+
+func yamlAuthToHTTPAuth(yamlAuth *yaml.Auth) *http.Auth {
+	if yamlAuth == nil {
+		return nil
+	}
+	auth := &http.Auth{
+		None:   yamlAuth.None,
+		Bearer: yamlAuth.Bearer,
+	}
+	if yamlAuth.Basic != nil {
+		auth.Basic = &http.BasicAuth{Username: yamlAuth.Basic.Username, Password: yamlAuth.Basic.Password}
+	}
+	if yamlAuth.OAuth2 != nil {
+		auth.OAuth2 = &http.OAuth2{
+			GrantType:    yamlAuth.OAuth2.GrantType,
+			TokenURL:     yamlAuth.OAuth2.TokenURL,
+			ClientID:     yamlAuth.OAuth2.ClientID,
+			ClientSecret: yamlAuth.OAuth2.ClientSecret,
+			Username:     yamlAuth.OAuth2.Username,
+			Password:     yamlAuth.OAuth2.Password,
+			Scopes:       yamlAuth.OAuth2.Scopes,
+		}
+	}
+	auth.JWT = yamlJWTToHTTPJWT(yamlAuth.JWT)
+	if yamlAuth.HMAC != nil {
+		auth.HMAC = &http.HMAC{
+			Key:             yamlAuth.HMAC.Key,
+			Algorithm:       yamlAuth.HMAC.Algorithm,
+			Encoding:        yamlAuth.HMAC.Encoding,
+			Header:          yamlAuth.HMAC.Header,
+			TimestampHeader: yamlAuth.HMAC.TimestampHeader,
+		}
+	}
+	return auth
+}
+
+func yamlJWTToHTTPJWT(yamlJWT *yaml.JWT) *http.JWT {
+	if yamlJWT == nil {
+		return nil
+	}
+	claims := map[string]any{}
+	// Claims are validated to be a JSON object.
+	_ = json.Unmarshal(yamlJWT.Claims, &claims)
+	return &http.JWT{
+		Algorithm:      yamlJWT.Algorithm,
+		Secret:         yamlJWT.Secret,
+		PrivateKeyFile: yamlJWT.PrivateKey,
+		Claims:         claims,
+		ExpiresIn:      yamlJWT.ExpiresIn,
+		Header:         yamlJWT.Header,
 	}
 }
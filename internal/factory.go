@@ -2,6 +2,8 @@
 package factory
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"os"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/inquiryproj/inquiry/internal/notifiers"
 	"github.com/inquiryproj/inquiry/internal/repository"
 	"github.com/inquiryproj/inquiry/internal/service"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
 )
 
 // App is the application.
@@ -32,6 +35,15 @@ func NewApp() (App, error) {
 
 	notifierServices := notifiersFactory(cfg.NotifiersConfig)
 
+	secretsCipher, err := secretsCipherFactory(cfg.SecretsConfig)
+	if err != nil {
+		logger.Error("failed to initialise secrets", slog.String("error", err.Error()))
+		return nil, err
+	}
+	if secretsCipher == nil {
+		logger.Warn("no secrets master key provided, secrets are disabled")
+	}
+
 	repositoryWrapper, err := repositoryFactory(cfg.RepositoryConfig, cfg.ServerConfig.APIKey)
 	if err != nil {
 		logger.Error("failed to initialise repository", slog.String("error", err.Error()))
@@ -44,13 +56,13 @@ func NewApp() (App, error) {
 		return nil, err
 	}
 
-	runsProducer, runsConsumer, err := runEventsFactory(completionsProducer, repositoryWrapper)
+	runsProducer, runsConsumer, err := runEventsFactory(completionsProducer, repositoryWrapper, secretsCipher, cfg.RunsConfig)
 	if err != nil {
 		logger.Error("failed to initialise runs events", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	serviceWrapper := serviceFactory(repositoryWrapper, runsProducer)
+	serviceWrapper := serviceFactory(repositoryWrapper, runsProducer, secretsCipher)
 
 	handlerWrapper := handlers.NewHandlerWrapper(serviceWrapper,
 		handlers.WithLogger(logger),
@@ -121,21 +133,37 @@ func completionEventsFactory(notifierServices []notifiers.Notifier, repositoryWr
 	return producer, newRunnableConsumer(consumer, "completion consumer"), nil
 }
 
-func runEventsFactory(completionsProducer events.Producer[uuid.UUID], repositoryWrapper *repository.Wrapper) (events.Producer[uuid.UUID], http.Runnable, error) {
-	runProcessor := runProcessorFactory(completionsProducer, repositoryWrapper)
+func runEventsFactory(
+	completionsProducer events.Producer[uuid.UUID],
+	repositoryWrapper *repository.Wrapper,
+	secretsCipher crypto.AESCipher,
+	runsConfig RunsConfig,
+) (events.Producer[uuid.UUID], http.Runnable, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runProcessor := runProcessorFactory(completionsProducer, repositoryWrapper,
+		runs.WithContext(ctx),
+		runs.WithRunTimeout(runsConfig.RunTimeout),
+		runs.WithSecretsCipher(secretsCipher),
+	)
 	producer, consumer, err := runs.NewProducerConsumer(runProcessor)
 	if err != nil {
+		cancel()
 		return nil, nil, err
 	}
-	return producer, newRunnableConsumer(consumer, "runs consumer"), nil
+	runnable := newRunnableConsumer(consumer, "runs consumer")
+	runnable.cancel = cancel
+	return producer, runnable, nil
 }
 
+// runnableConsumer is a consumer which can be run as component of the API.
+// If set, cancel is called on shutdown to cancel all in flight processing.
 type runnableConsumer struct {
 	events.Consumer
-	name string
+	name   string
+	cancel context.CancelFunc
 }
 
-func newRunnableConsumer(consumer events.Consumer, name string) http.Runnable {
+func newRunnableConsumer(consumer events.Consumer, name string) *runnableConsumer {
 	return &runnableConsumer{
 		Consumer: consumer,
 		name:     name,
@@ -150,16 +178,38 @@ func (r *runnableConsumer) Name() string {
 	return r.name
 }
 
+func (r *runnableConsumer) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	return r.Consumer.Shutdown(ctx)
+}
+
 func completionProcessorFactory(notifierServices []notifiers.Notifier, repositoryWrapper *repository.Wrapper) runs.Processor {
 	return completions.NewProcessor(notifierServices, repositoryWrapper.Run, repositoryWrapper.Project)
 }
 
-func runProcessorFactory(completionsProducer events.Producer[uuid.UUID], repositoryWrapper *repository.Wrapper) runs.Processor {
-	return runs.NewProcessor(completionsProducer, repositoryWrapper.Scenario, repositoryWrapper.Run)
+func runProcessorFactory(completionsProducer events.Producer[uuid.UUID], repositoryWrapper *repository.Wrapper, opts ...runs.ProcessorOpts) runs.Processor {
+	return runs.NewProcessor(completionsProducer, repositoryWrapper.Scenario, repositoryWrapper.Environment, repositoryWrapper.Secret, repositoryWrapper.Run, opts...)
+}
+
+func serviceFactory(repositoryWrapper *repository.Wrapper, runsProducer events.Producer[uuid.UUID], secretsCipher crypto.AESCipher) service.Wrapper {
+	return service.NewServiceWrapper(repositoryWrapper, runsProducer, secretsCipher)
 }
 
-func serviceFactory(repositoryWrapper *repository.Wrapper, runsProducer events.Producer[uuid.UUID]) service.Wrapper {
-	return service.NewServiceWrapper(repositoryWrapper, runsProducer)
+// secretsCipherFactory returns the cipher the values of secrets are encrypted with,
+// or nil if no master key is configured.
+func secretsCipherFactory(secretsConfig SecretsConfig) (crypto.AESCipher, error) {
+	if secretsConfig.MasterKey == "" {
+		return nil, nil
+	}
+	secretsCipher := crypto.NewAESCipher(secretsConfig.MasterKey)
+	// the master key is validated on startup rather than once the first secret is created.
+	_, err := secretsCipher.Encrypt("")
+	if err != nil {
+		return nil, fmt.Errorf("invalid secrets master key, it must be 16, 24 or 32 bytes long: %w", err)
+	}
+	return secretsCipher, nil
 }
 
 func repositoryFactory(repositoryConfig RepositoryConfig, apiKey string) (*repository.Wrapper, error) {
@@ -11,6 +11,20 @@ const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for AssertionResultType.
+const (
+	Body     AssertionResultType = "body"
+	Capture  AssertionResultType = "capture"
+	Contract AssertionResultType = "contract"
+	Cookies  AssertionResultType = "cookies"
+	Duration AssertionResultType = "duration"
+	Graphql  AssertionResultType = "graphql"
+	Headers  AssertionResultType = "headers"
+	Latency  AssertionResultType = "latency"
+	Schema   AssertionResultType = "schema"
+	Status   AssertionResultType = "status"
+)
+
 // Defines values for ProjectRunOutputState.
 const (
 	Cancelled ProjectRunOutputState = "cancelled"
@@ -22,9 +36,91 @@ const (
 
 // Defines values for ScenarioSpecType.
 const (
-	Yaml ScenarioSpecType = "yaml"
+	ScenarioSpecTypeAttachment   ScenarioSpecType = "attachment"
+	ScenarioSpecTypeClientConfig ScenarioSpecType = "client"
+	ScenarioSpecTypeCsv          ScenarioSpecType = "csv"
+	ScenarioSpecTypeFragment     ScenarioSpecType = "fragment"
+	ScenarioSpecTypeJson         ScenarioSpecType = "json"
+	ScenarioSpecTypeOpenapi      ScenarioSpecType = "openapi"
+	ScenarioSpecTypeYaml         ScenarioSpecType = "yaml"
+)
+
+// Defines values for ScenarioImportRequestFormat.
+const (
+	Har     ScenarioImportRequestFormat = "har"
+	Postman ScenarioImportRequestFormat = "postman"
+)
+
+// Defines values for ScenarioIssueSeverity.
+const (
+	Error   ScenarioIssueSeverity = "error"
+	Warning ScenarioIssueSeverity = "warning"
+)
+
+// Defines values for ScenarioValidateRequestSpecType.
+const (
+	ScenarioValidateRequestSpecTypeFragment ScenarioValidateRequestSpecType = "fragment"
+	ScenarioValidateRequestSpecTypeYaml     ScenarioValidateRequestSpecType = "yaml"
 )
 
+// AssertionResult defines model for AssertionResult.
+type AssertionResult struct {
+	Actual    string `json:"actual"`
+	Assertion string `json:"assertion"`
+	Expected  string `json:"expected"`
+
+	// Key The key of the asserted value, for schema assertions the JSON pointer of the violating value
+	Key     string              `json:"key"`
+	Message string              `json:"message"`
+	Success bool                `json:"success"`
+	Type    AssertionResultType `json:"type"`
+}
+
+// AssertionResultType defines model for AssertionResult.Type.
+type AssertionResultType string
+
+// AttemptResult defines model for AttemptResult.
+type AttemptResult struct {
+	// DelayInMs The delay before the next attempt
+	DelayInMs           int    `json:"delay_in_ms"`
+	Error               string `json:"error"`
+	RequestDurationInMs int    `json:"request_duration_in_ms"`
+
+	// Status The status of the response, 0 if the request failed
+	Status  int  `json:"status"`
+	Success bool `json:"success"`
+}
+
+// Environment defines model for Environment.
+type Environment struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID uuid.UUID `json:"project_id"`
+
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentArray defines model for EnvironmentArray.
+type EnvironmentArray = []Environment
+
+// EnvironmentCreateRequest defines model for EnvironmentCreateRequest.
+type EnvironmentCreateRequest struct {
+	Name string `json:"name"`
+
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentUpdateRequest defines model for EnvironmentUpdateRequest.
+type EnvironmentUpdateRequest struct {
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentVariables The values of the variables of the scenarios, which override the values defined by the scenarios
+type EnvironmentVariables map[string]string
+
 // ErrMsg defines model for ErrMsg.
 type ErrMsg struct {
 	Message string `json:"message"`
@@ -41,6 +137,8 @@ type ProjectArray = []Project
 
 // ProjectRunOutput defines model for ProjectRunOutput.
 type ProjectRunOutput struct {
+	// Environment The name of the environment the scenarios ran in, empty if the run has no environment
+	Environment        string                `json:"environment"`
 	ID                 uuid.UUID             `json:"id"`
 	ProjectID          uuid.UUID             `json:"project_id"`
 	ScenarioRunDetails []ScenarioRunDetails  `json:"scenario_run_details"`
@@ -56,6 +154,8 @@ type ProjectRunOutputArray = []ProjectRunOutput
 
 // ProjectRunRequest defines model for ProjectRunRequest.
 type ProjectRunRequest struct {
+	// Environment The name of the environment of the project the scenarios run in
+	Environment *string    `json:"environment,omitempty"`
 	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
 	ProjectName *string    `json:"project_name,omitempty"`
 }
@@ -84,24 +184,111 @@ type ScenarioCreateRequest struct {
 	SpecType string `json:"spec_type"`
 }
 
+// ScenarioImportRequest defines model for ScenarioImportRequest.
+type ScenarioImportRequest struct {
+	// Data A base64 encoded string of the HAR file or Postman collection
+	Data string `json:"data"`
+
+	// Format The format of the imported data, a HAR file or a Postman collection (v2.1)
+	Format ScenarioImportRequestFormat `json:"format"`
+}
+
+// ScenarioImportRequestFormat The format of the imported data, a HAR file or a Postman collection (v2.1)
+type ScenarioImportRequestFormat string
+
+// ScenarioIssue defines model for ScenarioIssue.
+type ScenarioIssue struct {
+	// Column The column of the issue, 0 if the issue applies to the whole line
+	Column  int    `json:"column"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+
+	// Severity Warnings, e.g. variables which are not declared by the scenario but may be provided by an environment, do not prevent creating the scenario
+	Severity ScenarioIssueSeverity `json:"severity"`
+}
+
+// ScenarioIssueSeverity Warnings, e.g. variables which are not declared by the scenario but may be provided by an environment, do not prevent creating the scenario
+type ScenarioIssueSeverity string
+
 // ScenarioRunDetails defines model for ScenarioRunDetails.
 type ScenarioRunDetails struct {
-	Assertions   int              `json:"assertions"`
-	DurationInMs int              `json:"duration_in_ms"`
-	Name         string           `json:"name"`
-	Steps        []StepRunDetails `json:"steps"`
-	Success      bool             `json:"success"`
+	// AssertionResults The assertions on the scenario as a whole, such as its maximum duration
+	AssertionResults []AssertionResult `json:"assertion_results"`
+	Assertions       int               `json:"assertions"`
+	DurationInMs     int               `json:"duration_in_ms"`
+	Name             string            `json:"name"`
+	SetupSteps       []StepRunDetails  `json:"setup_steps"`
+	Steps            []StepRunDetails  `json:"steps"`
+
+	// SubScenarios The executions of a scenario with a matrix, one for every row of the matrix
+	SubScenarios  []ScenarioRunDetails `json:"sub_scenarios"`
+	Success       bool                 `json:"success"`
+	TeardownSteps []StepRunDetails     `json:"teardown_steps"`
+
+	// TimedOut Whether the scenario was aborted, because its timeout or the timeout of the run was exceeded
+	TimedOut bool `json:"timed_out"`
+}
+
+// ScenarioValidateRequest defines model for ScenarioValidateRequest.
+type ScenarioValidateRequest struct {
+	// Spec A base64 encoded string of the spec
+	Spec string `json:"spec"`
+
+	// SpecType The type of the spec, a scenario or a fragment of reusable steps
+	SpecType *ScenarioValidateRequestSpecType `json:"spec_type,omitempty"`
+}
+
+// ScenarioValidateRequestSpecType The type of the spec, a scenario or a fragment of reusable steps
+type ScenarioValidateRequestSpecType string
+
+// ScenarioValidation defines model for ScenarioValidation.
+type ScenarioValidation struct {
+	Issues []ScenarioIssue `json:"issues"`
+
+	// Valid Whether the spec has no issues other than warnings
+	Valid bool `json:"valid"`
+}
+
+// Secret defines model for Secret.
+type Secret struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// SecretArray defines model for SecretArray.
+type SecretArray = []Secret
+
+// SecretCreateRequest defines model for SecretCreateRequest.
+type SecretCreateRequest struct {
+	Name string `json:"name"`
+
+	// Value The plaintext value of the secret, which is stored encrypted and never returned
+	Value string `json:"value"`
+}
+
+// SecretUpdateRequest defines model for SecretUpdateRequest.
+type SecretUpdateRequest struct {
+	// Value The plaintext value of the secret, which is stored encrypted and never returned
+	Value string `json:"value"`
 }
 
 // StepRunDetails defines model for StepRunDetails.
 type StepRunDetails struct {
-	Assertions          int    `json:"assertions"`
-	DurationInMs        int    `json:"duration_in_ms"`
-	Name                string `json:"name"`
-	RequestDurationInMs int    `json:"request_duration_in_ms"`
-	Retries             int    `json:"retries"`
-	Success             bool   `json:"success"`
-	URL                 string `json:"url"`
+	AssertionResults    []AssertionResult `json:"assertion_results"`
+	Assertions          int               `json:"assertions"`
+	Attempts            []AttemptResult   `json:"attempts"`
+	DurationInMs        int               `json:"duration_in_ms"`
+	Name                string            `json:"name"`
+	RequestDurationInMs int               `json:"request_duration_in_ms"`
+
+	// Retries The number of retries used, excluding the first attempt
+	Retries int `json:"retries"`
+
+	// Skipped Whether the step was skipped, skipped steps are neither successful nor failed
+	Skipped bool   `json:"skipped"`
+	Success bool   `json:"success"`
+	URL     string `json:"url"`
 }
 
 // ListProjectsParams defines parameters for ListProjects.
@@ -122,6 +309,15 @@ type ListRunsForProjectParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// ListEnvironmentsForProjectParams defines parameters for ListEnvironmentsForProject.
+type ListEnvironmentsForProjectParams struct {
+	// Limit The number of environments to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of environments to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListScenariosForProjectParams defines parameters for ListScenariosForProject.
 type ListScenariosForProjectParams struct {
 	// Limit The number of scenarios to return
@@ -131,11 +327,38 @@ type ListScenariosForProjectParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// ListSecretsForProjectParams defines parameters for ListSecretsForProject.
+type ListSecretsForProjectParams struct {
+	// Limit The number of secrets to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of secrets to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = Project
 
 // RunProjectJSONRequestBody defines body for RunProject for application/json ContentType.
 type RunProjectJSONRequestBody = ProjectRunRequest
 
+// CreateEnvironmentJSONRequestBody defines body for CreateEnvironment for application/json ContentType.
+type CreateEnvironmentJSONRequestBody = EnvironmentCreateRequest
+
+// UpdateEnvironmentJSONRequestBody defines body for UpdateEnvironment for application/json ContentType.
+type UpdateEnvironmentJSONRequestBody = EnvironmentUpdateRequest
+
 // CreateScenarioJSONRequestBody defines body for CreateScenario for application/json ContentType.
 type CreateScenarioJSONRequestBody = ScenarioCreateRequest
+
+// ImportScenariosJSONRequestBody defines body for ImportScenarios for application/json ContentType.
+type ImportScenariosJSONRequestBody = ScenarioImportRequest
+
+// CreateSecretJSONRequestBody defines body for CreateSecret for application/json ContentType.
+type CreateSecretJSONRequestBody = SecretCreateRequest
+
+// UpdateSecretJSONRequestBody defines body for UpdateSecret for application/json ContentType.
+type UpdateSecretJSONRequestBody = SecretUpdateRequest
+
+// ValidateScenarioJSONRequestBody defines body for ValidateScenario for application/json ContentType.
+type ValidateScenarioJSONRequestBody = ScenarioValidateRequest
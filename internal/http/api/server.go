@@ -27,11 +27,44 @@ type ServerInterface interface {
 	// (GET /v1/projects/{id}/runs)
 	ListRunsForProject(ctx echo.Context, id uuid.UUID, params ListRunsForProjectParams) error
 
+	// (GET /v1/projects/{project_id}/environments)
+	ListEnvironmentsForProject(ctx echo.Context, projectId uuid.UUID, params ListEnvironmentsForProjectParams) error
+
+	// (POST /v1/projects/{project_id}/environments)
+	CreateEnvironment(ctx echo.Context, projectId uuid.UUID) error
+
+	// (DELETE /v1/projects/{project_id}/environments/{name})
+	DeleteEnvironment(ctx echo.Context, projectId uuid.UUID, name string) error
+
+	// (PUT /v1/projects/{project_id}/environments/{name})
+	UpdateEnvironment(ctx echo.Context, projectId uuid.UUID, name string) error
+
 	// (GET /v1/projects/{project_id}/scenarios)
 	ListScenariosForProject(ctx echo.Context, projectId uuid.UUID, params ListScenariosForProjectParams) error
 
 	// (POST /v1/projects/{project_id}/scenarios)
 	CreateScenario(ctx echo.Context, projectId uuid.UUID) error
+
+	// (POST /v1/projects/{project_id}/scenarios/import)
+	ImportScenarios(ctx echo.Context, projectId uuid.UUID) error
+
+	// (GET /v1/projects/{project_id}/secrets)
+	ListSecretsForProject(ctx echo.Context, projectId uuid.UUID, params ListSecretsForProjectParams) error
+
+	// (POST /v1/projects/{project_id}/secrets)
+	CreateSecret(ctx echo.Context, projectId uuid.UUID) error
+
+	// (DELETE /v1/projects/{project_id}/secrets/{name})
+	DeleteSecret(ctx echo.Context, projectId uuid.UUID, name string) error
+
+	// (PUT /v1/projects/{project_id}/secrets/{name})
+	UpdateSecret(ctx echo.Context, projectId uuid.UUID, name string) error
+
+	// (GET /v1/scenarios/schema)
+	GetScenarioSchema(ctx echo.Context) error
+
+	// (POST /v1/scenarios/validate)
+	ValidateScenario(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -122,6 +155,110 @@ func (w *ServerInterfaceWrapper) ListRunsForProject(ctx echo.Context) error {
 	return err
 }
 
+// ListEnvironmentsForProject converts echo context to params.
+func (w *ServerInterfaceWrapper) ListEnvironmentsForProject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListEnvironmentsForProjectParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListEnvironmentsForProject(ctx, projectId, params)
+	return err
+}
+
+// CreateEnvironment converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateEnvironment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateEnvironment(ctx, projectId)
+	return err
+}
+
+// DeleteEnvironment converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteEnvironment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteEnvironment(ctx, projectId, name)
+	return err
+}
+
+// UpdateEnvironment converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateEnvironment(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateEnvironment(ctx, projectId, name)
+	return err
+}
+
 // ListScenariosForProject converts echo context to params.
 func (w *ServerInterfaceWrapper) ListScenariosForProject(ctx echo.Context) error {
 	var err error
@@ -174,6 +311,150 @@ func (w *ServerInterfaceWrapper) CreateScenario(ctx echo.Context) error {
 	return err
 }
 
+// ImportScenarios converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportScenarios(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ImportScenarios(ctx, projectId)
+	return err
+}
+
+// ListSecretsForProject converts echo context to params.
+func (w *ServerInterfaceWrapper) ListSecretsForProject(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSecretsForProjectParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListSecretsForProject(ctx, projectId, params)
+	return err
+}
+
+// CreateSecret converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateSecret(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateSecret(ctx, projectId)
+	return err
+}
+
+// DeleteSecret converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteSecret(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteSecret(ctx, projectId, name)
+	return err
+}
+
+// UpdateSecret converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateSecret(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "project_id" -------------
+	var projectId uuid.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "project_id", runtime.ParamLocationPath, ctx.Param("project_id"), &projectId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter project_id: %s", err))
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "name", runtime.ParamLocationPath, ctx.Param("name"), &name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateSecret(ctx, projectId, name)
+	return err
+}
+
+// GetScenarioSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) GetScenarioSchema(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetScenarioSchema(ctx)
+	return err
+}
+
+// ValidateScenario converts echo context to params.
+func (w *ServerInterfaceWrapper) ValidateScenario(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ValidateScenario(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -206,7 +487,18 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/v1/projects", wrapper.CreateProject)
 	router.POST(baseURL+"/v1/projects/run", wrapper.RunProject)
 	router.GET(baseURL+"/v1/projects/:id/runs", wrapper.ListRunsForProject)
+	router.GET(baseURL+"/v1/projects/:project_id/environments", wrapper.ListEnvironmentsForProject)
+	router.POST(baseURL+"/v1/projects/:project_id/environments", wrapper.CreateEnvironment)
+	router.DELETE(baseURL+"/v1/projects/:project_id/environments/:name", wrapper.DeleteEnvironment)
+	router.PUT(baseURL+"/v1/projects/:project_id/environments/:name", wrapper.UpdateEnvironment)
 	router.GET(baseURL+"/v1/projects/:project_id/scenarios", wrapper.ListScenariosForProject)
 	router.POST(baseURL+"/v1/projects/:project_id/scenarios", wrapper.CreateScenario)
+	router.POST(baseURL+"/v1/projects/:project_id/scenarios/import", wrapper.ImportScenarios)
+	router.GET(baseURL+"/v1/projects/:project_id/secrets", wrapper.ListSecretsForProject)
+	router.POST(baseURL+"/v1/projects/:project_id/secrets", wrapper.CreateSecret)
+	router.DELETE(baseURL+"/v1/projects/:project_id/secrets/:name", wrapper.DeleteSecret)
+	router.PUT(baseURL+"/v1/projects/:project_id/secrets/:name", wrapper.UpdateSecret)
+	router.GET(baseURL+"/v1/scenarios/schema", wrapper.GetScenarioSchema)
+	router.POST(baseURL+"/v1/scenarios/validate", wrapper.ValidateScenario)
 
 }
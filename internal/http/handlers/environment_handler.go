@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/http/api"
+	"github.com/inquiryproj/inquiry/internal/service"
+)
+
+// EnvironmentHandler handles environment requests.
+type EnvironmentHandler struct {
+	environmentService service.Environment
+	logger             *slog.Logger
+}
+
+// newEnvironmentHandler creates a new environment handler.
+func newEnvironmentHandler(environmentService service.Environment, opts ...Opts) *EnvironmentHandler {
+	options := defaultOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	return &EnvironmentHandler{
+		environmentService: environmentService,
+		logger:             options.Logger,
+	}
+}
+
+// CreateEnvironment creates an environment for a project.
+func (h *EnvironmentHandler) CreateEnvironment(ctx echo.Context, projectID uuid.UUID) error {
+	httpEnvironment := &api.CreateEnvironmentJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&httpEnvironment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid create environment payload")
+	}
+	if httpEnvironment.Name == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "please specify a name when creating an environment")
+	}
+	environment, err := h.environmentService.CreateEnvironment(ctx.Request().Context(), &app.CreateEnvironmentRequest{
+		Name:      httpEnvironment.Name,
+		Variables: variablesOrEmpty(httpEnvironment.Variables),
+		ProjectID: projectID,
+	})
+	switch {
+	case errors.Is(err, app.ErrEnvironmentAlreadyExists):
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("environment with name %s already exists for given project", httpEnvironment.Name))
+	case errors.Is(err, app.ErrProjectNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "project not found")
+	case err != nil:
+		h.logger.Error("unable to create environment", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to create environment")
+	}
+	return ctx.JSON(http.StatusCreated, appEnvironmentToHTTPEnvironment(environment))
+}
+
+// UpdateEnvironment replaces the variables of an environment of a project.
+func (h *EnvironmentHandler) UpdateEnvironment(ctx echo.Context, projectID uuid.UUID, name string) error {
+	httpEnvironment := &api.UpdateEnvironmentJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&httpEnvironment)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid update environment payload")
+	}
+	environment, err := h.environmentService.UpdateEnvironment(ctx.Request().Context(), &app.UpdateEnvironmentRequest{
+		Name:      name,
+		Variables: variablesOrEmpty(httpEnvironment.Variables),
+		ProjectID: projectID,
+	})
+	if errors.Is(err, app.ErrEnvironmentNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "environment not found")
+	} else if err != nil {
+		h.logger.Error("unable to update environment", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to update environment")
+	}
+	return ctx.JSON(http.StatusOK, appEnvironmentToHTTPEnvironment(environment))
+}
+
+// DeleteEnvironment deletes an environment of a project.
+func (h *EnvironmentHandler) DeleteEnvironment(ctx echo.Context, projectID uuid.UUID, name string) error {
+	err := h.environmentService.DeleteEnvironment(ctx.Request().Context(), &app.DeleteEnvironmentRequest{
+		Name:      name,
+		ProjectID: projectID,
+	})
+	if errors.Is(err, app.ErrEnvironmentNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "environment not found")
+	} else if err != nil {
+		h.logger.Error("unable to delete environment", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to delete environment")
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListEnvironmentsForProject lists all environments for a project.
+func (h *EnvironmentHandler) ListEnvironmentsForProject(ctx echo.Context, projectID uuid.UUID, params api.ListEnvironmentsForProjectParams) error {
+	listEnvironmentsRequest := &app.ListEnvironmentsRequest{
+		Limit:     100,
+		Offset:    0,
+		ProjectID: projectID,
+	}
+	if params.Limit != nil {
+		listEnvironmentsRequest.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		listEnvironmentsRequest.Offset = *params.Offset
+	}
+
+	environments, err := h.environmentService.ListEnvironments(ctx.Request().Context(), listEnvironmentsRequest)
+	if err != nil {
+		h.logger.Error("unable to get environments for project", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to get environments for project")
+	}
+
+	result := make([]api.Environment, len(environments))
+	for i, environment := range environments {
+		result[i] = appEnvironmentToHTTPEnvironment(environment)
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func variablesOrEmpty(variables api.EnvironmentVariables) map[string]string {
+	if variables == nil {
+		return map[string]string{}
+	}
+	return variables
+}
+
+func appEnvironmentToHTTPEnvironment(environment *app.Environment) api.Environment {
+	return api.Environment{
+		ID:        environment.ID,
+		Name:      environment.Name,
+		Variables: environment.Variables,
+		ProjectID: environment.ProjectID,
+	}
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/http/api"
+	httpMocks "github.com/inquiryproj/inquiry/internal/http/mocks"
+	serviceMocks "github.com/inquiryproj/inquiry/internal/service/mocks"
+)
+
+func TestCreateEnvironment(t *testing.T) {
+	projectID := uuid.New()
+	environmentID := uuid.New()
+	variables := map[string]string{"base_url": "https://staging.example.com"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateEnvironmentJSONRequestBody{
+					Name:      "staging",
+					Variables: variables,
+				}))
+				echoMockContext.On("JSON", http.StatusCreated, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, api.Environment{
+						ID:        environmentID,
+						ProjectID: projectID,
+						Name:      "staging",
+						Variables: variables,
+					}, args.Get(1))
+				}).Return(nil)
+				environmentServiceMock.On("CreateEnvironment", mock.Anything, &app.CreateEnvironmentRequest{
+					Name:      "staging",
+					Variables: variables,
+					ProjectID: projectID,
+				}).Return(&app.Environment{
+					ID:        environmentID,
+					ProjectID: projectID,
+					Name:      "staging",
+					Variables: variables,
+				}, nil)
+			},
+		},
+		{
+			name: "missing name",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateEnvironmentJSONRequestBody{
+					Variables: variables,
+				}))
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unable to create environment, already exists",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateEnvironmentJSONRequestBody{
+					Name: "staging",
+				}))
+				environmentServiceMock.On("CreateEnvironment", mock.Anything, &app.CreateEnvironmentRequest{
+					Name:      "staging",
+					Variables: map[string]string{},
+					ProjectID: projectID,
+				}).Return(nil, app.ErrEnvironmentAlreadyExists)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusConflict,
+		},
+		{
+			name: "unable to create environment, project not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateEnvironmentJSONRequestBody{
+					Name: "staging",
+				}))
+				environmentServiceMock.On("CreateEnvironment", mock.Anything, mock.Anything).Return(nil, app.ErrProjectNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to create environment, internal",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateEnvironmentJSONRequestBody{
+					Name: "staging",
+				}))
+				environmentServiceMock.On("CreateEnvironment", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			environmentServiceMock := serviceMocks.NewEnvironment(t)
+
+			tt.setupMocks(echoMockContext, environmentServiceMock)
+
+			environmentHandler := newEnvironmentHandler(environmentServiceMock)
+			err := environmentHandler.CreateEnvironment(echoMockContext, projectID)
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateEnvironment(t *testing.T) {
+	projectID := uuid.New()
+	environmentID := uuid.New()
+	variables := map[string]string{"base_url": "https://staging.example.com"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateEnvironmentJSONRequestBody{
+					Variables: variables,
+				}))
+				echoMockContext.On("JSON", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, api.Environment{
+						ID:        environmentID,
+						ProjectID: projectID,
+						Name:      "staging",
+						Variables: variables,
+					}, args.Get(1))
+				}).Return(nil)
+				environmentServiceMock.On("UpdateEnvironment", mock.Anything, &app.UpdateEnvironmentRequest{
+					Name:      "staging",
+					Variables: variables,
+					ProjectID: projectID,
+				}).Return(&app.Environment{
+					ID:        environmentID,
+					ProjectID: projectID,
+					Name:      "staging",
+					Variables: variables,
+				}, nil)
+			},
+		},
+		{
+			name: "environment not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateEnvironmentJSONRequestBody{
+					Variables: variables,
+				}))
+				environmentServiceMock.On("UpdateEnvironment", mock.Anything, mock.Anything).Return(nil, app.ErrEnvironmentNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to update environment, internal",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateEnvironmentJSONRequestBody{
+					Variables: variables,
+				}))
+				environmentServiceMock.On("UpdateEnvironment", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			environmentServiceMock := serviceMocks.NewEnvironment(t)
+
+			tt.setupMocks(echoMockContext, environmentServiceMock)
+
+			environmentHandler := newEnvironmentHandler(environmentServiceMock)
+			err := environmentHandler.UpdateEnvironment(echoMockContext, projectID, "staging")
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				echoMockContext.On("NoContent", http.StatusNoContent).Return(nil)
+				environmentServiceMock.On("DeleteEnvironment", mock.Anything, &app.DeleteEnvironmentRequest{
+					Name:      "staging",
+					ProjectID: projectID,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "environment not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				environmentServiceMock.On("DeleteEnvironment", mock.Anything, mock.Anything).Return(app.ErrEnvironmentNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to delete environment, internal",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				environmentServiceMock.On("DeleteEnvironment", mock.Anything, mock.Anything).Return(assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			environmentServiceMock := serviceMocks.NewEnvironment(t)
+
+			tt.setupMocks(echoMockContext, environmentServiceMock)
+
+			environmentHandler := newEnvironmentHandler(environmentServiceMock)
+			err := environmentHandler.DeleteEnvironment(echoMockContext, projectID, "staging")
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListEnvironmentsForProject(t *testing.T) {
+	projectID := uuid.New()
+	environmentID := uuid.New()
+	limit := 10
+	offset := 1
+
+	tests := []struct {
+		name          string
+		params        api.ListEnvironmentsForProjectParams
+		setupMocks    func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name:   "success",
+			params: api.ListEnvironmentsForProjectParams{Limit: &limit, Offset: &offset},
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				echoMockContext.On("JSON", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, []api.Environment{
+						{
+							ID:        environmentID,
+							ProjectID: projectID,
+							Name:      "staging",
+							Variables: map[string]string{},
+						},
+					}, args.Get(1))
+				}).Return(nil)
+				environmentServiceMock.On("ListEnvironments", mock.Anything, &app.ListEnvironmentsRequest{
+					Limit:     limit,
+					Offset:    offset,
+					ProjectID: projectID,
+				}).Return([]*app.Environment{
+					{
+						ID:        environmentID,
+						ProjectID: projectID,
+						Name:      "staging",
+						Variables: map[string]string{},
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "unable to list environments",
+			setupMocks: func(echoMockContext *httpMocks.Context, environmentServiceMock *serviceMocks.Environment) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				environmentServiceMock.On("ListEnvironments", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			environmentServiceMock := serviceMocks.NewEnvironment(t)
+
+			tt.setupMocks(echoMockContext, environmentServiceMock)
+
+			environmentHandler := newEnvironmentHandler(environmentServiceMock)
+			err := environmentHandler.ListEnvironmentsForProject(echoMockContext, projectID, tt.params)
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 var _ api.ServerInterface = &struct {
 	*ProjectHandler
 	*ScenarioHandler
+	*EnvironmentHandler
+	*SecretHandler
 	*RunHandler
 }{}
 
@@ -41,6 +43,8 @@ func WithLogger(logger *slog.Logger) Opts {
 type HandlerWrapper struct {
 	*ProjectHandler
 	*ScenarioHandler
+	*EnvironmentHandler
+	*SecretHandler
 	*RunHandler
 }
 
@@ -50,8 +54,10 @@ func NewHandlerWrapper(
 	opts ...Opts,
 ) *HandlerWrapper {
 	return &HandlerWrapper{
-		ProjectHandler:  newProjectHandler(serviceWrapper, opts...),
-		ScenarioHandler: newScenarioHandler(serviceWrapper, opts...),
-		RunHandler:      newRunHandler(serviceWrapper, opts...),
+		ProjectHandler:     newProjectHandler(serviceWrapper, opts...),
+		ScenarioHandler:    newScenarioHandler(serviceWrapper, opts...),
+		EnvironmentHandler: newEnvironmentHandler(serviceWrapper, opts...),
+		SecretHandler:      newSecretHandler(serviceWrapper, opts...),
+		RunHandler:         newRunHandler(serviceWrapper, opts...),
 	}
 }
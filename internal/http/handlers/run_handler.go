@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 
@@ -43,19 +44,28 @@ func (h *RunHandler) RunProject(ctx echo.Context) error {
 	if runProjectJSONRequestBody.ProjectID == nil && runProjectJSONRequestBody.ProjectName == nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "either project_id or project_name must be provided")
 	}
+	environment := ""
+	if runProjectJSONRequestBody.Environment != nil {
+		environment = *runProjectJSONRequestBody.Environment
+	}
 	var projectRunOutput *app.ProjectRunOutput
 	if runProjectJSONRequestBody.ProjectID != nil {
 		projectRunOutput, err = h.runnerService.RunProject(ctx.Request().Context(), &app.RunProjectRequest{
-			ProjectID: *runProjectJSONRequestBody.ProjectID,
+			ProjectID:   *runProjectJSONRequestBody.ProjectID,
+			Environment: environment,
 		})
 	} else {
 		projectRunOutput, err = h.runnerService.RunProjectByName(ctx.Request().Context(), &app.RunProjectByNameRequest{
 			ProjectName: *runProjectJSONRequestBody.ProjectName,
+			Environment: environment,
 		})
 	}
-	if errors.Is(err, app.ErrProjectNotFound) {
+	switch {
+	case errors.Is(err, app.ErrProjectNotFound):
 		return echo.NewHTTPError(http.StatusNotFound, "project not found")
-	} else if err != nil {
+	case errors.Is(err, app.ErrEnvironmentNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("environment %s not found for given project", environment))
+	case err != nil:
 		h.logger.Error("failed to run project", slog.String("error", err.Error()))
 		return echo.NewHTTPError(http.StatusInternalServerError, "unable to run project")
 	}
@@ -64,10 +74,11 @@ func (h *RunHandler) RunProject(ctx echo.Context) error {
 
 func projectRunOutputToHTTP(projectRunOutput *app.ProjectRunOutput) api.ProjectRunOutput {
 	return api.ProjectRunOutput{
-		ID:        projectRunOutput.ID,
-		ProjectID: projectRunOutput.ProjectID,
-		Success:   projectRunOutput.Success,
-		State:     api.ProjectRunOutputState(projectRunOutput.State),
+		ID:          projectRunOutput.ID,
+		ProjectID:   projectRunOutput.ProjectID,
+		Success:     projectRunOutput.Success,
+		State:       api.ProjectRunOutputState(projectRunOutput.State),
+		Environment: projectRunOutput.Environment,
 	}
 }
 
@@ -97,6 +108,7 @@ func (h *RunHandler) ListRunsForProject(ctx echo.Context, id uuid.UUID, params a
 			ProjectID:          run.ProjectID,
 			Success:            run.Success,
 			State:              api.ProjectRunOutputState(run.State),
+			Environment:        run.Environment,
 			ScenarioRunDetails: appScenarioDetailsToHTTPScenarioDetails(run.ScenarioRunDetails),
 		}
 	}
@@ -108,11 +120,16 @@ func appScenarioDetailsToHTTPScenarioDetails(scenario []*app.ScenarioRunDetails)
 	result := []api.ScenarioRunDetails{}
 	for _, detail := range scenario {
 		result = append(result, api.ScenarioRunDetails{
-			Name:         detail.Name,
-			DurationInMs: int(detail.Duration.Milliseconds()),
-			Assertions:   detail.Assertions,
-			Steps:        appStepsRunDetailsToHTTPStepRunDetails(detail.Steps),
-			Success:      detail.Success,
+			Name:             detail.Name,
+			DurationInMs:     int(detail.Duration.Milliseconds()),
+			Assertions:       detail.Assertions,
+			AssertionResults: appAssertionResultsToHTTPAssertionResults(detail.AssertionResults),
+			SetupSteps:       appStepsRunDetailsToHTTPStepRunDetails(detail.SetupSteps),
+			Steps:            appStepsRunDetailsToHTTPStepRunDetails(detail.Steps),
+			TeardownSteps:    appStepsRunDetailsToHTTPStepRunDetails(detail.TeardownSteps),
+			SubScenarios:     appScenarioDetailsToHTTPScenarioDetails(detail.SubScenarios),
+			TimedOut:         detail.TimedOut,
+			Success:          detail.Success,
 		})
 	}
 	return result
@@ -124,12 +141,45 @@ func appStepsRunDetailsToHTTPStepRunDetails(steps []*app.StepRunDetails) []api.S
 		result = append(result, api.StepRunDetails{
 			Name:                detail.Name,
 			Assertions:          detail.Assertions,
+			AssertionResults:    appAssertionResultsToHTTPAssertionResults(detail.AssertionResults),
 			URL:                 detail.URL,
 			RequestDurationInMs: int(detail.RequestDuration.Milliseconds()),
 			DurationInMs:        int(detail.Duration.Milliseconds()),
 			Retries:             detail.Retries,
+			Attempts:            appAttemptResultsToHTTPAttemptResults(detail.Attempts),
+			Skipped:             detail.Skipped,
 			Success:             detail.Success,
 		})
 	}
 	return result
 }
+
+func appAttemptResultsToHTTPAttemptResults(attemptResults []*app.AttemptResult) []api.AttemptResult {
+	result := []api.AttemptResult{}
+	for _, attemptResult := range attemptResults {
+		result = append(result, api.AttemptResult{
+			Status:              attemptResult.Status,
+			RequestDurationInMs: int(attemptResult.RequestDuration.Milliseconds()),
+			DelayInMs:           int(attemptResult.Delay.Milliseconds()),
+			Success:             attemptResult.Success,
+			Error:               attemptResult.Error,
+		})
+	}
+	return result
+}
+
+func appAssertionResultsToHTTPAssertionResults(assertionResults []*app.AssertionResult) []api.AssertionResult {
+	result := []api.AssertionResult{}
+	for _, assertionResult := range assertionResults {
+		result = append(result, api.AssertionResult{
+			Type:      api.AssertionResultType(assertionResult.Type),
+			Key:       assertionResult.Key,
+			Assertion: assertionResult.Assertion,
+			Expected:  assertionResult.Expected,
+			Actual:    assertionResult.Actual,
+			Success:   assertionResult.Success,
+			Message:   assertionResult.Message,
+		})
+	}
+	return result
+}
@@ -50,6 +50,51 @@ func TestRunProject(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name: "success by project id with environment",
+			setupMocks: func(echoMockContext *httpMocks.Context, runnerServiceMock *serviceMocks.Runner) {
+				environment := "staging"
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.RunProjectJSONRequestBody{
+					ProjectID:   &projectID,
+					Environment: &environment,
+				}))
+				echoMockContext.On("JSON", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, api.ProjectRunOutput{
+						ID:          runID,
+						ProjectID:   projectID,
+						Success:     false,
+						State:       api.Pending,
+						Environment: "staging",
+					}, args.Get(1))
+				}).Return(nil)
+				runnerServiceMock.On("RunProject", mock.Anything, &app.RunProjectRequest{
+					ProjectID:   projectID,
+					Environment: "staging",
+				}).Return(&app.ProjectRunOutput{
+					ID:          runID,
+					ProjectID:   projectID,
+					Success:     false,
+					State:       app.RunStatePending,
+					Environment: "staging",
+				}, nil)
+			},
+		},
+		{
+			name: "environment not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, runnerServiceMock *serviceMocks.Runner) {
+				environment := "staging"
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.RunProjectJSONRequestBody{
+					ProjectName: &projectName,
+					Environment: &environment,
+				}))
+				runnerServiceMock.On("RunProjectByName", mock.Anything, &app.RunProjectByNameRequest{
+					ProjectName: projectName,
+					Environment: "staging",
+				}).Return(nil, app.ErrEnvironmentNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
 		{
 			name: "unable to run project by id",
 			setupMocks: func(echoMockContext *httpMocks.Context, runnerServiceMock *serviceMocks.Runner) {
@@ -158,18 +203,63 @@ func TestListRunsForProject(t *testing.T) {
 									Name:         "Test Scenario 1",
 									DurationInMs: 1000,
 									Assertions:   42,
+									AssertionResults: []api.AssertionResult{
+										{
+											Type:      api.Duration,
+											Assertion: "lte",
+											Expected:  "2s",
+											Actual:    "1s",
+											Success:   true,
+										},
+									},
 									Steps: []api.StepRunDetails{
 										{
-											Name:                "Test Step 1",
-											Assertions:          42,
+											Name:       "Test Step 1",
+											Assertions: 42,
+											AssertionResults: []api.AssertionResult{
+												{
+													Type:      api.Status,
+													Assertion: "equal",
+													Expected:  "200",
+													Actual:    "500",
+													Success:   false,
+													Message:   "status has value 500, expected 200",
+												},
+											},
 											URL:                 "https://example.com",
 											RequestDurationInMs: 1000,
 											DurationInMs:        1000,
 											Retries:             1,
-											Success:             true,
+											Attempts: []api.AttemptResult{
+												{
+													Status:              503,
+													RequestDurationInMs: 1000,
+													DelayInMs:           1000,
+												},
+												{
+													Status:              500,
+													RequestDurationInMs: 1000,
+													Success:             true,
+												},
+											},
+											Success: true,
 										},
 									},
-									Success: true,
+									TeardownSteps: []api.StepRunDetails{
+										{
+											Name:                "Test Teardown Step 1",
+											Assertions:          0,
+											AssertionResults:    []api.AssertionResult{},
+											Attempts:            []api.AttemptResult{},
+											URL:                 "https://example.com",
+											RequestDurationInMs: 1000,
+											DurationInMs:        1000,
+											Skipped:             true,
+										},
+									},
+									SetupSteps:   []api.StepRunDetails{},
+									SubScenarios: []api.ScenarioRunDetails{},
+									Success:      true,
 								},
 							},
 						},
@@ -237,15 +327,55 @@ func dummyProjectRunOutput(projectID, runID uuid.UUID) *app.ProjectRunOutput {
 				Name:       "Test Scenario 1",
 				Duration:   time.Second,
 				Assertions: 42,
+				AssertionResults: []*app.AssertionResult{
+					{
+						Type:      "duration",
+						Assertion: "lte",
+						Expected:  "2s",
+						Actual:    "1s",
+						Success:   true,
+					},
+				},
 				Steps: []*app.StepRunDetails{
 					{
-						Name:            "Test Step 1",
-						Assertions:      42,
+						Name:       "Test Step 1",
+						Assertions: 42,
+						AssertionResults: []*app.AssertionResult{
+							{
+								Type:      "status",
+								Assertion: "equal",
+								Expected:  "200",
+								Actual:    "500",
+								Success:   false,
+								Message:   "status has value 500, expected 200",
+							},
+						},
 						URL:             "https://example.com",
 						RequestDuration: time.Second,
 						Duration:        time.Second,
 						Retries:         1,
-						Success:         true,
+						Attempts: []*app.AttemptResult{
+							{
+								Status:          503,
+								RequestDuration: time.Second,
+								Delay:           time.Second,
+							},
+							{
+								Status:          500,
+								RequestDuration: time.Second,
+								Success:         true,
+							},
+						},
+						Success: true,
+					},
+				},
+				TeardownSteps: []*app.StepRunDetails{
+					{
+						Name:            "Test Teardown Step 1",
+						URL:             "https://example.com",
+						RequestDuration: time.Second,
+						Duration:        time.Second,
+						Skipped:         true,
 					},
 				},
 				Success: true,
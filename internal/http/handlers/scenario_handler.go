@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
 	"github.com/inquiryproj/inquiry/internal/http/api"
 	"github.com/inquiryproj/inquiry/internal/service"
 )
@@ -47,17 +49,115 @@ func (h *ScenarioHandler) CreateScenario(ctx echo.Context, projectID uuid.UUID)
 		ProjectID: projectID,
 	}
 	scenario, err := h.scenarioService.CreateScenario(ctx.Request().Context(), createScenarioRequest)
+	if err != nil {
+		return h.createScenarioError(err, httpScenario.Name)
+	}
+
+	return ctx.JSON(http.StatusCreated, appScenarioToHTTPScenario(scenario))
+}
+
+// ImportScenarios imports the requests of a HAR file or a Postman collection as yaml scenarios for a project.
+func (h *ScenarioHandler) ImportScenarios(ctx echo.Context, projectID uuid.UUID) error {
+	importRequest := &api.ImportScenariosJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&importRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid import scenarios payload")
+	}
+	data, err := base64.StdEncoding.DecodeString(importRequest.Data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "data must be base64 encoded")
+	}
+	scenarios, err := h.scenarioService.ImportScenarios(ctx.Request().Context(), &app.ImportScenariosRequest{
+		ProjectID: projectID,
+		Format:    app.ImportFormat(importRequest.Format),
+		Data:      data,
+	})
+	if err != nil {
+		return h.importScenariosError(err)
+	}
+
+	result := make([]api.Scenario, len(scenarios))
+	for i, scenario := range scenarios {
+		result[i] = appScenarioToHTTPScenario(scenario)
+	}
+	return ctx.JSON(http.StatusCreated, result)
+}
+
+func (h *ScenarioHandler) importScenariosError(err error) error {
 	switch {
+	case errors.Is(err, app.ErrInvalidImport):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	case errors.Is(err, app.ErrScenarioAlreadyExists):
-		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("scenario with name %s already exists for given project", httpScenario.Name))
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	default:
+		return h.createScenarioError(err, "")
+	}
+}
+
+func (h *ScenarioHandler) createScenarioError(err error, name string) error {
+	switch {
+	case errors.Is(err, app.ErrScenarioAlreadyExists):
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("scenario with name %s already exists for given project", name))
 	case errors.Is(err, app.ErrProjectNotFound):
 		return echo.NewHTTPError(http.StatusNotFound, "project not found")
-	case err != nil:
+	case errors.Is(err, app.ErrInvalidScenarioSpec):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
 		h.logger.Error("unable to create scenario", slog.String("error", err.Error()))
 		return echo.NewHTTPError(http.StatusInternalServerError, "unable to create scenario")
 	}
+}
 
-	return ctx.JSON(http.StatusCreated, appScenarioToHTTPScenario(scenario))
+// ValidateScenario validates the spec of a scenario or fragment and returns its issues.
+func (h *ScenarioHandler) ValidateScenario(ctx echo.Context) error {
+	validateRequest := &api.ValidateScenarioJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&validateRequest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid validate scenario payload")
+	}
+	specType := app.ScenarioSpecTypeYAML
+	if validateRequest.SpecType != nil {
+		specType = app.ScenarioSpecType(*validateRequest.SpecType)
+	}
+	issues, err := h.scenarioService.ValidateScenario(ctx.Request().Context(), &app.ValidateScenarioRequest{
+		SpecType: specType,
+		Spec:     validateRequest.Spec,
+	})
+	if errors.Is(err, app.ErrInvalidScenarioSpec) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	} else if err != nil {
+		h.logger.Error("unable to validate scenario", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to validate scenario")
+	}
+
+	result := api.ScenarioValidation{
+		Valid:  true,
+		Issues: make([]api.ScenarioIssue, len(issues)),
+	}
+	for i, issue := range issues {
+		result.Issues[i] = api.ScenarioIssue{
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Message:  issue.Message,
+			Severity: api.Error,
+		}
+		if issue.Warning {
+			result.Issues[i].Severity = api.Warning
+		} else {
+			result.Valid = false
+		}
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// GetScenarioSchema returns the JSON Schema of the YAML format of scenarios.
+func (h *ScenarioHandler) GetScenarioSchema(ctx echo.Context) error {
+	schema, err := yaml.JSONSchema()
+	if err != nil {
+		h.logger.Error("unable to generate scenario schema", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to get scenario schema")
+	}
+	return ctx.JSONBlob(http.StatusOK, schema)
 }
 
 // ListScenariosForProject lists all scenarios for a project.
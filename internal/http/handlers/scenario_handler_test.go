@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -103,6 +105,19 @@ func TestCreateScenario(t *testing.T) {
 			expectErr:     true,
 			errStatusCode: http.StatusNotFound,
 		},
+		{
+			name: "unable to create scenario, invalid spec",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.Scenario{
+					Name:     "test",
+					Spec:     "base64yaml",
+					SpecType: "yaml",
+				}))
+				scenarioServiceMock.On("CreateScenario", mock.Anything, mock.Anything).Return(nil, app.ErrInvalidScenarioSpec)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,6 +141,267 @@ func TestCreateScenario(t *testing.T) {
 	}
 }
 
+func TestImportScenarios(t *testing.T) {
+	projectID := uuid.New()
+	scenarioID := uuid.New()
+	har := []byte(`{"log": {"entries": [
+		{"request": {"method": "GET", "url": "http://localhost:3000/v1/projects", "headers": []}, "response": {"status": 200}}
+	]}}`)
+	spec := base64.StdEncoding.EncodeToString([]byte("version: v1\n"))
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: api.Har,
+					Data:   base64.StdEncoding.EncodeToString(har),
+				}))
+				echoMockContext.On("JSON", http.StatusCreated, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, []api.Scenario{{
+						ID:        scenarioID,
+						ProjectID: projectID,
+						Name:      "har",
+						Spec:      spec,
+						SpecType:  "yaml",
+					}}, args.Get(1))
+				}).Return(nil)
+				scenarioServiceMock.On("ImportScenarios", mock.Anything, &app.ImportScenariosRequest{
+					ProjectID: projectID,
+					Format:    app.ImportFormatHAR,
+					Data:      har,
+				}).Return([]*app.Scenario{{
+					ID:        scenarioID,
+					ProjectID: projectID,
+					Name:      "har",
+					Spec:      spec,
+					SpecType:  app.ScenarioSpecTypeYAML,
+				}}, nil)
+			},
+		},
+		{
+			name: "invalid data, not base64 encoded",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: api.Har,
+					Data:   "{}",
+				}))
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid import",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: "xml",
+					Data:   base64.StdEncoding.EncodeToString(har),
+				}))
+				scenarioServiceMock.On("ImportScenarios", mock.Anything, mock.Anything).Return(nil, app.ErrInvalidImport)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unable to import scenarios, project not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: api.Har,
+					Data:   base64.StdEncoding.EncodeToString(har),
+				}))
+				scenarioServiceMock.On("ImportScenarios", mock.Anything, mock.Anything).Return(nil, app.ErrProjectNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to import scenarios, already exists",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: api.Har,
+					Data:   base64.StdEncoding.EncodeToString(har),
+				}))
+				scenarioServiceMock.On("ImportScenarios", mock.Anything, mock.Anything).Return(nil, app.ErrScenarioAlreadyExists)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusConflict,
+		},
+		{
+			name: "unable to import scenarios, invalid spec",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ImportScenariosJSONRequestBody{
+					Format: api.Har,
+					Data:   base64.StdEncoding.EncodeToString(har),
+				}))
+				scenarioServiceMock.On("ImportScenarios", mock.Anything, mock.Anything).Return(nil, app.ErrInvalidScenarioSpec)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			scenarioServiceMock := serviceMocks.NewScenario(t)
+
+			tt.setupMocks(echoMockContext, scenarioServiceMock)
+
+			scenarioHandler := newScenarioHandler(scenarioServiceMock)
+			err := scenarioHandler.ImportScenarios(echoMockContext, projectID)
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateScenario(t *testing.T) {
+	fragment := api.ScenarioValidateRequestSpecTypeFragment
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success, valid",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ValidateScenarioJSONRequestBody{
+					Spec: "base64yaml",
+				}))
+				echoMockContext.On("JSON", http.StatusOK, api.ScenarioValidation{
+					Valid:  true,
+					Issues: []api.ScenarioIssue{},
+				}).Return(nil)
+				scenarioServiceMock.On("ValidateScenario", mock.Anything, &app.ValidateScenarioRequest{
+					SpecType: app.ScenarioSpecTypeYAML,
+					Spec:     "base64yaml",
+				}).Return([]*app.ScenarioIssue{}, nil)
+			},
+		},
+		{
+			name: "success, issues",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ValidateScenarioJSONRequestBody{
+					Spec:     "base64yaml",
+					SpecType: &fragment,
+				}))
+				echoMockContext.On("JSON", http.StatusOK, api.ScenarioValidation{
+					Valid: false,
+					Issues: []api.ScenarioIssue{{
+						Line:     3,
+						Column:   5,
+						Message:  "step has no name",
+						Severity: api.Error,
+					}},
+				}).Return(nil)
+				scenarioServiceMock.On("ValidateScenario", mock.Anything, &app.ValidateScenarioRequest{
+					SpecType: app.ScenarioSpecTypeFragment,
+					Spec:     "base64yaml",
+				}).Return([]*app.ScenarioIssue{{
+					Line:    3,
+					Column:  5,
+					Message: "step has no name",
+				}}, nil)
+			},
+		},
+		{
+			name: "success, warnings only",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ValidateScenarioJSONRequestBody{
+					Spec: "base64yaml",
+				}))
+				echoMockContext.On("JSON", http.StatusOK, api.ScenarioValidation{
+					Valid: true,
+					Issues: []api.ScenarioIssue{{
+						Line:     7,
+						Column:   12,
+						Message:  "unresolved placeholder ${variables.host}: variable host is not declared",
+						Severity: api.Warning,
+					}},
+				}).Return(nil)
+				scenarioServiceMock.On("ValidateScenario", mock.Anything, &app.ValidateScenarioRequest{
+					SpecType: app.ScenarioSpecTypeYAML,
+					Spec:     "base64yaml",
+				}).Return([]*app.ScenarioIssue{{
+					Line:    7,
+					Column:  12,
+					Message: "unresolved placeholder ${variables.host}: variable host is not declared",
+					Warning: true,
+				}}, nil)
+			},
+		},
+		{
+			name: "invalid spec, not base64 encoded",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ValidateScenarioJSONRequestBody{
+					Spec: "{}",
+				}))
+				scenarioServiceMock.On("ValidateScenario", mock.Anything, mock.Anything).Return(nil, app.ErrInvalidScenarioSpec)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unable to validate scenario, internal",
+			setupMocks: func(echoMockContext *httpMocks.Context, scenarioServiceMock *serviceMocks.Scenario) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.ValidateScenarioJSONRequestBody{
+					Spec: "base64yaml",
+				}))
+				scenarioServiceMock.On("ValidateScenario", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			scenarioServiceMock := serviceMocks.NewScenario(t)
+
+			tt.setupMocks(echoMockContext, scenarioServiceMock)
+
+			scenarioHandler := newScenarioHandler(scenarioServiceMock)
+			err := scenarioHandler.ValidateScenario(echoMockContext)
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetScenarioSchema(t *testing.T) {
+	echoMockContext := httpMocks.NewContext(t)
+	scenarioServiceMock := serviceMocks.NewScenario(t)
+	echoMockContext.On("JSONBlob", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+		schema := map[string]any{}
+		assert.NoError(t, json.Unmarshal(args.Get(1).([]byte), &schema))
+		assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	}).Return(nil)
+
+	scenarioHandler := newScenarioHandler(scenarioServiceMock)
+	err := scenarioHandler.GetScenarioSchema(echoMockContext)
+	assert.NoError(t, err)
+}
+
 func TestListScenariosForProject(t *testing.T) {
 	projectID := uuid.New()
 	scenarioID := uuid.New()
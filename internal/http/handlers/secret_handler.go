@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/http/api"
+	"github.com/inquiryproj/inquiry/internal/service"
+)
+
+// SecretHandler handles secret requests.
+type SecretHandler struct {
+	secretService service.Secret
+	logger        *slog.Logger
+}
+
+// newSecretHandler creates a new secret handler.
+func newSecretHandler(secretService service.Secret, opts ...Opts) *SecretHandler {
+	options := defaultOptions()
+	for _, o := range opts {
+		o(options)
+	}
+	return &SecretHandler{
+		secretService: secretService,
+		logger:        options.Logger,
+	}
+}
+
+// CreateSecret creates a secret for a project.
+func (h *SecretHandler) CreateSecret(ctx echo.Context, projectID uuid.UUID) error {
+	httpSecret := &api.CreateSecretJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&httpSecret)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid create secret payload")
+	}
+	if httpSecret.Name == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "please specify a name when creating a secret")
+	}
+	secret, err := h.secretService.CreateSecret(ctx.Request().Context(), &app.CreateSecretRequest{
+		Name:      httpSecret.Name,
+		Value:     httpSecret.Value,
+		ProjectID: projectID,
+	})
+	switch {
+	case errors.Is(err, app.ErrSecretAlreadyExists):
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("secret with name %s already exists for given project", httpSecret.Name))
+	case errors.Is(err, app.ErrProjectNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "project not found")
+	case errors.Is(err, app.ErrSecretsDisabled):
+		return echo.NewHTTPError(http.StatusNotImplemented, "secrets are disabled, please configure a master key")
+	case err != nil:
+		h.logger.Error("unable to create secret", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to create secret")
+	}
+	return ctx.JSON(http.StatusCreated, appSecretToHTTPSecret(secret))
+}
+
+// UpdateSecret replaces the value of a secret of a project.
+func (h *SecretHandler) UpdateSecret(ctx echo.Context, projectID uuid.UUID, name string) error {
+	httpSecret := &api.UpdateSecretJSONRequestBody{}
+	err := json.NewDecoder(ctx.Request().Body).Decode(&httpSecret)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid update secret payload")
+	}
+	secret, err := h.secretService.UpdateSecret(ctx.Request().Context(), &app.UpdateSecretRequest{
+		Name:      name,
+		Value:     httpSecret.Value,
+		ProjectID: projectID,
+	})
+	switch {
+	case errors.Is(err, app.ErrSecretNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "secret not found")
+	case errors.Is(err, app.ErrSecretsDisabled):
+		return echo.NewHTTPError(http.StatusNotImplemented, "secrets are disabled, please configure a master key")
+	case err != nil:
+		h.logger.Error("unable to update secret", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to update secret")
+	}
+	return ctx.JSON(http.StatusOK, appSecretToHTTPSecret(secret))
+}
+
+// DeleteSecret deletes a secret of a project.
+func (h *SecretHandler) DeleteSecret(ctx echo.Context, projectID uuid.UUID, name string) error {
+	err := h.secretService.DeleteSecret(ctx.Request().Context(), &app.DeleteSecretRequest{
+		Name:      name,
+		ProjectID: projectID,
+	})
+	if errors.Is(err, app.ErrSecretNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "secret not found")
+	} else if err != nil {
+		h.logger.Error("unable to delete secret", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to delete secret")
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListSecretsForProject lists the names of all secrets for a project.
+func (h *SecretHandler) ListSecretsForProject(ctx echo.Context, projectID uuid.UUID, params api.ListSecretsForProjectParams) error {
+	listSecretsRequest := &app.ListSecretsRequest{
+		Limit:     100,
+		Offset:    0,
+		ProjectID: projectID,
+	}
+	if params.Limit != nil {
+		listSecretsRequest.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		listSecretsRequest.Offset = *params.Offset
+	}
+
+	secrets, err := h.secretService.ListSecrets(ctx.Request().Context(), listSecretsRequest)
+	if err != nil {
+		h.logger.Error("unable to get secrets for project", slog.String("error", err.Error()))
+		return echo.NewHTTPError(http.StatusInternalServerError, "unable to get secrets for project")
+	}
+
+	result := make([]api.Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = appSecretToHTTPSecret(secret)
+	}
+
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func appSecretToHTTPSecret(secret *app.Secret) api.Secret {
+	return api.Secret{
+		ID:        secret.ID,
+		Name:      secret.Name,
+		ProjectID: secret.ProjectID,
+	}
+}
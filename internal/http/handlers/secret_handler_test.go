@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/http/api"
+	httpMocks "github.com/inquiryproj/inquiry/internal/http/mocks"
+	serviceMocks "github.com/inquiryproj/inquiry/internal/service/mocks"
+)
+
+func TestCreateSecret(t *testing.T) {
+	projectID := uuid.New()
+	secretID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Name:  "token",
+					Value: "s3cr3t",
+				}))
+				echoMockContext.On("JSON", http.StatusCreated, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, api.Secret{
+						ID:        secretID,
+						ProjectID: projectID,
+						Name:      "token",
+					}, args.Get(1))
+				}).Return(nil)
+				secretServiceMock.On("CreateSecret", mock.Anything, &app.CreateSecretRequest{
+					Name:      "token",
+					Value:     "s3cr3t",
+					ProjectID: projectID,
+				}).Return(&app.Secret{
+					ID:        secretID,
+					ProjectID: projectID,
+					Name:      "token",
+				}, nil)
+			},
+		},
+		{
+			name: "missing name",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Value: "s3cr3t",
+				}))
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "unable to create secret, already exists",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Name:  "token",
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("CreateSecret", mock.Anything, mock.Anything).Return(nil, app.ErrSecretAlreadyExists)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusConflict,
+		},
+		{
+			name: "unable to create secret, project not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Name:  "token",
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("CreateSecret", mock.Anything, mock.Anything).Return(nil, app.ErrProjectNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to create secret, secrets disabled",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Name:  "token",
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("CreateSecret", mock.Anything, mock.Anything).Return(nil, app.ErrSecretsDisabled)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotImplemented,
+		},
+		{
+			name: "unable to create secret, internal",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.CreateSecretJSONRequestBody{
+					Name:  "token",
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("CreateSecret", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			secretServiceMock := serviceMocks.NewSecret(t)
+
+			tt.setupMocks(echoMockContext, secretServiceMock)
+
+			secretHandler := newSecretHandler(secretServiceMock)
+			err := secretHandler.CreateSecret(echoMockContext, projectID)
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateSecret(t *testing.T) {
+	projectID := uuid.New()
+	secretID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateSecretJSONRequestBody{
+					Value: "s3cr3t",
+				}))
+				echoMockContext.On("JSON", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, api.Secret{
+						ID:        secretID,
+						ProjectID: projectID,
+						Name:      "token",
+					}, args.Get(1))
+				}).Return(nil)
+				secretServiceMock.On("UpdateSecret", mock.Anything, &app.UpdateSecretRequest{
+					Name:      "token",
+					Value:     "s3cr3t",
+					ProjectID: projectID,
+				}).Return(&app.Secret{
+					ID:        secretID,
+					ProjectID: projectID,
+					Name:      "token",
+				}, nil)
+			},
+		},
+		{
+			name: "secret not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateSecretJSONRequestBody{
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("UpdateSecret", mock.Anything, mock.Anything).Return(nil, app.ErrSecretNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "unable to update secret, secrets disabled",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(httpRequestForStruct(t, api.UpdateSecretJSONRequestBody{
+					Value: "s3cr3t",
+				}))
+				secretServiceMock.On("UpdateSecret", mock.Anything, mock.Anything).Return(nil, app.ErrSecretsDisabled)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotImplemented,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			secretServiceMock := serviceMocks.NewSecret(t)
+
+			tt.setupMocks(echoMockContext, secretServiceMock)
+
+			secretHandler := newSecretHandler(secretServiceMock)
+			err := secretHandler.UpdateSecret(echoMockContext, projectID, "token")
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteSecret(t *testing.T) {
+	projectID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				echoMockContext.On("NoContent", http.StatusNoContent).Return(nil)
+				secretServiceMock.On("DeleteSecret", mock.Anything, &app.DeleteSecretRequest{
+					Name:      "token",
+					ProjectID: projectID,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "secret not found",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				secretServiceMock.On("DeleteSecret", mock.Anything, mock.Anything).Return(app.ErrSecretNotFound)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			secretServiceMock := serviceMocks.NewSecret(t)
+
+			tt.setupMocks(echoMockContext, secretServiceMock)
+
+			secretHandler := newSecretHandler(secretServiceMock)
+			err := secretHandler.DeleteSecret(echoMockContext, projectID, "token")
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListSecretsForProject(t *testing.T) {
+	projectID := uuid.New()
+	secretID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret)
+		expectErr     bool
+		errStatusCode int
+	}{
+		{
+			name: "success",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				echoMockContext.On("JSON", http.StatusOK, mock.Anything).Run(func(args mock.Arguments) {
+					assert.Equal(t, []api.Secret{
+						{
+							ID:        secretID,
+							ProjectID: projectID,
+							Name:      "token",
+						},
+					}, args.Get(1))
+				}).Return(nil)
+				secretServiceMock.On("ListSecrets", mock.Anything, &app.ListSecretsRequest{
+					Limit:     100,
+					Offset:    0,
+					ProjectID: projectID,
+				}).Return([]*app.Secret{
+					{
+						ID:        secretID,
+						ProjectID: projectID,
+						Name:      "token",
+					},
+				}, nil)
+			},
+		},
+		{
+			name: "unable to list secrets",
+			setupMocks: func(echoMockContext *httpMocks.Context, secretServiceMock *serviceMocks.Secret) {
+				echoMockContext.On("Request").Return(&http.Request{})
+				secretServiceMock.On("ListSecrets", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			},
+			expectErr:     true,
+			errStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			echoMockContext := httpMocks.NewContext(t)
+			secretServiceMock := serviceMocks.NewSecret(t)
+
+			tt.setupMocks(echoMockContext, secretServiceMock)
+
+			secretHandler := newSecretHandler(secretServiceMock)
+			err := secretHandler.ListSecretsForProject(echoMockContext, projectID, api.ListSecretsForProjectParams{})
+			if tt.expectErr {
+				assert.Error(t, err)
+				httpError := &echo.HTTPError{}
+				assert.ErrorAs(t, err, &httpError)
+				assert.Equal(t, tt.errStatusCode, httpError.Code)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
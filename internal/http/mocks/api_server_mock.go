@@ -15,6 +15,20 @@ type ServerInterface struct {
 	mock.Mock
 }
 
+// CreateEnvironment provides a mock function with given fields: ctx, projectId
+func (_m *ServerInterface) CreateEnvironment(ctx echo.Context, projectId uuid.UUID) error {
+	ret := _m.Called(ctx, projectId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, projectId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateProject provides a mock function with given fields: ctx
 func (_m *ServerInterface) CreateProject(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -43,6 +57,90 @@ func (_m *ServerInterface) CreateScenario(ctx echo.Context, projectId uuid.UUID)
 	return r0
 }
 
+// CreateSecret provides a mock function with given fields: ctx, projectId
+func (_m *ServerInterface) CreateSecret(ctx echo.Context, projectId uuid.UUID) error {
+	ret := _m.Called(ctx, projectId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, projectId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteEnvironment provides a mock function with given fields: ctx, projectId, name
+func (_m *ServerInterface) DeleteEnvironment(ctx echo.Context, projectId uuid.UUID, name string) error {
+	ret := _m.Called(ctx, projectId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, projectId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSecret provides a mock function with given fields: ctx, projectId, name
+func (_m *ServerInterface) DeleteSecret(ctx echo.Context, projectId uuid.UUID, name string) error {
+	ret := _m.Called(ctx, projectId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, projectId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetScenarioSchema provides a mock function with given fields: ctx
+func (_m *ServerInterface) GetScenarioSchema(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ImportScenarios provides a mock function with given fields: ctx, projectId
+func (_m *ServerInterface) ImportScenarios(ctx echo.Context, projectId uuid.UUID) error {
+	ret := _m.Called(ctx, projectId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, projectId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListEnvironmentsForProject provides a mock function with given fields: ctx, projectId, params
+func (_m *ServerInterface) ListEnvironmentsForProject(ctx echo.Context, projectId uuid.UUID, params api.ListEnvironmentsForProjectParams) error {
+	ret := _m.Called(ctx, projectId, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, api.ListEnvironmentsForProjectParams) error); ok {
+		r0 = rf(ctx, projectId, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ListProjects provides a mock function with given fields: ctx, params
 func (_m *ServerInterface) ListProjects(ctx echo.Context, params api.ListProjectsParams) error {
 	ret := _m.Called(ctx, params)
@@ -85,6 +183,20 @@ func (_m *ServerInterface) ListScenariosForProject(ctx echo.Context, projectId u
 	return r0
 }
 
+// ListSecretsForProject provides a mock function with given fields: ctx, projectId, params
+func (_m *ServerInterface) ListSecretsForProject(ctx echo.Context, projectId uuid.UUID, params api.ListSecretsForProjectParams) error {
+	ret := _m.Called(ctx, projectId, params)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, api.ListSecretsForProjectParams) error); ok {
+		r0 = rf(ctx, projectId, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RunProject provides a mock function with given fields: ctx
 func (_m *ServerInterface) RunProject(ctx echo.Context) error {
 	ret := _m.Called(ctx)
@@ -99,6 +211,48 @@ func (_m *ServerInterface) RunProject(ctx echo.Context) error {
 	return r0
 }
 
+// UpdateEnvironment provides a mock function with given fields: ctx, projectId, name
+func (_m *ServerInterface) UpdateEnvironment(ctx echo.Context, projectId uuid.UUID, name string) error {
+	ret := _m.Called(ctx, projectId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, projectId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSecret provides a mock function with given fields: ctx, projectId, name
+func (_m *ServerInterface) UpdateSecret(ctx echo.Context, projectId uuid.UUID, name string) error {
+	ret := _m.Called(ctx, projectId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, projectId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidateScenario provides a mock function with given fields: ctx
+func (_m *ServerInterface) ValidateScenario(ctx echo.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(echo.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewServerInterface creates a new instance of ServerInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServerInterface(t interface {
@@ -0,0 +1,45 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Environment is the environment domain model, the variables of an environment
+// override the values of the variables defined by the scenarios of a project.
+type Environment struct {
+	ID        uuid.UUID
+	Name      string
+	Variables map[string]string
+	ProjectID uuid.UUID
+}
+
+// CreateEnvironmentRequest requests model for creating an environment.
+type CreateEnvironmentRequest struct {
+	Name      string
+	Variables map[string]string
+	ProjectID uuid.UUID
+}
+
+// UpdateEnvironmentRequest requests model for updating the variables of an environment.
+type UpdateEnvironmentRequest struct {
+	Name      string
+	Variables map[string]string
+	ProjectID uuid.UUID
+}
+
+// GetEnvironmentByNameRequest requests model for retrieving an environment of a project by name.
+type GetEnvironmentByNameRequest struct {
+	Name      string
+	ProjectID uuid.UUID
+}
+
+// DeleteEnvironmentRequest requests model for deleting an environment of a project.
+type DeleteEnvironmentRequest struct {
+	Name      string
+	ProjectID uuid.UUID
+}
+
+// ListEnvironmentsForProjectRequest requests model for retrieving environments for a project.
+type ListEnvironmentsForProjectRequest struct {
+	Limit     int
+	Offset    int
+	ProjectID uuid.UUID
+}
@@ -5,6 +5,12 @@ import "fmt"
 // ErrInvalidScenarioSpecType is returned when an invalid scenario spec type is provided.
 var ErrInvalidScenarioSpecType = fmt.Errorf("invalid scenario spec type")
 
+// ErrSecretAlreadyExists is returned when a secret already exists.
+var ErrSecretAlreadyExists = fmt.Errorf("secret already exists")
+
+// ErrSecretNotFound is returned when a secret is not found.
+var ErrSecretNotFound = fmt.Errorf("secret not found")
+
 // ErrScenarioAlreadyExists is returned when a scenario already exists.
 var ErrScenarioAlreadyExists = fmt.Errorf("scenario already exists")
 
@@ -16,3 +22,9 @@ var ErrProjectNotFound = fmt.Errorf("project not found")
 
 // ErrUserAlreadyExists is returned when a user already exists.
 var ErrUserAlreadyExists = fmt.Errorf("user already exists")
+
+// ErrEnvironmentAlreadyExists is returned when an environment already exists.
+var ErrEnvironmentAlreadyExists = fmt.Errorf("environment already exists")
+
+// ErrEnvironmentNotFound is returned when an environment is not found.
+var ErrEnvironmentNotFound = fmt.Errorf("environment not found")
@@ -25,33 +25,64 @@ type Run struct {
 	Success            bool
 	State              RunState
 	ErrorMessage       string
+	Environment        string
 	ScenarioRunDetails []*ScenarioRunDetails
 	CreatedAt          time.Time
 }
 
 // ScenarioRunDetails is the domain model for scenario run details.
 type ScenarioRunDetails struct {
-	Name       string
-	Duration   time.Duration
-	Assertions int
-	Steps      []*StepRunDetails
-	Success    bool
+	Name             string
+	Duration         time.Duration
+	Assertions       int
+	AssertionResults []*AssertionResult
+	SetupSteps       []*StepRunDetails
+	Steps            []*StepRunDetails
+	TeardownSteps    []*StepRunDetails
+	SubScenarios     []*ScenarioRunDetails
+	TimedOut         bool
+	Success          bool
 }
 
 // StepRunDetails is the domain model for scenario step run details.
 type StepRunDetails struct {
-	Name            string
-	Assertions      int
-	URL             string
+	Name             string
+	Assertions       int
+	AssertionResults []*AssertionResult
+	URL              string
+	RequestDuration  time.Duration
+	Duration         time.Duration
+	Retries          int
+	Attempts         []*AttemptResult
+	Skipped          bool
+	Success          bool
+}
+
+// AssertionResult is the domain model for the outcome of a single assertion of a step.
+type AssertionResult struct {
+	Type      string
+	Key       string
+	Assertion string
+	Expected  string
+	Actual    string
+	Success   bool
+	Message   string
+}
+
+// AttemptResult is the domain model for the outcome of a single attempt of a step.
+type AttemptResult struct {
+	Status          int
 	RequestDuration time.Duration
-	Duration        time.Duration
-	Retries         int
+	Delay           time.Duration
 	Success         bool
+	Error           string
 }
 
 // CreateRunRequest is the request to create a run.
+// Environment is the name of the environment of the project the run uses, if any.
 type CreateRunRequest struct {
-	ProjectID uuid.UUID
+	ProjectID   uuid.UUID
+	Environment string
 }
 
 // UpdateRunRequest is the request to update a run.
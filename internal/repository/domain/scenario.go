@@ -13,16 +13,36 @@ func (s ScenarioSpecType) String() string {
 // ScenarioSpecType constants.
 const (
 	ScenarioSpecTypeYAML ScenarioSpecType = "yaml"
+	ScenarioSpecTypeCSV  ScenarioSpecType = "csv"
+	ScenarioSpecTypeJSON ScenarioSpecType = "json"
+	// ScenarioSpecTypeOpenAPI is an OpenAPI 3 document in either YAML or JSON format. The only
+	// OpenAPI document of a project is the contract of the scenarios which do not reference one.
+	ScenarioSpecTypeOpenAPI ScenarioSpecType = "openapi"
+	// ScenarioSpecTypeAttachment is a file attached to requests, such as a file of a multipart body.
+	ScenarioSpecTypeAttachment ScenarioSpecType = "attachment"
+	// ScenarioSpecTypeClient is the YAML client configuration of a project, such as TLS and redirects,
+	// which is used for the settings the client configuration of a scenario does not set.
+	ScenarioSpecTypeClient ScenarioSpecType = "client"
+	// ScenarioSpecTypeFragment is a YAML fragment of reusable steps, which scenarios include with use steps.
+	ScenarioSpecTypeFragment ScenarioSpecType = "fragment"
 )
 
 // ScenarioSpecTypeFromString returns the scenario spec type from string or an error if unknown.
 func ScenarioSpecTypeFromString(s string) (ScenarioSpecType, error) {
-	switch s {
-	case "yaml":
-		return ScenarioSpecTypeYAML, nil
-	default:
-		return "", ErrInvalidScenarioSpecType
+	for _, specType := range []ScenarioSpecType{
+		ScenarioSpecTypeYAML,
+		ScenarioSpecTypeCSV,
+		ScenarioSpecTypeJSON,
+		ScenarioSpecTypeOpenAPI,
+		ScenarioSpecTypeAttachment,
+		ScenarioSpecTypeClient,
+		ScenarioSpecTypeFragment,
+	} {
+		if s == string(specType) {
+			return specType, nil
+		}
 	}
+	return "", ErrInvalidScenarioSpecType
 }
 
 // CreateScenarioRequest requests model for creating a scenario.
@@ -0,0 +1,39 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Secret is the secret domain model, the value of a secret is encrypted
+// with the master key of the server and never stored in plaintext.
+type Secret struct {
+	ID             uuid.UUID
+	Name           string
+	EncryptedValue string
+	ProjectID      uuid.UUID
+}
+
+// CreateSecretRequest requests model for creating a secret.
+type CreateSecretRequest struct {
+	Name           string
+	EncryptedValue string
+	ProjectID      uuid.UUID
+}
+
+// UpdateSecretRequest requests model for updating the value of a secret.
+type UpdateSecretRequest struct {
+	Name           string
+	EncryptedValue string
+	ProjectID      uuid.UUID
+}
+
+// DeleteSecretRequest requests model for deleting a secret of a project.
+type DeleteSecretRequest struct {
+	Name      string
+	ProjectID uuid.UUID
+}
+
+// ListSecretsForProjectRequest requests model for retrieving secrets for a project.
+type ListSecretsForProjectRequest struct {
+	Limit     int
+	Offset    int
+	ProjectID uuid.UUID
+}
@@ -4,4 +4,6 @@ package mocks
 
 //go:generate mockery --output . --filename ./project_repository_mock.go 	--dir .. --name Project
 //go:generate mockery --output . --filename ./scenario_repository_mock.go 	--dir .. --name Scenario
+//go:generate mockery --output . --filename ./environment_repository_mock.go 	--dir .. --name Environment
+//go:generate mockery --output . --filename ./secret_repository_mock.go 	--dir .. --name Secret
 //go:generate mockery --output . --filename ./run_repository_mock.go 		--dir .. --name Run
@@ -14,10 +14,12 @@ import (
 
 // Wrapper wraps all repositories.
 type Wrapper struct {
-	Project  Project
-	Run      Run
-	Scenario Scenario
-	APIKey   APIKey
+	Project     Project
+	Run         Run
+	Scenario    Scenario
+	Environment Environment
+	Secret      Secret
+	APIKey      APIKey
 }
 
 // Project is the project repository.
@@ -42,6 +44,24 @@ type Scenario interface {
 	GetForProject(ctx context.Context, getForProjectRequest *domain.GetScenariosForProjectRequest) ([]*domain.Scenario, error)
 }
 
+// Environment is the environment repository.
+type Environment interface {
+	Create(ctx context.Context, environment *domain.CreateEnvironmentRequest) (*domain.Environment, error)
+	GetByName(ctx context.Context, getByNameRequest *domain.GetEnvironmentByNameRequest) (*domain.Environment, error)
+	Update(ctx context.Context, environment *domain.UpdateEnvironmentRequest) (*domain.Environment, error)
+	Delete(ctx context.Context, deleteEnvironmentRequest *domain.DeleteEnvironmentRequest) error
+	ListForProject(ctx context.Context, listForProjectRequest *domain.ListEnvironmentsForProjectRequest) ([]*domain.Environment, error)
+}
+
+// Secret is the secret repository.
+type Secret interface {
+	Create(ctx context.Context, secret *domain.CreateSecretRequest) (*domain.Secret, error)
+	Update(ctx context.Context, secret *domain.UpdateSecretRequest) (*domain.Secret, error)
+	Delete(ctx context.Context, deleteSecretRequest *domain.DeleteSecretRequest) error
+	ListForProject(ctx context.Context, listForProjectRequest *domain.ListSecretsForProjectRequest) ([]*domain.Secret, error)
+	GetAllForProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Secret, error)
+}
+
 // APIKey is the API key repository.
 type APIKey interface {
 	Validate(ctx context.Context, s string) (uuid.UUID, error)
@@ -128,9 +148,11 @@ func NewSQLiteWrapper(dsn string, options *Options) (*Wrapper, error) {
 		return nil, err
 	}
 	return &Wrapper{
-		Project:  sqliteRepository.ProjectRepository,
-		Scenario: sqliteRepository.ScenarioRepository,
-		Run:      sqliteRepository.RunRepository,
-		APIKey:   sqliteRepository.APIKeyRepository,
+		Project:     sqliteRepository.ProjectRepository,
+		Scenario:    sqliteRepository.ScenarioRepository,
+		Environment: sqliteRepository.EnvironmentRepository,
+		Secret:      sqliteRepository.SecretRepository,
+		Run:         sqliteRepository.RunRepository,
+		APIKey:      sqliteRepository.APIKeyRepository,
 	}, nil
 }
@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+)
+
+const defaultEnvironmentLimit = 100
+
+// Environment is the sqlite model for environments.
+type Environment struct {
+	BaseModel
+	Name      string `gorm:"index:idx_environment_project_id_name_unique,unique"`
+	Variables []byte
+	ProjectID uuid.UUID `gorm:"index:idx_environment_project_id_name_unique,unique"`
+}
+
+// EnvironmentRepository is the sqlite repository for environments.
+type EnvironmentRepository struct {
+	conn *gorm.DB
+}
+
+// NewEnvironmentRepository initialises the sqlite environment repository.
+func NewEnvironmentRepository(conn *gorm.DB) *EnvironmentRepository {
+	return &EnvironmentRepository{
+		conn: conn,
+	}
+}
+
+// Create creates a new environment in sqlite.
+func (r *EnvironmentRepository) Create(ctx context.Context, createEnvironmentRequest *domain.CreateEnvironmentRequest) (*domain.Environment, error) {
+	variables, err := json.Marshal(createEnvironmentRequest.Variables)
+	if err != nil {
+		return nil, err
+	}
+	sqliteEnvironment := &Environment{
+		Name:      createEnvironmentRequest.Name,
+		Variables: variables,
+		ProjectID: createEnvironmentRequest.ProjectID,
+	}
+	err = r.conn.WithContext(ctx).Model(&Environment{}).Create(sqliteEnvironment).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w %w", domain.ErrEnvironmentAlreadyExists, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return environmentToDomainEnvironment(sqliteEnvironment)
+}
+
+// GetByName returns the environment of a project with a given name.
+func (r *EnvironmentRepository) GetByName(ctx context.Context, getByNameRequest *domain.GetEnvironmentByNameRequest) (*domain.Environment, error) {
+	environment, err := r.getByName(ctx, getByNameRequest.ProjectID, getByNameRequest.Name)
+	if err != nil {
+		return nil, err
+	}
+	return environmentToDomainEnvironment(environment)
+}
+
+func (r *EnvironmentRepository) getByName(ctx context.Context, projectID uuid.UUID, name string) (*Environment, error) {
+	environment := &Environment{}
+	err := r.conn.WithContext(ctx).
+		Model(&Environment{}).
+		Where("project_id = ? AND name = ?", projectID, name).
+		First(environment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w %w", domain.ErrEnvironmentNotFound, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return environment, nil
+}
+
+// Update replaces the variables of an environment in sqlite.
+func (r *EnvironmentRepository) Update(ctx context.Context, updateEnvironmentRequest *domain.UpdateEnvironmentRequest) (*domain.Environment, error) {
+	environment, err := r.getByName(ctx, updateEnvironmentRequest.ProjectID, updateEnvironmentRequest.Name)
+	if err != nil {
+		return nil, err
+	}
+	environment.Variables, err = json.Marshal(updateEnvironmentRequest.Variables)
+	if err != nil {
+		return nil, err
+	}
+	err = r.conn.WithContext(ctx).Model(&Environment{}).Where("id = ?", environment.ID).Save(environment).Error
+	if err != nil {
+		return nil, err
+	}
+	return environmentToDomainEnvironment(environment)
+}
+
+// Delete deletes the environment of a project with a given name.
+func (r *EnvironmentRepository) Delete(ctx context.Context, deleteEnvironmentRequest *domain.DeleteEnvironmentRequest) error {
+	// environments are deleted permanently, such that an environment with the same name can be created again.
+	result := r.conn.WithContext(ctx).
+		Unscoped().
+		Where("project_id = ? AND name = ?", deleteEnvironmentRequest.ProjectID, deleteEnvironmentRequest.Name).
+		Delete(&Environment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrEnvironmentNotFound
+	}
+	return nil
+}
+
+// ListForProject returns the environments of a given project.
+func (r *EnvironmentRepository) ListForProject(ctx context.Context, listForProjectRequest *domain.ListEnvironmentsForProjectRequest) ([]*domain.Environment, error) {
+	if listForProjectRequest.Limit == 0 {
+		listForProjectRequest.Limit = defaultEnvironmentLimit
+	}
+	environments := []*Environment{}
+	err := r.conn.WithContext(ctx).
+		Model(&Environment{}).
+		Limit(listForProjectRequest.Limit).
+		Offset(listForProjectRequest.Limit*listForProjectRequest.Offset).
+		Where("project_id = ?", listForProjectRequest.ProjectID).
+		Order("name").
+		Find(&environments).Error
+	if err != nil {
+		return nil, err
+	}
+	result := []*domain.Environment{}
+	for _, environment := range environments {
+		domainEnvironment, err := environmentToDomainEnvironment(environment)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, domainEnvironment)
+	}
+	return result, nil
+}
+
+func environmentToDomainEnvironment(environment *Environment) (*domain.Environment, error) {
+	variables := map[string]string{}
+	err := json.Unmarshal(environment.Variables, &variables)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Environment{
+		ID:        environment.ID,
+		Name:      environment.Name,
+		Variables: variables,
+		ProjectID: environment.ProjectID,
+	}, nil
+}
@@ -0,0 +1,97 @@
+//go:build integration
+
+package sqlite
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+)
+
+func (s *SQLiteIntegrationSuite) TestCreateEnvironment() {
+	projectID := uuid.New()
+	environment, err := s.repository.EnvironmentRepository.Create(context.Background(), &domain.CreateEnvironmentRequest{
+		Name:      "staging",
+		Variables: map[string]string{"base_url": "https://staging.example.com"},
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+	s.Equal("staging", environment.Name)
+	s.Equal(map[string]string{"base_url": "https://staging.example.com"}, environment.Variables)
+	s.Equal(projectID, environment.ProjectID)
+
+	_, err = s.repository.EnvironmentRepository.Create(context.Background(), &domain.CreateEnvironmentRequest{
+		Name:      "staging",
+		ProjectID: projectID,
+	})
+	s.ErrorIs(err, domain.ErrEnvironmentAlreadyExists)
+}
+
+func (s *SQLiteIntegrationSuite) TestUpdateAndGetEnvironment() {
+	projectID := uuid.New()
+	environment, err := s.repository.EnvironmentRepository.Create(context.Background(), &domain.CreateEnvironmentRequest{
+		Name:      "staging",
+		Variables: map[string]string{"base_url": "https://staging.example.com"},
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+
+	_, err = s.repository.EnvironmentRepository.Update(context.Background(), &domain.UpdateEnvironmentRequest{
+		Name:      "staging",
+		Variables: map[string]string{"token": "secret"},
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+
+	updated, err := s.repository.EnvironmentRepository.GetByName(context.Background(), &domain.GetEnvironmentByNameRequest{
+		Name:      "staging",
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+	s.Equal(environment.ID, updated.ID)
+	s.Equal(map[string]string{"token": "secret"}, updated.Variables)
+
+	_, err = s.repository.EnvironmentRepository.GetByName(context.Background(), &domain.GetEnvironmentByNameRequest{
+		Name:      "production",
+		ProjectID: projectID,
+	})
+	s.ErrorIs(err, domain.ErrEnvironmentNotFound)
+}
+
+func (s *SQLiteIntegrationSuite) TestListAndDeleteEnvironments() {
+	projectID := uuid.New()
+	for _, name := range []string{"staging", "production"} {
+		_, err := s.repository.EnvironmentRepository.Create(context.Background(), &domain.CreateEnvironmentRequest{
+			Name:      name,
+			Variables: map[string]string{},
+			ProjectID: projectID,
+		})
+		s.NoError(err)
+	}
+
+	s.NoError(s.repository.EnvironmentRepository.Delete(context.Background(), &domain.DeleteEnvironmentRequest{
+		Name:      "staging",
+		ProjectID: projectID,
+	}))
+	s.ErrorIs(s.repository.EnvironmentRepository.Delete(context.Background(), &domain.DeleteEnvironmentRequest{
+		Name:      "staging",
+		ProjectID: projectID,
+	}), domain.ErrEnvironmentNotFound)
+
+	environments, err := s.repository.EnvironmentRepository.ListForProject(context.Background(), &domain.ListEnvironmentsForProjectRequest{
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+	s.Equal(1, len(environments))
+	s.Equal("production", environments[0].Name)
+
+	// deleted environments can be created again.
+	_, err = s.repository.EnvironmentRepository.Create(context.Background(), &domain.CreateEnvironmentRequest{
+		Name:      "staging",
+		Variables: map[string]string{},
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+}
@@ -25,22 +25,50 @@ const (
 
 // ScenarioDetails is the json model for scenario run details.
 type ScenarioDetails struct {
-	Name       string        `json:"name"`
-	Duration   time.Duration `json:"duration"`
-	Assertions int           `json:"assertions"`
-	Steps      []*Step       `json:"steps"`
-	Success    bool          `json:"success"`
+	Name             string             `json:"name"`
+	Duration         time.Duration      `json:"duration"`
+	Assertions       int                `json:"assertions"`
+	AssertionResults []*AssertionResult `json:"assertion_results"`
+	SetupSteps       []*Step            `json:"setup_steps"`
+	Steps            []*Step            `json:"steps"`
+	TeardownSteps    []*Step            `json:"teardown_steps"`
+	SubScenarios     []*ScenarioDetails `json:"sub_scenarios"`
+	TimedOut         bool               `json:"timed_out"`
+	Success          bool               `json:"success"`
 }
 
 // Step is the json model for scenario step run details.
 type Step struct {
-	Name            string        `json:"name"`
-	Assertions      int           `json:"assertions"`
-	URL             string        `json:"url"`
+	Name             string             `json:"name"`
+	Assertions       int                `json:"assertions"`
+	AssertionResults []*AssertionResult `json:"assertion_results"`
+	URL              string             `json:"url"`
+	RequestDuration  time.Duration      `json:"request_duration"`
+	Duration         time.Duration      `json:"duration"`
+	Retries          int                `json:"retries"`
+	Attempts         []*Attempt         `json:"attempts"`
+	Skipped          bool               `json:"skipped"`
+	Success          bool               `json:"success"`
+}
+
+// AssertionResult is the json model for the outcome of a single assertion of a step.
+type AssertionResult struct {
+	Type      string `json:"type"`
+	Key       string `json:"key"`
+	Assertion string `json:"assertion"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+// Attempt is the json model for the outcome of a single attempt of a step.
+type Attempt struct {
+	Status          int           `json:"status"`
 	RequestDuration time.Duration `json:"request_duration"`
-	Duration        time.Duration `json:"duration"`
-	Retries         int           `json:"retries"`
+	Delay           time.Duration `json:"delay"`
 	Success         bool          `json:"success"`
+	Error           string        `json:"error"`
 }
 
 // Run is the sqlite model for runs.
@@ -50,6 +78,7 @@ type Run struct {
 	Success         bool
 	State           RunState
 	ErrorMessage    string
+	Environment     string
 	ScenarioDetails []byte
 }
 
@@ -80,6 +109,7 @@ func (r *RunRepository) Create(ctx context.Context, createRunRequest *domain.Cre
 	run := &Run{
 		ProjectID:       createRunRequest.ProjectID,
 		State:           RunStatePending,
+		Environment:     createRunRequest.Environment,
 		ScenarioDetails: []byte(`[]`),
 	}
 	err := r.conn.WithContext(ctx).Model(&Run{}).Create(run).Error
@@ -128,11 +158,16 @@ func domainScenarioToScenario(scenario *domain.ScenarioRunDetails) *ScenarioDeta
 		return &ScenarioDetails{}
 	}
 	return &ScenarioDetails{
-		Name:       scenario.Name,
-		Duration:   scenario.Duration,
-		Assertions: scenario.Assertions,
-		Steps:      domainStepsToSteps(scenario.Steps),
-		Success:    scenario.Success,
+		Name:             scenario.Name,
+		Duration:         scenario.Duration,
+		Assertions:       scenario.Assertions,
+		AssertionResults: domainAssertionResultsToAssertionResults(scenario.AssertionResults),
+		SetupSteps:       domainStepsToSteps(scenario.SetupSteps),
+		Steps:            domainStepsToSteps(scenario.Steps),
+		TeardownSteps:    domainStepsToSteps(scenario.TeardownSteps),
+		SubScenarios:     domainScenariosToScenarios(scenario.SubScenarios),
+		TimedOut:         scenario.TimedOut,
+		Success:          scenario.Success,
 	}
 }
 
@@ -140,13 +175,46 @@ func domainStepsToSteps(steps []*domain.StepRunDetails) []*Step {
 	result := []*Step{}
 	for _, step := range steps {
 		result = append(result, &Step{
-			Name:            step.Name,
-			Assertions:      step.Assertions,
-			URL:             step.URL,
-			RequestDuration: step.RequestDuration,
-			Duration:        step.Duration,
-			Retries:         step.Retries,
-			Success:         step.Success,
+			Name:             step.Name,
+			Assertions:       step.Assertions,
+			AssertionResults: domainAssertionResultsToAssertionResults(step.AssertionResults),
+			URL:              step.URL,
+			RequestDuration:  step.RequestDuration,
+			Duration:         step.Duration,
+			Retries:          step.Retries,
+			Attempts:         domainAttemptsToAttempts(step.Attempts),
+			Skipped:          step.Skipped,
+			Success:          step.Success,
+		})
+	}
+	return result
+}
+
+func domainAttemptsToAttempts(attempts []*domain.AttemptResult) []*Attempt {
+	result := []*Attempt{}
+	for _, attempt := range attempts {
+		result = append(result, &Attempt{
+			Status:          attempt.Status,
+			RequestDuration: attempt.RequestDuration,
+			Delay:           attempt.Delay,
+			Success:         attempt.Success,
+			Error:           attempt.Error,
+		})
+	}
+	return result
+}
+
+func domainAssertionResultsToAssertionResults(assertionResults []*domain.AssertionResult) []*AssertionResult {
+	result := []*AssertionResult{}
+	for _, assertionResult := range assertionResults {
+		result = append(result, &AssertionResult{
+			Type:      assertionResult.Type,
+			Key:       assertionResult.Key,
+			Assertion: assertionResult.Assertion,
+			Expected:  assertionResult.Expected,
+			Actual:    assertionResult.Actual,
+			Success:   assertionResult.Success,
+			Message:   assertionResult.Message,
 		})
 	}
 	return result
@@ -189,6 +257,7 @@ func runToDomainRun(run *Run) (*domain.Run, error) {
 		Success:            run.Success,
 		State:              domain.RunState(run.State),
 		ErrorMessage:       run.ErrorMessage,
+		Environment:        run.Environment,
 		ScenarioRunDetails: scenarioRunDetails,
 		CreatedAt:          run.CreatedAt,
 	}, nil
@@ -200,30 +269,72 @@ func scenarioRunDetailsToDomainScenarioRunDetails(scenario []byte) ([]*domain.Sc
 	if err != nil {
 		return nil, err
 	}
+	return scenariosToDomainScenarios(details), nil
+}
+
+func scenariosToDomainScenarios(details []*ScenarioDetails) []*domain.ScenarioRunDetails {
 	result := []*domain.ScenarioRunDetails{}
 	for _, detail := range details {
 		result = append(result, &domain.ScenarioRunDetails{
-			Name:       detail.Name,
-			Duration:   detail.Duration,
-			Assertions: detail.Assertions,
-			Steps:      stepsRunDetailsToDomainStepRunDetails(detail.Steps),
-			Success:    detail.Success,
+			Name:             detail.Name,
+			Duration:         detail.Duration,
+			Assertions:       detail.Assertions,
+			AssertionResults: assertionResultsToDomainAssertionResults(detail.AssertionResults),
+			SetupSteps:       stepsRunDetailsToDomainStepRunDetails(detail.SetupSteps),
+			Steps:            stepsRunDetailsToDomainStepRunDetails(detail.Steps),
+			TeardownSteps:    stepsRunDetailsToDomainStepRunDetails(detail.TeardownSteps),
+			SubScenarios:     scenariosToDomainScenarios(detail.SubScenarios),
+			TimedOut:         detail.TimedOut,
+			Success:          detail.Success,
 		})
 	}
-	return result, nil
+	return result
 }
 
 func stepsRunDetailsToDomainStepRunDetails(steps []*Step) []*domain.StepRunDetails {
 	result := []*domain.StepRunDetails{}
 	for _, step := range steps {
 		result = append(result, &domain.StepRunDetails{
-			Name:            step.Name,
-			Assertions:      step.Assertions,
-			URL:             step.URL,
-			RequestDuration: step.RequestDuration,
-			Duration:        step.Duration,
-			Retries:         step.Retries,
-			Success:         step.Success,
+			Name:             step.Name,
+			Assertions:       step.Assertions,
+			AssertionResults: assertionResultsToDomainAssertionResults(step.AssertionResults),
+			URL:              step.URL,
+			RequestDuration:  step.RequestDuration,
+			Duration:         step.Duration,
+			Retries:          step.Retries,
+			Attempts:         attemptsToDomainAttempts(step.Attempts),
+			Skipped:          step.Skipped,
+			Success:          step.Success,
+		})
+	}
+	return result
+}
+
+func attemptsToDomainAttempts(attempts []*Attempt) []*domain.AttemptResult {
+	result := []*domain.AttemptResult{}
+	for _, attempt := range attempts {
+		result = append(result, &domain.AttemptResult{
+			Status:          attempt.Status,
+			RequestDuration: attempt.RequestDuration,
+			Delay:           attempt.Delay,
+			Success:         attempt.Success,
+			Error:           attempt.Error,
+		})
+	}
+	return result
+}
+
+func assertionResultsToDomainAssertionResults(assertionResults []*AssertionResult) []*domain.AssertionResult {
+	result := []*domain.AssertionResult{}
+	for _, assertionResult := range assertionResults {
+		result = append(result, &domain.AssertionResult{
+			Type:      assertionResult.Type,
+			Key:       assertionResult.Key,
+			Assertion: assertionResult.Assertion,
+			Expected:  assertionResult.Expected,
+			Actual:    assertionResult.Actual,
+			Success:   assertionResult.Success,
+			Message:   assertionResult.Message,
 		})
 	}
 	return result
@@ -20,9 +20,23 @@ func (s *SQLiteIntegrationSuite) TestCreateInitialRun() {
 	s.Equal(false, run.Success)
 	s.Equal(domain.RunStatePending, run.State)
 	s.Equal("", run.ErrorMessage)
+	s.Equal("", run.Environment)
 	s.Equal([]*domain.ScenarioRunDetails{}, run.ScenarioRunDetails)
 }
 
+func (s *SQLiteIntegrationSuite) TestCreateRunForEnvironment() {
+	projectID := uuid.New()
+	run, err := s.repository.RunRepository.Create(context.Background(), &domain.CreateRunRequest{
+		ProjectID:   projectID,
+		Environment: "staging",
+	})
+	s.NoError(err)
+
+	run, err = s.repository.RunRepository.Get(context.Background(), run.ID)
+	s.NoError(err)
+	s.Equal("staging", run.Environment)
+}
+
 func (s *SQLiteIntegrationSuite) TestListRunsForProject() {
 	projectID := uuid.New()
 	_, err := s.repository.RunRepository.Create(context.Background(), &domain.CreateRunRequest{
@@ -73,15 +87,67 @@ func testScenarioDetails() []*domain.ScenarioRunDetails {
 	return []*domain.ScenarioRunDetails{
 		{
 			Name: "foo",
+			AssertionResults: []*domain.AssertionResult{
+				{
+					Type:      "duration",
+					Assertion: "lte",
+					Expected:  "500ms",
+					Actual:    "1s",
+					Success:   false,
+					Message:   "duration was 1s, expected less than or equal to 500ms",
+				},
+			},
 			Steps: []*domain.StepRunDetails{
 				{
-					Name:            "bar",
-					Assertions:      42,
+					Name:       "bar",
+					Assertions: 42,
+					AssertionResults: []*domain.AssertionResult{
+						{
+							Type:      "status",
+							Assertion: "equal",
+							Expected:  "200",
+							Actual:    "404",
+							Success:   false,
+							Message:   "status has value 404, expected 200",
+						},
+					},
 					Duration:        1.0,
 					Success:         true,
 					URL:             "https://example.com",
 					RequestDuration: 1.0,
-					Retries:         42,
+					Retries:         1,
+					Attempts: []*domain.AttemptResult{
+						{
+							Error: "connection refused",
+							Delay: 1.0,
+						},
+						{
+							Status:          200,
+							RequestDuration: 1.0,
+							Success:         true,
+						},
+					},
+				},
+			},
+			SetupSteps: []*domain.StepRunDetails{
+				{
+					Name:             "setup",
+					AssertionResults: []*domain.AssertionResult{},
+					Attempts:         []*domain.AttemptResult{},
+					URL:              "https://example.com",
+					Success:          true,
+				},
+			},
+			TeardownSteps: []*domain.StepRunDetails{},
+			SubScenarios: []*domain.ScenarioRunDetails{
+				{
+					Name:             "foo [user=admin]",
+					AssertionResults: []*domain.AssertionResult{},
+					SetupSteps:       []*domain.StepRunDetails{},
+					Steps:            []*domain.StepRunDetails{},
+					TeardownSteps:    []*domain.StepRunDetails{},
+					SubScenarios:     []*domain.ScenarioRunDetails{},
+					TimedOut:         true,
 				},
 			},
 			Duration:   1.0,
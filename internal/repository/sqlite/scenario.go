@@ -62,6 +62,7 @@ func (r *ScenarioRepository) GetForProject(ctx context.Context, getForProjectReq
 		Limit(getForProjectRequest.Limit).
 		Offset(getForProjectRequest.Limit*getForProjectRequest.Offset).
 		Where("project_id = ?", getForProjectRequest.ProjectID).
+		Order("name").
 		Find(&scenarios).Error
 	if err != nil {
 		return nil, err
@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+)
+
+const defaultSecretLimit = 100
+
+// Secret is the sqlite model for secrets.
+type Secret struct {
+	BaseModel
+	Name           string `gorm:"index:idx_secret_project_id_name_unique,unique"`
+	EncryptedValue []byte
+	ProjectID      uuid.UUID `gorm:"index:idx_secret_project_id_name_unique,unique"`
+}
+
+// SecretRepository is the sqlite repository for secrets.
+type SecretRepository struct {
+	conn *gorm.DB
+}
+
+// NewSecretRepository initialises the sqlite secret repository.
+func NewSecretRepository(conn *gorm.DB) *SecretRepository {
+	return &SecretRepository{
+		conn: conn,
+	}
+}
+
+// Create creates a new secret in sqlite.
+func (r *SecretRepository) Create(ctx context.Context, createSecretRequest *domain.CreateSecretRequest) (*domain.Secret, error) {
+	sqliteSecret := &Secret{
+		Name:           createSecretRequest.Name,
+		EncryptedValue: []byte(createSecretRequest.EncryptedValue),
+		ProjectID:      createSecretRequest.ProjectID,
+	}
+	err := r.conn.WithContext(ctx).Model(&Secret{}).Create(sqliteSecret).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, fmt.Errorf("%w %w", domain.ErrSecretAlreadyExists, err)
+	} else if err != nil {
+		return nil, err
+	}
+	return secretToDomainSecret(sqliteSecret), nil
+}
+
+// Update replaces the encrypted value of a secret in sqlite.
+func (r *SecretRepository) Update(ctx context.Context, updateSecretRequest *domain.UpdateSecretRequest) (*domain.Secret, error) {
+	secret := &Secret{}
+	err := r.conn.WithContext(ctx).
+		Model(&Secret{}).
+		Where("project_id = ? AND name = ?", updateSecretRequest.ProjectID, updateSecretRequest.Name).
+		First(secret).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w %w", domain.ErrSecretNotFound, err)
+	} else if err != nil {
+		return nil, err
+	}
+	secret.EncryptedValue = []byte(updateSecretRequest.EncryptedValue)
+	err = r.conn.WithContext(ctx).Model(&Secret{}).Where("id = ?", secret.ID).Save(secret).Error
+	if err != nil {
+		return nil, err
+	}
+	return secretToDomainSecret(secret), nil
+}
+
+// Delete deletes the secret of a project with a given name.
+func (r *SecretRepository) Delete(ctx context.Context, deleteSecretRequest *domain.DeleteSecretRequest) error {
+	// secrets are deleted permanently, such that their encrypted values are not retained.
+	result := r.conn.WithContext(ctx).
+		Unscoped().
+		Where("project_id = ? AND name = ?", deleteSecretRequest.ProjectID, deleteSecretRequest.Name).
+		Delete(&Secret{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSecretNotFound
+	}
+	return nil
+}
+
+// ListForProject returns the secrets of a given project.
+func (r *SecretRepository) ListForProject(ctx context.Context, listForProjectRequest *domain.ListSecretsForProjectRequest) ([]*domain.Secret, error) {
+	if listForProjectRequest.Limit == 0 {
+		listForProjectRequest.Limit = defaultSecretLimit
+	}
+	secrets := []*Secret{}
+	err := r.conn.WithContext(ctx).
+		Model(&Secret{}).
+		Limit(listForProjectRequest.Limit).
+		Offset(listForProjectRequest.Limit*listForProjectRequest.Offset).
+		Where("project_id = ?", listForProjectRequest.ProjectID).
+		Order("name").
+		Find(&secrets).Error
+	if err != nil {
+		return nil, err
+	}
+	result := []*domain.Secret{}
+	for _, secret := range secrets {
+		result = append(result, secretToDomainSecret(secret))
+	}
+	return result, nil
+}
+
+// GetAllForProject returns all secrets of a given project, without pagination, as
+// every secret of a project can be used by the scenarios of a run.
+func (r *SecretRepository) GetAllForProject(ctx context.Context, projectID uuid.UUID) ([]*domain.Secret, error) {
+	secrets := []*Secret{}
+	err := r.conn.WithContext(ctx).
+		Model(&Secret{}).
+		Where("project_id = ?", projectID).
+		Order("name").
+		Find(&secrets).Error
+	if err != nil {
+		return nil, err
+	}
+	result := []*domain.Secret{}
+	for _, secret := range secrets {
+		result = append(result, secretToDomainSecret(secret))
+	}
+	return result, nil
+}
+
+func secretToDomainSecret(secret *Secret) *domain.Secret {
+	return &domain.Secret{
+		ID:             secret.ID,
+		Name:           secret.Name,
+		EncryptedValue: string(secret.EncryptedValue),
+		ProjectID:      secret.ProjectID,
+	}
+}
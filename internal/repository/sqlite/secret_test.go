@@ -0,0 +1,108 @@
+//go:build integration
+
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+)
+
+func (s *SQLiteIntegrationSuite) TestCreateSecret() {
+	projectID := uuid.New()
+	secret, err := s.repository.SecretRepository.Create(context.Background(), &domain.CreateSecretRequest{
+		Name:           "token",
+		EncryptedValue: "\x00\xffencrypted",
+		ProjectID:      projectID,
+	})
+	s.NoError(err)
+	s.Equal("token", secret.Name)
+	s.Equal("\x00\xffencrypted", secret.EncryptedValue)
+	s.Equal(projectID, secret.ProjectID)
+
+	_, err = s.repository.SecretRepository.Create(context.Background(), &domain.CreateSecretRequest{
+		Name:      "token",
+		ProjectID: projectID,
+	})
+	s.ErrorIs(err, domain.ErrSecretAlreadyExists)
+}
+
+func (s *SQLiteIntegrationSuite) TestUpdateSecret() {
+	projectID := uuid.New()
+	secret, err := s.repository.SecretRepository.Create(context.Background(), &domain.CreateSecretRequest{
+		Name:           "token",
+		EncryptedValue: "encrypted",
+		ProjectID:      projectID,
+	})
+	s.NoError(err)
+
+	updated, err := s.repository.SecretRepository.Update(context.Background(), &domain.UpdateSecretRequest{
+		Name:           "token",
+		EncryptedValue: "updated",
+		ProjectID:      projectID,
+	})
+	s.NoError(err)
+	s.Equal(secret.ID, updated.ID)
+	s.Equal("updated", updated.EncryptedValue)
+
+	_, err = s.repository.SecretRepository.Update(context.Background(), &domain.UpdateSecretRequest{
+		Name:           "password",
+		EncryptedValue: "updated",
+		ProjectID:      projectID,
+	})
+	s.ErrorIs(err, domain.ErrSecretNotFound)
+}
+
+func (s *SQLiteIntegrationSuite) TestListAndDeleteSecrets() {
+	projectID := uuid.New()
+	for _, name := range []string{"token", "password"} {
+		_, err := s.repository.SecretRepository.Create(context.Background(), &domain.CreateSecretRequest{
+			Name:           name,
+			EncryptedValue: "encrypted",
+			ProjectID:      projectID,
+		})
+		s.NoError(err)
+	}
+
+	s.NoError(s.repository.SecretRepository.Delete(context.Background(), &domain.DeleteSecretRequest{
+		Name:      "token",
+		ProjectID: projectID,
+	}))
+	s.ErrorIs(s.repository.SecretRepository.Delete(context.Background(), &domain.DeleteSecretRequest{
+		Name:      "token",
+		ProjectID: projectID,
+	}), domain.ErrSecretNotFound)
+
+	secrets, err := s.repository.SecretRepository.ListForProject(context.Background(), &domain.ListSecretsForProjectRequest{
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+	s.Equal(1, len(secrets))
+	s.Equal("password", secrets[0].Name)
+}
+
+func (s *SQLiteIntegrationSuite) TestGetAllSecretsForProject() {
+	projectID := uuid.New()
+	for i := 0; i < defaultSecretLimit+1; i++ {
+		_, err := s.repository.SecretRepository.Create(context.Background(), &domain.CreateSecretRequest{
+			Name:           fmt.Sprintf("secret_%03d", i),
+			EncryptedValue: "encrypted",
+			ProjectID:      projectID,
+		})
+		s.NoError(err)
+	}
+
+	secrets, err := s.repository.SecretRepository.ListForProject(context.Background(), &domain.ListSecretsForProjectRequest{
+		ProjectID: projectID,
+	})
+	s.NoError(err)
+	s.Equal(defaultSecretLimit, len(secrets))
+
+	secrets, err = s.repository.SecretRepository.GetAllForProject(context.Background(), projectID)
+	s.NoError(err)
+	s.Equal(defaultSecretLimit+1, len(secrets))
+	s.Equal(fmt.Sprintf("secret_%03d", defaultSecretLimit), secrets[defaultSecretLimit].Name)
+}
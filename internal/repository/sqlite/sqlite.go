@@ -32,11 +32,13 @@ func (u *BaseModel) BeforeCreate(_ *gorm.DB) (err error) {
 
 // Repository is the sqlite repository.
 type Repository struct {
-	ProjectRepository  *ProjectRepository
-	ScenarioRepository *ScenarioRepository
-	RunRepository      *RunRepository
-	APIKeyRepository   *APIKeyRepository
-	UserRepository     *UserRepository
+	ProjectRepository     *ProjectRepository
+	ScenarioRepository    *ScenarioRepository
+	EnvironmentRepository *EnvironmentRepository
+	SecretRepository      *SecretRepository
+	RunRepository         *RunRepository
+	APIKeyRepository      *APIKeyRepository
+	UserRepository        *UserRepository
 }
 
 // NewRepository initialises the sqlite repository.
@@ -56,11 +58,13 @@ func NewRepository(dsn string, logger *slog.Logger, options *MigrationOptions) (
 	}
 
 	return &Repository{
-		ProjectRepository:  NewProjectRepository(db),
-		ScenarioRepository: NewScenarioRepository(db),
-		RunRepository:      NewRunRepository(db),
-		APIKeyRepository:   NewAPIKeyRepository(db),
-		UserRepository:     NewUserRepository(db),
+		ProjectRepository:     NewProjectRepository(db),
+		ScenarioRepository:    NewScenarioRepository(db),
+		EnvironmentRepository: NewEnvironmentRepository(db),
+		SecretRepository:      NewSecretRepository(db),
+		RunRepository:         NewRunRepository(db),
+		APIKeyRepository:      NewAPIKeyRepository(db),
+		UserRepository:        NewUserRepository(db),
 	}, nil
 }
 
@@ -86,6 +90,8 @@ func tableList() []any {
 	return []any{
 		&Project{},
 		&Scenario{},
+		&Environment{},
+		&Secret{},
 		&Run{},
 		&User{},
 		&APIKey{},
@@ -0,0 +1,109 @@
+// Package environment implements the environment service.
+package environment
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/repository"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	serviceOptions "github.com/inquiryproj/inquiry/internal/service/options"
+)
+
+// Environment is the environment service.
+type Environment struct {
+	environmentRepository repository.Environment
+	projectRepository     repository.Project
+
+	logger *slog.Logger
+}
+
+// NewService initialises the environment service.
+func NewService(environmentRepository repository.Environment, projectRepository repository.Project, opts ...serviceOptions.Opts) *Environment {
+	options := serviceOptions.DefaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &Environment{
+		environmentRepository: environmentRepository,
+		projectRepository:     projectRepository,
+		logger:                options.Logger,
+	}
+}
+
+// ListEnvironments returns all environments for a given project.
+func (s *Environment) ListEnvironments(ctx context.Context, listEnvironmentsRequest *app.ListEnvironmentsRequest) ([]*app.Environment, error) {
+	environments, err := s.environmentRepository.ListForProject(ctx, &domain.ListEnvironmentsForProjectRequest{
+		Limit:     listEnvironmentsRequest.Limit,
+		Offset:    listEnvironmentsRequest.Offset,
+		ProjectID: listEnvironmentsRequest.ProjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []*app.Environment{}
+	for _, environment := range environments {
+		result = append(result, environmentToAppEnvironment(environment))
+	}
+	return result, nil
+}
+
+// CreateEnvironment creates a new environment.
+func (s *Environment) CreateEnvironment(ctx context.Context, createEnvironmentRequest *app.CreateEnvironmentRequest) (*app.Environment, error) {
+	_, err := s.projectRepository.GetByID(ctx, createEnvironmentRequest.ProjectID)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		return nil, app.ErrProjectNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	environment, err := s.environmentRepository.Create(ctx, &domain.CreateEnvironmentRequest{
+		Name:      createEnvironmentRequest.Name,
+		Variables: createEnvironmentRequest.Variables,
+		ProjectID: createEnvironmentRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrEnvironmentAlreadyExists) {
+		return nil, app.ErrEnvironmentAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+	return environmentToAppEnvironment(environment), nil
+}
+
+// UpdateEnvironment replaces the variables of an environment.
+func (s *Environment) UpdateEnvironment(ctx context.Context, updateEnvironmentRequest *app.UpdateEnvironmentRequest) (*app.Environment, error) {
+	environment, err := s.environmentRepository.Update(ctx, &domain.UpdateEnvironmentRequest{
+		Name:      updateEnvironmentRequest.Name,
+		Variables: updateEnvironmentRequest.Variables,
+		ProjectID: updateEnvironmentRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrEnvironmentNotFound) {
+		return nil, app.ErrEnvironmentNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return environmentToAppEnvironment(environment), nil
+}
+
+// DeleteEnvironment deletes an environment.
+func (s *Environment) DeleteEnvironment(ctx context.Context, deleteEnvironmentRequest *app.DeleteEnvironmentRequest) error {
+	err := s.environmentRepository.Delete(ctx, &domain.DeleteEnvironmentRequest{
+		Name:      deleteEnvironmentRequest.Name,
+		ProjectID: deleteEnvironmentRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrEnvironmentNotFound) {
+		return app.ErrEnvironmentNotFound
+	}
+	return err
+}
+
+func environmentToAppEnvironment(environment *domain.Environment) *app.Environment {
+	return &app.Environment{
+		ID:        environment.ID,
+		Name:      environment.Name,
+		Variables: environment.Variables,
+		ProjectID: environment.ProjectID,
+	}
+}
@@ -0,0 +1,127 @@
+package environment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	repositoryMocks "github.com/inquiryproj/inquiry/internal/repository/mocks"
+)
+
+func TestCreateEnvironment(t *testing.T) {
+	projectID := uuid.New()
+	environmentID := uuid.New()
+	variables := map[string]string{"base_url": "https://staging.example.com"}
+	tests := []struct {
+		name                string
+		setupMocks          func(*repositoryMocks.Environment, *repositoryMocks.Project)
+		expectedEnvironment *app.Environment
+		expectedErr         error
+	}{
+		{
+			name: "success",
+			setupMocks: func(environmentRepositoryMock *repositoryMocks.Environment, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				environmentRepositoryMock.On("Create", mock.Anything, &domain.CreateEnvironmentRequest{
+					Name:      "staging",
+					Variables: variables,
+					ProjectID: projectID,
+				}).Return(&domain.Environment{ID: environmentID, Name: "staging", Variables: variables, ProjectID: projectID}, nil)
+			},
+			expectedEnvironment: &app.Environment{ID: environmentID, Name: "staging", Variables: variables, ProjectID: projectID},
+		},
+		{
+			name: "project not found",
+			setupMocks: func(environmentRepositoryMock *repositoryMocks.Environment, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(nil, domain.ErrProjectNotFound)
+			},
+			expectedErr: app.ErrProjectNotFound,
+		},
+		{
+			name: "environment already exists",
+			setupMocks: func(environmentRepositoryMock *repositoryMocks.Environment, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				environmentRepositoryMock.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrEnvironmentAlreadyExists)
+			},
+			expectedErr: app.ErrEnvironmentAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environmentRepositoryMock := repositoryMocks.NewEnvironment(t)
+			projectRepositoryMock := repositoryMocks.NewProject(t)
+			tt.setupMocks(environmentRepositoryMock, projectRepositoryMock)
+
+			s := NewService(environmentRepositoryMock, projectRepositoryMock)
+			environment, err := s.CreateEnvironment(context.Background(), &app.CreateEnvironmentRequest{
+				Name:      "staging",
+				Variables: variables,
+				ProjectID: projectID,
+			})
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedEnvironment, environment)
+		})
+	}
+}
+
+func TestUpdateEnvironment(t *testing.T) {
+	projectID := uuid.New()
+	environmentID := uuid.New()
+	variables := map[string]string{"base_url": "https://production.example.com"}
+	tests := []struct {
+		name                string
+		setupMocks          func(*repositoryMocks.Environment)
+		expectedEnvironment *app.Environment
+		expectedErr         error
+	}{
+		{
+			name: "success",
+			setupMocks: func(environmentRepositoryMock *repositoryMocks.Environment) {
+				environmentRepositoryMock.On("Update", mock.Anything, &domain.UpdateEnvironmentRequest{
+					Name:      "production",
+					Variables: variables,
+					ProjectID: projectID,
+				}).Return(&domain.Environment{ID: environmentID, Name: "production", Variables: variables, ProjectID: projectID}, nil)
+			},
+			expectedEnvironment: &app.Environment{ID: environmentID, Name: "production", Variables: variables, ProjectID: projectID},
+		},
+		{
+			name: "environment not found",
+			setupMocks: func(environmentRepositoryMock *repositoryMocks.Environment) {
+				environmentRepositoryMock.On("Update", mock.Anything, mock.Anything).Return(nil, domain.ErrEnvironmentNotFound)
+			},
+			expectedErr: app.ErrEnvironmentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			environmentRepositoryMock := repositoryMocks.NewEnvironment(t)
+			tt.setupMocks(environmentRepositoryMock)
+
+			s := NewService(environmentRepositoryMock, repositoryMocks.NewProject(t))
+			environment, err := s.UpdateEnvironment(context.Background(), &app.UpdateEnvironmentRequest{
+				Name:      "production",
+				Variables: variables,
+				ProjectID: projectID,
+			})
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedEnvironment, environment)
+		})
+	}
+}
+
+func TestDeleteEnvironment(t *testing.T) {
+	projectID := uuid.New()
+	environmentRepositoryMock := repositoryMocks.NewEnvironment(t)
+	environmentRepositoryMock.On("Delete", mock.Anything, &domain.DeleteEnvironmentRequest{Name: "staging", ProjectID: projectID}).
+		Return(domain.ErrEnvironmentNotFound)
+
+	s := NewService(environmentRepositoryMock, repositoryMocks.NewProject(t))
+	err := s.DeleteEnvironment(context.Background(), &app.DeleteEnvironmentRequest{Name: "staging", ProjectID: projectID})
+	assert.ErrorIs(t, err, app.ErrEnvironmentNotFound)
+}
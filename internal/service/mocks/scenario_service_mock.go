@@ -41,6 +41,32 @@ func (_m *Scenario) CreateScenario(ctx context.Context, createScenarioRequest *a
 	return r0, r1
 }
 
+// ImportScenarios provides a mock function with given fields: ctx, importScenariosRequest
+func (_m *Scenario) ImportScenarios(ctx context.Context, importScenariosRequest *app.ImportScenariosRequest) ([]*app.Scenario, error) {
+	ret := _m.Called(ctx, importScenariosRequest)
+
+	var r0 []*app.Scenario
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *app.ImportScenariosRequest) ([]*app.Scenario, error)); ok {
+		return rf(ctx, importScenariosRequest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *app.ImportScenariosRequest) []*app.Scenario); ok {
+		r0 = rf(ctx, importScenariosRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.Scenario)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *app.ImportScenariosRequest) error); ok {
+		r1 = rf(ctx, importScenariosRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListScenarios provides a mock function with given fields: ctx, listScenariosRequest
 func (_m *Scenario) ListScenarios(ctx context.Context, listScenariosRequest *app.ListScenariosRequest) ([]*app.Scenario, error) {
 	ret := _m.Called(ctx, listScenariosRequest)
@@ -67,6 +93,32 @@ func (_m *Scenario) ListScenarios(ctx context.Context, listScenariosRequest *app
 	return r0, r1
 }
 
+// ValidateScenario provides a mock function with given fields: ctx, validateScenarioRequest
+func (_m *Scenario) ValidateScenario(ctx context.Context, validateScenarioRequest *app.ValidateScenarioRequest) ([]*app.ScenarioIssue, error) {
+	ret := _m.Called(ctx, validateScenarioRequest)
+
+	var r0 []*app.ScenarioIssue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *app.ValidateScenarioRequest) ([]*app.ScenarioIssue, error)); ok {
+		return rf(ctx, validateScenarioRequest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *app.ValidateScenarioRequest) []*app.ScenarioIssue); ok {
+		r0 = rf(ctx, validateScenarioRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*app.ScenarioIssue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *app.ValidateScenarioRequest) error); ok {
+		r1 = rf(ctx, validateScenarioRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewScenario creates a new instance of Scenario. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewScenario(t interface {
@@ -17,10 +17,11 @@ import (
 
 // Runner is the runner service.
 type Runner struct {
-	projectRepository  repository.Project
-	scenarioRepository repository.Scenario
-	runRepository      repository.Run
-	runsProducer       events.Producer[uuid.UUID]
+	projectRepository     repository.Project
+	scenarioRepository    repository.Scenario
+	environmentRepository repository.Environment
+	runRepository         repository.Run
+	runsProducer          events.Producer[uuid.UUID]
 
 	logger *slog.Logger
 }
@@ -29,6 +30,7 @@ type Runner struct {
 func NewService(
 	projectRepository repository.Project,
 	scenarioRepository repository.Scenario,
+	environmentRepository repository.Environment,
 	runRepository repository.Run,
 	runsProducer events.Producer[uuid.UUID],
 	opts ...serviceOptions.Opts,
@@ -38,17 +40,18 @@ func NewService(
 		opt(options)
 	}
 	return &Runner{
-		projectRepository:  projectRepository,
-		scenarioRepository: scenarioRepository,
-		runRepository:      runRepository,
-		logger:             options.Logger,
-		runsProducer:       runsProducer,
+		projectRepository:     projectRepository,
+		scenarioRepository:    scenarioRepository,
+		environmentRepository: environmentRepository,
+		runRepository:         runRepository,
+		logger:                options.Logger,
+		runsProducer:          runsProducer,
 	}
 }
 
 // RunProject runs all scenarios for a given project.
 func (s *Runner) RunProject(ctx context.Context, runProjectRequest *app.RunProjectRequest) (*app.ProjectRunOutput, error) {
-	return s.runProjectForID(ctx, runProjectRequest.ProjectID)
+	return s.runProjectForID(ctx, runProjectRequest.ProjectID, runProjectRequest.Environment)
 }
 
 // RunProjectByName runs all scenarios for a given project with a given name.
@@ -61,12 +64,25 @@ func (s *Runner) RunProjectByName(ctx context.Context, run *app.RunProjectByName
 		return nil, err
 	}
 
-	return s.runProjectForID(ctx, project.ID)
+	return s.runProjectForID(ctx, project.ID, run.Environment)
 }
 
-func (s *Runner) runProjectForID(ctx context.Context, projectID uuid.UUID) (*app.ProjectRunOutput, error) {
+func (s *Runner) runProjectForID(ctx context.Context, projectID uuid.UUID, environment string) (*app.ProjectRunOutput, error) {
+	if environment != "" {
+		_, err := s.environmentRepository.GetByName(ctx, &domain.GetEnvironmentByNameRequest{
+			Name:      environment,
+			ProjectID: projectID,
+		})
+		if errors.Is(err, domain.ErrEnvironmentNotFound) {
+			return nil, app.ErrEnvironmentNotFound
+		} else if err != nil {
+			s.logger.Error("failed to get environment", slog.String("error", err.Error()))
+			return nil, err
+		}
+	}
 	run, err := s.runRepository.Create(ctx, &domain.CreateRunRequest{
-		ProjectID: projectID,
+		ProjectID:   projectID,
+		Environment: environment,
 	})
 	if err != nil {
 		s.logger.Error("failed to create run", slog.String("error", err.Error()))
@@ -78,10 +94,11 @@ func (s *Runner) runProjectForID(ctx context.Context, projectID uuid.UUID) (*app
 		return nil, err
 	}
 	return &app.ProjectRunOutput{
-		ID:        run.ID,
-		ProjectID: run.ProjectID,
-		State:     app.RunState(run.State),
-		Success:   false,
+		ID:          run.ID,
+		ProjectID:   run.ProjectID,
+		State:       app.RunState(run.State),
+		Environment: run.Environment,
+		Success:     false,
 	}, nil
 }
 
@@ -102,6 +119,7 @@ func (s *Runner) ListRunsForProject(ctx context.Context, listRunsForProjectReque
 			ProjectID:          run.ProjectID,
 			Success:            run.Success,
 			State:              app.RunState(run.State),
+			Environment:        run.Environment,
 			ScenarioRunDetails: scenarioRunDetailsToAppScenarioRunDetails(run.ScenarioRunDetails),
 		}
 	}
@@ -114,11 +132,16 @@ func scenarioRunDetailsToAppScenarioRunDetails(scenario []*domain.ScenarioRunDet
 	result := []*app.ScenarioRunDetails{}
 	for _, detail := range scenario {
 		result = append(result, &app.ScenarioRunDetails{
-			Name:       detail.Name,
-			Duration:   detail.Duration,
-			Assertions: detail.Assertions,
-			Steps:      stepsRunDetailsToAppStepRunDetails(detail.Steps),
-			Success:    detail.Success,
+			Name:             detail.Name,
+			Duration:         detail.Duration,
+			Assertions:       detail.Assertions,
+			AssertionResults: assertionResultsToAppAssertionResults(detail.AssertionResults),
+			SetupSteps:       stepsRunDetailsToAppStepRunDetails(detail.SetupSteps),
+			Steps:            stepsRunDetailsToAppStepRunDetails(detail.Steps),
+			TeardownSteps:    stepsRunDetailsToAppStepRunDetails(detail.TeardownSteps),
+			SubScenarios:     scenarioRunDetailsToAppScenarioRunDetails(detail.SubScenarios),
+			TimedOut:         detail.TimedOut,
+			Success:          detail.Success,
 		})
 	}
 	return result
@@ -128,13 +151,46 @@ func stepsRunDetailsToAppStepRunDetails(steps []*domain.StepRunDetails) []*app.S
 	result := []*app.StepRunDetails{}
 	for _, detail := range steps {
 		result = append(result, &app.StepRunDetails{
-			Name:            detail.Name,
-			Assertions:      detail.Assertions,
-			URL:             detail.URL,
-			RequestDuration: detail.RequestDuration,
-			Duration:        detail.Duration,
-			Retries:         detail.Retries,
-			Success:         detail.Success,
+			Name:             detail.Name,
+			Assertions:       detail.Assertions,
+			AssertionResults: assertionResultsToAppAssertionResults(detail.AssertionResults),
+			URL:              detail.URL,
+			RequestDuration:  detail.RequestDuration,
+			Duration:         detail.Duration,
+			Retries:          detail.Retries,
+			Attempts:         attemptResultsToAppAttemptResults(detail.Attempts),
+			Skipped:          detail.Skipped,
+			Success:          detail.Success,
+		})
+	}
+	return result
+}
+
+func attemptResultsToAppAttemptResults(attemptResults []*domain.AttemptResult) []*app.AttemptResult {
+	result := []*app.AttemptResult{}
+	for _, attemptResult := range attemptResults {
+		result = append(result, &app.AttemptResult{
+			Status:          attemptResult.Status,
+			RequestDuration: attemptResult.RequestDuration,
+			Delay:           attemptResult.Delay,
+			Success:         attemptResult.Success,
+			Error:           attemptResult.Error,
+		})
+	}
+	return result
+}
+
+func assertionResultsToAppAssertionResults(assertionResults []*domain.AssertionResult) []*app.AssertionResult {
+	result := []*app.AssertionResult{}
+	for _, assertionResult := range assertionResults {
+		result = append(result, &app.AssertionResult{
+			Type:      assertionResult.Type,
+			Key:       assertionResult.Key,
+			Assertion: assertionResult.Assertion,
+			Expected:  assertionResult.Expected,
+			Actual:    assertionResult.Actual,
+			Success:   assertionResult.Success,
+			Message:   assertionResult.Message,
 		})
 	}
 	return result
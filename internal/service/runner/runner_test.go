@@ -15,18 +15,20 @@ import (
 )
 
 type mockWrapper struct {
-	scenarioRepositoryMock *repositoryMocks.Scenario
-	projectRepositoryMock  *repositoryMocks.Project
-	runRepositoryMock      *repositoryMocks.Run
-	runProducerMock        *eventMocks.Producer[uuid.UUID]
+	scenarioRepositoryMock    *repositoryMocks.Scenario
+	environmentRepositoryMock *repositoryMocks.Environment
+	projectRepositoryMock     *repositoryMocks.Project
+	runRepositoryMock         *repositoryMocks.Run
+	runProducerMock           *eventMocks.Producer[uuid.UUID]
 }
 
 func newMockWrapper(t *testing.T) *mockWrapper {
 	return &mockWrapper{
-		scenarioRepositoryMock: repositoryMocks.NewScenario(t),
-		projectRepositoryMock:  repositoryMocks.NewProject(t),
-		runRepositoryMock:      repositoryMocks.NewRun(t),
-		runProducerMock:        eventMocks.NewProducer[uuid.UUID](t),
+		scenarioRepositoryMock:    repositoryMocks.NewScenario(t),
+		environmentRepositoryMock: repositoryMocks.NewEnvironment(t),
+		projectRepositoryMock:     repositoryMocks.NewProject(t),
+		runRepositoryMock:         repositoryMocks.NewRun(t),
+		runProducerMock:           eventMocks.NewProducer[uuid.UUID](t),
 	}
 }
 
@@ -76,7 +78,20 @@ func TestListRunsForProject(t *testing.T) {
 				assert.Equal(t, 1, res.Runs[0].ScenarioRunDetails[0].Steps[0].Assertions)
 				assert.Equal(t, "http://localhost:8080", res.Runs[0].ScenarioRunDetails[0].Steps[0].URL)
 				assert.Equal(t, 1, res.Runs[0].ScenarioRunDetails[0].Steps[0].Retries)
+				assert.Equal(t, []*app.AttemptResult{
+					{Status: 500, RequestDuration: 1, Delay: 1},
+					{Status: 200, RequestDuration: 1, Success: true},
+				}, res.Runs[0].ScenarioRunDetails[0].Steps[0].Attempts)
 				assert.Equal(t, true, res.Runs[0].ScenarioRunDetails[0].Steps[0].Success)
+				assert.Equal(t, []*app.AssertionResult{
+					{
+						Type:      "body",
+						Key:       "id",
+						Assertion: "not_empty",
+						Actual:    "42",
+						Success:   true,
+					},
+				}, res.Runs[0].ScenarioRunDetails[0].Steps[0].AssertionResults)
 			},
 		},
 		{
@@ -188,6 +203,74 @@ func TestRunProject(t *testing.T) {
 				assert.Equal(t, false, res.Success)
 			},
 		},
+		{
+			name: "success with environment",
+			runProjectRequest: &app.RunProjectRequest{
+				ProjectID:   projectID,
+				Environment: "staging",
+			},
+			setupMocks: func(wrapper *mockWrapper) {
+				wrapper.environmentRepositoryMock.On("GetByName", mock.Anything,
+					&domain.GetEnvironmentByNameRequest{
+						Name:      "staging",
+						ProjectID: projectID,
+					}).
+					Return(&domain.Environment{
+						Name:      "staging",
+						ProjectID: projectID,
+					}, nil)
+				wrapper.runRepositoryMock.On("Create", mock.Anything,
+					&domain.CreateRunRequest{
+						ProjectID:   projectID,
+						Environment: "staging",
+					}).
+					Return(&domain.Run{
+						ID:          runID,
+						ProjectID:   projectID,
+						State:       domain.RunStatePending,
+						Environment: "staging",
+					}, nil)
+				wrapper.runProducerMock.On("Produce", mock.Anything, runID).Return(nil)
+			},
+			validateOutput: func(t *testing.T, res *app.ProjectRunOutput, err error) {
+				assert.NoError(t, err)
+
+				assert.Equal(t, runID, res.ID)
+				assert.Equal(t, "staging", res.Environment)
+			},
+		},
+		{
+			name: "environment not found",
+			runProjectRequest: &app.RunProjectRequest{
+				ProjectID:   projectID,
+				Environment: "staging",
+			},
+			setupMocks: func(wrapper *mockWrapper) {
+				wrapper.environmentRepositoryMock.On("GetByName", mock.Anything,
+					&domain.GetEnvironmentByNameRequest{
+						Name:      "staging",
+						ProjectID: projectID,
+					}).
+					Return(nil, domain.ErrEnvironmentNotFound)
+			},
+			validateOutput: func(t *testing.T, res *app.ProjectRunOutput, err error) {
+				assert.ErrorIs(t, err, app.ErrEnvironmentNotFound)
+			},
+		},
+		{
+			name: "unable to get environment",
+			runProjectRequest: &app.RunProjectRequest{
+				ProjectID:   projectID,
+				Environment: "staging",
+			},
+			setupMocks: func(wrapper *mockWrapper) {
+				wrapper.environmentRepositoryMock.On("GetByName", mock.Anything, mock.Anything).
+					Return(nil, assert.AnError)
+			},
+			validateOutput: func(t *testing.T, res *app.ProjectRunOutput, err error) {
+				assert.ErrorIs(t, err, assert.AnError)
+			},
+		},
 		{
 			name: "unable to produce",
 			setupMocks: func(wrapper *mockWrapper) {
@@ -354,6 +437,7 @@ func newRunnerService(mockWrapper *mockWrapper) *Runner {
 	return NewService(
 		mockWrapper.projectRepositoryMock,
 		mockWrapper.scenarioRepositoryMock,
+		mockWrapper.environmentRepositoryMock,
 		mockWrapper.runRepositoryMock,
 		mockWrapper.runProducerMock,
 	)
@@ -366,13 +450,26 @@ func testScenarioDetails() *domain.ScenarioRunDetails {
 		Assertions: 1,
 		Steps: []*domain.StepRunDetails{
 			{
-				Name:            "step 1",
-				Assertions:      1,
+				Name:       "step 1",
+				Assertions: 1,
+				AssertionResults: []*domain.AssertionResult{
+					{
+						Type:      "body",
+						Key:       "id",
+						Assertion: "not_empty",
+						Actual:    "42",
+						Success:   true,
+					},
+				},
 				URL:             "http://localhost:8080",
 				RequestDuration: 1,
 				Duration:        1,
 				Retries:         1,
-				Success:         true,
+				Attempts: []*domain.AttemptResult{
+					{Status: 500, RequestDuration: 1, Delay: 1},
+					{Status: 200, RequestDuration: 1, Success: true},
+				},
+				Success: true,
 			},
 		},
 		Success: true,
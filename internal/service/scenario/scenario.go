@@ -3,15 +3,24 @@ package scenario
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
 
 	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/executor/importer"
+	"github.com/inquiryproj/inquiry/internal/executor/yaml"
 	"github.com/inquiryproj/inquiry/internal/repository"
 	"github.com/inquiryproj/inquiry/internal/repository/domain"
 	serviceOptions "github.com/inquiryproj/inquiry/internal/service/options"
 )
 
+const scenarioNamesPageSize = 100
+
 // Scenario is the scenario service.
 type Scenario struct {
 	scenarioRepository repository.Scenario
@@ -53,12 +62,12 @@ func (s *Scenario) ListScenarios(ctx context.Context, listScenariosRequest *app.
 
 // CreateScenario creates a new scenario.
 func (s *Scenario) CreateScenario(ctx context.Context, createScenarioRequest *app.CreateScenarioRequest) (*app.Scenario, error) {
-	// FIXME validate spec
-	// Validate payload
-	_, err := s.projectRepository.GetByID(ctx, createScenarioRequest.ProjectID)
-	if errors.Is(err, domain.ErrProjectNotFound) {
-		return nil, app.ErrProjectNotFound
-	} else if err != nil {
+	err := checkSpec(createScenarioRequest.SpecType, createScenarioRequest.Spec)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkProject(ctx, createScenarioRequest.ProjectID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -76,6 +85,156 @@ func (s *Scenario) CreateScenario(ctx context.Context, createScenarioRequest *ap
 	return scenarioToAppScenario(scenario), nil
 }
 
+// ImportScenarios converts the requests of a HAR file or a Postman collection to yaml scenarios
+// and creates them. All specs and names are checked before the first scenario is created.
+func (s *Scenario) ImportScenarios(ctx context.Context, importScenariosRequest *app.ImportScenariosRequest) ([]*app.Scenario, error) {
+	createScenarioRequests, err := importedScenarios(importScenariosRequest)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkProject(ctx, importScenariosRequest.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkNames(ctx, importScenariosRequest.ProjectID, createScenarioRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*app.Scenario, len(createScenarioRequests))
+	for i, createScenarioRequest := range createScenarioRequests {
+		result[i], err = s.CreateScenario(ctx, createScenarioRequest)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", createScenarioRequest.Name, err)
+		}
+	}
+	return result, nil
+}
+
+// importedScenarios converts the imported data to requests for creating yaml scenarios, the specs of
+// which are checked.
+func importedScenarios(importScenariosRequest *app.ImportScenariosRequest) ([]*app.CreateScenarioRequest, error) {
+	scenarios, err := importer.Import(importer.Format(importScenariosRequest.Format), importScenariosRequest.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", app.ErrInvalidImport, err)
+	}
+	createScenarioRequests := make([]*app.CreateScenarioRequest, len(scenarios))
+	for i, scenario := range scenarios {
+		spec, err := scenario.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal scenario %s: %w", scenario.Name, err)
+		}
+		createScenarioRequests[i] = &app.CreateScenarioRequest{
+			Name:      scenario.Name,
+			SpecType:  app.ScenarioSpecTypeYAML,
+			Spec:      base64.StdEncoding.EncodeToString(spec),
+			ProjectID: importScenariosRequest.ProjectID,
+		}
+		err = checkSpec(app.ScenarioSpecTypeYAML, createScenarioRequests[i].Spec)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", scenario.Name, err)
+		}
+	}
+	return createScenarioRequests, nil
+}
+
+// checkNames returns an error if the name of a scenario is used more than once or by
+// an existing scenario of the project.
+func (s *Scenario) checkNames(ctx context.Context, projectID uuid.UUID, createScenarioRequests []*app.CreateScenarioRequest) error {
+	names, err := s.scenarioNames(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, createScenarioRequest := range createScenarioRequests {
+		if names[createScenarioRequest.Name] {
+			return fmt.Errorf("%w: %s", app.ErrScenarioAlreadyExists, createScenarioRequest.Name)
+		}
+		names[createScenarioRequest.Name] = true
+	}
+	return nil
+}
+
+// scenarioNames returns the names of all scenarios of a project.
+func (s *Scenario) scenarioNames(ctx context.Context, projectID uuid.UUID) (map[string]bool, error) {
+	names := map[string]bool{}
+	for page := 0; ; page++ {
+		scenarios, err := s.scenarioRepository.GetForProject(ctx, &domain.GetScenariosForProjectRequest{
+			Limit:     scenarioNamesPageSize,
+			Offset:    page,
+			ProjectID: projectID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, scenario := range scenarios {
+			names[scenario.Name] = true
+		}
+		if len(scenarios) < scenarioNamesPageSize {
+			return names, nil
+		}
+	}
+}
+
+// checkProject returns an error if the project does not exist.
+func (s *Scenario) checkProject(ctx context.Context, projectID uuid.UUID) error {
+	_, err := s.projectRepository.GetByID(ctx, projectID)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		return app.ErrProjectNotFound
+	}
+	return err
+}
+
+// checkSpec returns an error with all issues of the spec which are no warnings, if there are any.
+func checkSpec(specType app.ScenarioSpecType, spec string) error {
+	issues, err := validateSpec(specType, spec)
+	if err != nil {
+		return err
+	}
+	issues = yaml.Errors(issues)
+	if len(issues) > 0 {
+		messages := make([]string, len(issues))
+		for i, issue := range issues {
+			messages[i] = issue.String()
+		}
+		return fmt.Errorf("%w: %s", app.ErrInvalidScenarioSpec, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// ValidateScenario returns the issues of the spec of a scenario or fragment.
+func (s *Scenario) ValidateScenario(_ context.Context, validateScenarioRequest *app.ValidateScenarioRequest) ([]*app.ScenarioIssue, error) {
+	issues, err := validateSpec(validateScenarioRequest.SpecType, validateScenarioRequest.Spec)
+	if err != nil {
+		return nil, err
+	}
+	result := []*app.ScenarioIssue{}
+	for _, issue := range issues {
+		result = append(result, &app.ScenarioIssue{
+			Line:    issue.Line,
+			Column:  issue.Column,
+			Message: issue.Message,
+			Warning: issue.Warning,
+		})
+	}
+	return result, nil
+}
+
+// validateSpec decodes the base64 encoded spec and lints it, if it is a scenario or a fragment.
+func validateSpec(specType app.ScenarioSpecType, spec string) ([]*yaml.Issue, error) {
+	data, err := base64.StdEncoding.DecodeString(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: spec must be base64 encoded", app.ErrInvalidScenarioSpec)
+	}
+	switch specType {
+	case app.ScenarioSpecTypeYAML:
+		return yaml.Lint(data), nil
+	case app.ScenarioSpecTypeFragment:
+		return yaml.LintFragment(data), nil
+	default:
+		return nil, nil
+	}
+}
+
 func scenarioToAppScenario(scenario *domain.Scenario) *app.Scenario {
 	return &app.Scenario{
 		ID:        scenario.ID,
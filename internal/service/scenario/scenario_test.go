@@ -0,0 +1,144 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	repositoryMocks "github.com/inquiryproj/inquiry/internal/repository/mocks"
+)
+
+// harPages returns a HAR file with a page of the given title for every title, which is imported as a scenario.
+func harPages(titles ...string) []byte {
+	pages := []string{}
+	entries := []string{}
+	for i, title := range titles {
+		pages = append(pages, fmt.Sprintf(`{"id": "page_%d", "title": %q}`, i, title))
+		entries = append(entries, fmt.Sprintf(`{"pageref": "page_%d", "request": {"method": "GET", "url": "http://localhost/%d"}, "response": {"status": 200}}`, i, i))
+	}
+	return []byte(fmt.Sprintf(`{"log": {"pages": [%s], "entries": [%s]}}`, strings.Join(pages, ", "), strings.Join(entries, ", ")))
+}
+
+func TestImportScenarios(t *testing.T) {
+	projectID := uuid.New()
+	tests := []struct {
+		name       string
+		format     app.ImportFormat
+		data       []byte
+		setupMocks func(scenarioRepositoryMock *repositoryMocks.Scenario, projectRepositoryMock *repositoryMocks.Project)
+		expectErr  error
+		errMessage string
+	}{
+		{
+			name:   "success",
+			format: app.ImportFormatHAR,
+			data:   harPages("first", "second"),
+			setupMocks: func(scenarioRepositoryMock *repositoryMocks.Scenario, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				scenarioRepositoryMock.On("GetForProject", mock.Anything, mock.Anything).Return([]*domain.Scenario{{Name: "existing"}}, nil)
+				for _, name := range []string{"first", "second"} {
+					name := name
+					scenarioRepositoryMock.On("Create", mock.Anything, mock.MatchedBy(func(request *domain.CreateScenarioRequest) bool {
+						return request.Name == name && request.SpecType == domain.ScenarioSpecTypeYAML && request.ProjectID == projectID
+					})).Return(&domain.Scenario{Name: name}, nil).Once()
+				}
+			},
+		},
+		{
+			name:       "unknown format",
+			format:     app.ImportFormat("insomnia"),
+			data:       harPages("first"),
+			setupMocks: func(*repositoryMocks.Scenario, *repositoryMocks.Project) {},
+			expectErr:  app.ErrInvalidImport,
+		},
+		{
+			name:       "invalid data",
+			format:     app.ImportFormatPostman,
+			data:       harPages("first"),
+			setupMocks: func(*repositoryMocks.Scenario, *repositoryMocks.Project) {},
+			expectErr:  app.ErrInvalidImport,
+			errMessage: "invalid import: invalid Postman collection: info is missing",
+		},
+		{
+			name:   "project not found",
+			format: app.ImportFormatHAR,
+			data:   harPages("first"),
+			setupMocks: func(scenarioRepositoryMock *repositoryMocks.Scenario, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(nil, domain.ErrProjectNotFound)
+			},
+			expectErr: app.ErrProjectNotFound,
+		},
+		{
+			name:   "conflict with an existing scenario partway",
+			format: app.ImportFormatHAR,
+			data:   harPages("first", "existing", "last"),
+			setupMocks: func(scenarioRepositoryMock *repositoryMocks.Scenario, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				scenarioRepositoryMock.On("GetForProject", mock.Anything, mock.Anything).Return([]*domain.Scenario{{Name: "existing"}}, nil)
+			},
+			expectErr:  app.ErrScenarioAlreadyExists,
+			errMessage: "scenario already exists: existing",
+		},
+		{
+			name:   "conflict when creating a scenario",
+			format: app.ImportFormatHAR,
+			data:   harPages("first", "second"),
+			setupMocks: func(scenarioRepositoryMock *repositoryMocks.Scenario, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				scenarioRepositoryMock.On("GetForProject", mock.Anything, mock.Anything).Return([]*domain.Scenario{}, nil)
+				scenarioRepositoryMock.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrScenarioAlreadyExists).Once()
+			},
+			expectErr:  app.ErrScenarioAlreadyExists,
+			errMessage: "scenario first: scenario already exists",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioRepositoryMock := repositoryMocks.NewScenario(t)
+			projectRepositoryMock := repositoryMocks.NewProject(t)
+			tt.setupMocks(scenarioRepositoryMock, projectRepositoryMock)
+
+			scenarios, err := NewService(scenarioRepositoryMock, projectRepositoryMock).ImportScenarios(context.Background(), &app.ImportScenariosRequest{
+				ProjectID: projectID,
+				Format:    tt.format,
+				Data:      tt.data,
+			})
+			if tt.expectErr != nil {
+				assert.ErrorIs(t, err, tt.expectErr)
+				if tt.errMessage != "" {
+					assert.EqualError(t, err, tt.errMessage)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, scenarios, 2)
+		})
+	}
+}
+
+func TestScenarioNamesPages(t *testing.T) {
+	projectID := uuid.New()
+	scenarioRepositoryMock := repositoryMocks.NewScenario(t)
+	page := make([]*domain.Scenario, scenarioNamesPageSize)
+	for i := range page {
+		page[i] = &domain.Scenario{Name: uuid.NewString()}
+	}
+	scenarioRepositoryMock.On("GetForProject", mock.Anything, &domain.GetScenariosForProjectRequest{
+		Limit: scenarioNamesPageSize, Offset: 0, ProjectID: projectID,
+	}).Return(page, nil)
+	scenarioRepositoryMock.On("GetForProject", mock.Anything, &domain.GetScenariosForProjectRequest{
+		Limit: scenarioNamesPageSize, Offset: 1, ProjectID: projectID,
+	}).Return([]*domain.Scenario{{Name: "last"}}, nil)
+
+	names, err := NewService(scenarioRepositoryMock, repositoryMocks.NewProject(t)).scenarioNames(context.Background(), projectID)
+	assert.NoError(t, err)
+	assert.Len(t, names, scenarioNamesPageSize+1)
+	assert.True(t, names["last"])
+}
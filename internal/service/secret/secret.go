@@ -0,0 +1,127 @@
+// Package secret implements the secret service.
+package secret
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/repository"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	serviceOptions "github.com/inquiryproj/inquiry/internal/service/options"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
+)
+
+// Secret is the secret service.
+type Secret struct {
+	secretRepository  repository.Secret
+	projectRepository repository.Project
+	secretsCipher     crypto.AESCipher
+
+	logger *slog.Logger
+}
+
+// NewService initialises the secret service. The values of secrets are encrypted with
+// the given cipher, secrets are disabled if the cipher is nil.
+func NewService(secretRepository repository.Secret, projectRepository repository.Project, secretsCipher crypto.AESCipher, opts ...serviceOptions.Opts) *Secret {
+	options := serviceOptions.DefaultOptions()
+	for _, opt := range opts {
+		opt(options)
+	}
+	return &Secret{
+		secretRepository:  secretRepository,
+		projectRepository: projectRepository,
+		secretsCipher:     secretsCipher,
+		logger:            options.Logger,
+	}
+}
+
+// ListSecrets returns all secrets for a given project.
+func (s *Secret) ListSecrets(ctx context.Context, listSecretsRequest *app.ListSecretsRequest) ([]*app.Secret, error) {
+	secrets, err := s.secretRepository.ListForProject(ctx, &domain.ListSecretsForProjectRequest{
+		Limit:     listSecretsRequest.Limit,
+		Offset:    listSecretsRequest.Offset,
+		ProjectID: listSecretsRequest.ProjectID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := []*app.Secret{}
+	for _, secret := range secrets {
+		result = append(result, secretToAppSecret(secret))
+	}
+	return result, nil
+}
+
+// CreateSecret encrypts and stores a new secret.
+func (s *Secret) CreateSecret(ctx context.Context, createSecretRequest *app.CreateSecretRequest) (*app.Secret, error) {
+	_, err := s.projectRepository.GetByID(ctx, createSecretRequest.ProjectID)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		return nil, app.ErrProjectNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	encryptedValue, err := s.encrypt(createSecretRequest.Value)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.secretRepository.Create(ctx, &domain.CreateSecretRequest{
+		Name:           createSecretRequest.Name,
+		EncryptedValue: encryptedValue,
+		ProjectID:      createSecretRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrSecretAlreadyExists) {
+		return nil, app.ErrSecretAlreadyExists
+	} else if err != nil {
+		return nil, err
+	}
+	return secretToAppSecret(secret), nil
+}
+
+// UpdateSecret encrypts and replaces the value of a secret.
+func (s *Secret) UpdateSecret(ctx context.Context, updateSecretRequest *app.UpdateSecretRequest) (*app.Secret, error) {
+	encryptedValue, err := s.encrypt(updateSecretRequest.Value)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.secretRepository.Update(ctx, &domain.UpdateSecretRequest{
+		Name:           updateSecretRequest.Name,
+		EncryptedValue: encryptedValue,
+		ProjectID:      updateSecretRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrSecretNotFound) {
+		return nil, app.ErrSecretNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return secretToAppSecret(secret), nil
+}
+
+// DeleteSecret deletes a secret.
+func (s *Secret) DeleteSecret(ctx context.Context, deleteSecretRequest *app.DeleteSecretRequest) error {
+	err := s.secretRepository.Delete(ctx, &domain.DeleteSecretRequest{
+		Name:      deleteSecretRequest.Name,
+		ProjectID: deleteSecretRequest.ProjectID,
+	})
+	if errors.Is(err, domain.ErrSecretNotFound) {
+		return app.ErrSecretNotFound
+	}
+	return err
+}
+
+func (s *Secret) encrypt(value string) (string, error) {
+	if s.secretsCipher == nil {
+		return "", app.ErrSecretsDisabled
+	}
+	return s.secretsCipher.Encrypt(value)
+}
+
+func secretToAppSecret(secret *domain.Secret) *app.Secret {
+	return &app.Secret{
+		ID:        secret.ID,
+		Name:      secret.Name,
+		ProjectID: secret.ProjectID,
+	}
+}
@@ -0,0 +1,177 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/inquiryproj/inquiry/internal/app"
+	"github.com/inquiryproj/inquiry/internal/repository/domain"
+	repositoryMocks "github.com/inquiryproj/inquiry/internal/repository/mocks"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
+)
+
+const (
+	masterKey      = "0123456789abcdef0123456789abcdef"
+	wrongMasterKey = "fedcba9876543210fedcba9876543210"
+)
+
+// encryptedWith matches encrypted values which decrypt to the value with the master key.
+func encryptedWith(value string) func(string) bool {
+	return func(encryptedValue string) bool {
+		decrypted, err := crypto.NewAESCipher(masterKey).Decrypt(encryptedValue)
+		return err == nil && decrypted == value && encryptedValue != value
+	}
+}
+
+func TestCreateSecret(t *testing.T) {
+	projectID := uuid.New()
+	secretID := uuid.New()
+	tests := []struct {
+		name           string
+		secretsCipher  crypto.AESCipher
+		setupMocks     func(*repositoryMocks.Secret, *repositoryMocks.Project)
+		expectedSecret *app.Secret
+		expectedErr    error
+	}{
+		{
+			name:          "success",
+			secretsCipher: crypto.NewAESCipher(masterKey),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				secretRepositoryMock.On("Create", mock.Anything, mock.MatchedBy(func(req *domain.CreateSecretRequest) bool {
+					return req.Name == "token" && req.ProjectID == projectID && encryptedWith("s3cr3t")(req.EncryptedValue)
+				})).Return(&domain.Secret{ID: secretID, Name: "token", EncryptedValue: "encrypted", ProjectID: projectID}, nil)
+			},
+			expectedSecret: &app.Secret{ID: secretID, Name: "token", ProjectID: projectID},
+		},
+		{
+			name: "secrets disabled",
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+			},
+			expectedErr: app.ErrSecretsDisabled,
+		},
+		{
+			name:          "invalid master key",
+			secretsCipher: crypto.NewAESCipher("short"),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+			},
+			expectedErr: fmt.Errorf("crypto/aes: invalid key size 5"),
+		},
+		{
+			name:          "project not found",
+			secretsCipher: crypto.NewAESCipher(masterKey),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(nil, domain.ErrProjectNotFound)
+			},
+			expectedErr: app.ErrProjectNotFound,
+		},
+		{
+			name:          "secret already exists",
+			secretsCipher: crypto.NewAESCipher(masterKey),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret, projectRepositoryMock *repositoryMocks.Project) {
+				projectRepositoryMock.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: projectID}, nil)
+				secretRepositoryMock.On("Create", mock.Anything, mock.Anything).Return(nil, domain.ErrSecretAlreadyExists)
+			},
+			expectedErr: app.ErrSecretAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretRepositoryMock := repositoryMocks.NewSecret(t)
+			projectRepositoryMock := repositoryMocks.NewProject(t)
+			tt.setupMocks(secretRepositoryMock, projectRepositoryMock)
+
+			s := NewService(secretRepositoryMock, projectRepositoryMock, tt.secretsCipher)
+			secret, err := s.CreateSecret(context.Background(), &app.CreateSecretRequest{
+				Name:      "token",
+				Value:     "s3cr3t",
+				ProjectID: projectID,
+			})
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedSecret, secret)
+		})
+	}
+}
+
+func TestUpdateSecret(t *testing.T) {
+	projectID := uuid.New()
+	secretID := uuid.New()
+	tests := []struct {
+		name           string
+		secretsCipher  crypto.AESCipher
+		setupMocks     func(*repositoryMocks.Secret)
+		expectedSecret *app.Secret
+		expectedErr    error
+	}{
+		{
+			name:          "success",
+			secretsCipher: crypto.NewAESCipher(masterKey),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret) {
+				secretRepositoryMock.On("Update", mock.Anything, mock.MatchedBy(func(req *domain.UpdateSecretRequest) bool {
+					return req.Name == "token" && req.ProjectID == projectID && encryptedWith("n3w")(req.EncryptedValue)
+				})).Return(&domain.Secret{ID: secretID, Name: "token", ProjectID: projectID}, nil)
+			},
+			expectedSecret: &app.Secret{ID: secretID, Name: "token", ProjectID: projectID},
+		},
+		{
+			name:        "secrets disabled",
+			setupMocks:  func(secretRepositoryMock *repositoryMocks.Secret) {},
+			expectedErr: app.ErrSecretsDisabled,
+		},
+		{
+			name:          "secret not found",
+			secretsCipher: crypto.NewAESCipher(masterKey),
+			setupMocks: func(secretRepositoryMock *repositoryMocks.Secret) {
+				secretRepositoryMock.On("Update", mock.Anything, mock.Anything).Return(nil, domain.ErrSecretNotFound)
+			},
+			expectedErr: app.ErrSecretNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secretRepositoryMock := repositoryMocks.NewSecret(t)
+			tt.setupMocks(secretRepositoryMock)
+
+			s := NewService(secretRepositoryMock, repositoryMocks.NewProject(t), tt.secretsCipher)
+			secret, err := s.UpdateSecret(context.Background(), &app.UpdateSecretRequest{
+				Name:      "token",
+				Value:     "n3w",
+				ProjectID: projectID,
+			})
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expectedSecret, secret)
+		})
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	s := NewService(repositoryMocks.NewSecret(t), repositoryMocks.NewProject(t), crypto.NewAESCipher(masterKey))
+
+	first, err := s.encrypt("s3cr3t")
+	assert.NoError(t, err)
+	second, err := s.encrypt("s3cr3t")
+	assert.NoError(t, err)
+	assert.NotContains(t, first, "s3cr3t")
+	// every encryption uses a new nonce.
+	assert.NotEqual(t, first, second)
+
+	for _, encryptedValue := range []string{first, second} {
+		value, err := crypto.NewAESCipher(masterKey).Decrypt(encryptedValue)
+		assert.NoError(t, err)
+		assert.Equal(t, "s3cr3t", value)
+
+		_, err = crypto.NewAESCipher(wrongMasterKey).Decrypt(encryptedValue)
+		assert.EqualError(t, err, "cipher: message authentication failed")
+	}
+}
@@ -9,16 +9,21 @@ import (
 	"github.com/inquiryproj/inquiry/internal/app"
 	"github.com/inquiryproj/inquiry/internal/events"
 	"github.com/inquiryproj/inquiry/internal/repository"
+	"github.com/inquiryproj/inquiry/internal/service/environment"
 	"github.com/inquiryproj/inquiry/internal/service/options"
 	"github.com/inquiryproj/inquiry/internal/service/project"
 	"github.com/inquiryproj/inquiry/internal/service/runner"
 	"github.com/inquiryproj/inquiry/internal/service/scenario"
+	"github.com/inquiryproj/inquiry/internal/service/secret"
+	"github.com/inquiryproj/inquiry/pkg/crypto"
 )
 
 // Wrapper wraps all services.
 type Wrapper interface {
 	Project
 	Scenario
+	Environment
+	Secret
 	Runner
 }
 
@@ -32,6 +37,24 @@ type Project interface {
 type Scenario interface {
 	ListScenarios(ctx context.Context, listScenariosRequest *app.ListScenariosRequest) ([]*app.Scenario, error)
 	CreateScenario(ctx context.Context, createScenarioRequest *app.CreateScenarioRequest) (*app.Scenario, error)
+	ImportScenarios(ctx context.Context, importScenariosRequest *app.ImportScenariosRequest) ([]*app.Scenario, error)
+	ValidateScenario(ctx context.Context, validateScenarioRequest *app.ValidateScenarioRequest) ([]*app.ScenarioIssue, error)
+}
+
+// Environment is the environment service.
+type Environment interface {
+	ListEnvironments(ctx context.Context, listEnvironmentsRequest *app.ListEnvironmentsRequest) ([]*app.Environment, error)
+	CreateEnvironment(ctx context.Context, createEnvironmentRequest *app.CreateEnvironmentRequest) (*app.Environment, error)
+	UpdateEnvironment(ctx context.Context, updateEnvironmentRequest *app.UpdateEnvironmentRequest) (*app.Environment, error)
+	DeleteEnvironment(ctx context.Context, deleteEnvironmentRequest *app.DeleteEnvironmentRequest) error
+}
+
+// Secret is the secret service.
+type Secret interface {
+	ListSecrets(ctx context.Context, listSecretsRequest *app.ListSecretsRequest) ([]*app.Secret, error)
+	CreateSecret(ctx context.Context, createSecretRequest *app.CreateSecretRequest) (*app.Secret, error)
+	UpdateSecret(ctx context.Context, updateSecretRequest *app.UpdateSecretRequest) (*app.Secret, error)
+	DeleteSecret(ctx context.Context, deleteSecretRequest *app.DeleteSecretRequest) error
 }
 
 // Runner is the runner service.
@@ -41,19 +64,25 @@ type Runner interface {
 	ListRunsForProject(ctx context.Context, listRunsForProjectRequest *app.ListRunsForProjectRequest) (*app.ListRunsForProjectResponse, error)
 }
 
-// NewServiceWrapper initialises all services.
+// NewServiceWrapper initialises all services, the values of secrets are
+// encrypted with the secrets cipher, secrets are disabled if it is nil.
 func NewServiceWrapper(
 	repositoryWrapper *repository.Wrapper,
 	runsProducer events.Producer[uuid.UUID],
+	secretsCipher crypto.AESCipher,
 	opts ...options.Opts,
 ) Wrapper {
 	return &struct {
 		*project.Project
 		*scenario.Scenario
+		*environment.Environment
+		*secret.Secret
 		*runner.Runner
 	}{
 		project.NewService(repositoryWrapper.Project, opts...),
 		scenario.NewService(repositoryWrapper.Scenario, repositoryWrapper.Project, opts...),
-		runner.NewService(repositoryWrapper.Project, repositoryWrapper.Scenario, repositoryWrapper.Run, runsProducer, opts...),
+		environment.NewService(repositoryWrapper.Environment, repositoryWrapper.Project, opts...),
+		secret.NewService(repositoryWrapper.Secret, repositoryWrapper.Project, secretsCipher, opts...),
+		runner.NewService(repositoryWrapper.Project, repositoryWrapper.Scenario, repositoryWrapper.Environment, repositoryWrapper.Run, runsProducer, opts...),
 	}
 }
@@ -21,6 +21,20 @@ const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for AssertionResultType.
+const (
+	Body     AssertionResultType = "body"
+	Capture  AssertionResultType = "capture"
+	Contract AssertionResultType = "contract"
+	Cookies  AssertionResultType = "cookies"
+	Duration AssertionResultType = "duration"
+	Graphql  AssertionResultType = "graphql"
+	Headers  AssertionResultType = "headers"
+	Latency  AssertionResultType = "latency"
+	Schema   AssertionResultType = "schema"
+	Status   AssertionResultType = "status"
+)
+
 // Defines values for ProjectRunOutputState.
 const (
 	Cancelled ProjectRunOutputState = "cancelled"
@@ -32,9 +46,91 @@ const (
 
 // Defines values for ScenarioSpecType.
 const (
-	Yaml ScenarioSpecType = "yaml"
+	ScenarioSpecTypeAttachment   ScenarioSpecType = "attachment"
+	ScenarioSpecTypeClientConfig ScenarioSpecType = "client"
+	ScenarioSpecTypeCsv          ScenarioSpecType = "csv"
+	ScenarioSpecTypeFragment     ScenarioSpecType = "fragment"
+	ScenarioSpecTypeJson         ScenarioSpecType = "json"
+	ScenarioSpecTypeOpenapi      ScenarioSpecType = "openapi"
+	ScenarioSpecTypeYaml         ScenarioSpecType = "yaml"
+)
+
+// Defines values for ScenarioImportRequestFormat.
+const (
+	Har     ScenarioImportRequestFormat = "har"
+	Postman ScenarioImportRequestFormat = "postman"
+)
+
+// Defines values for ScenarioIssueSeverity.
+const (
+	Error   ScenarioIssueSeverity = "error"
+	Warning ScenarioIssueSeverity = "warning"
+)
+
+// Defines values for ScenarioValidateRequestSpecType.
+const (
+	ScenarioValidateRequestSpecTypeFragment ScenarioValidateRequestSpecType = "fragment"
+	ScenarioValidateRequestSpecTypeYaml     ScenarioValidateRequestSpecType = "yaml"
 )
 
+// AssertionResult defines model for AssertionResult.
+type AssertionResult struct {
+	Actual    string `json:"actual"`
+	Assertion string `json:"assertion"`
+	Expected  string `json:"expected"`
+
+	// Key The key of the asserted value, for schema assertions the JSON pointer of the violating value
+	Key     string              `json:"key"`
+	Message string              `json:"message"`
+	Success bool                `json:"success"`
+	Type    AssertionResultType `json:"type"`
+}
+
+// AssertionResultType defines model for AssertionResult.Type.
+type AssertionResultType string
+
+// AttemptResult defines model for AttemptResult.
+type AttemptResult struct {
+	// DelayInMs The delay before the next attempt
+	DelayInMs           int    `json:"delay_in_ms"`
+	Error               string `json:"error"`
+	RequestDurationInMs int    `json:"request_duration_in_ms"`
+
+	// Status The status of the response, 0 if the request failed
+	Status  int  `json:"status"`
+	Success bool `json:"success"`
+}
+
+// Environment defines model for Environment.
+type Environment struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID uuid.UUID `json:"project_id"`
+
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentArray defines model for EnvironmentArray.
+type EnvironmentArray = []Environment
+
+// EnvironmentCreateRequest defines model for EnvironmentCreateRequest.
+type EnvironmentCreateRequest struct {
+	Name string `json:"name"`
+
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentUpdateRequest defines model for EnvironmentUpdateRequest.
+type EnvironmentUpdateRequest struct {
+	// Variables The values of the variables of the scenarios, which override the values defined by the scenarios
+	Variables EnvironmentVariables `json:"variables"`
+}
+
+// EnvironmentVariables The values of the variables of the scenarios, which override the values defined by the scenarios
+type EnvironmentVariables map[string]string
+
 // ErrMsg defines model for ErrMsg.
 type ErrMsg struct {
 	Message string `json:"message"`
@@ -51,6 +147,8 @@ type ProjectArray = []Project
 
 // ProjectRunOutput defines model for ProjectRunOutput.
 type ProjectRunOutput struct {
+	// Environment The name of the environment the scenarios ran in, empty if the run has no environment
+	Environment        string                `json:"environment"`
 	ID                 uuid.UUID             `json:"id"`
 	ProjectID          uuid.UUID             `json:"project_id"`
 	ScenarioRunDetails []ScenarioRunDetails  `json:"scenario_run_details"`
@@ -66,6 +164,8 @@ type ProjectRunOutputArray = []ProjectRunOutput
 
 // ProjectRunRequest defines model for ProjectRunRequest.
 type ProjectRunRequest struct {
+	// Environment The name of the environment of the project the scenarios run in
+	Environment *string    `json:"environment,omitempty"`
 	ProjectID   *uuid.UUID `json:"project_id,omitempty"`
 	ProjectName *string    `json:"project_name,omitempty"`
 }
@@ -94,24 +194,111 @@ type ScenarioCreateRequest struct {
 	SpecType string `json:"spec_type"`
 }
 
+// ScenarioImportRequest defines model for ScenarioImportRequest.
+type ScenarioImportRequest struct {
+	// Data A base64 encoded string of the HAR file or Postman collection
+	Data string `json:"data"`
+
+	// Format The format of the imported data, a HAR file or a Postman collection (v2.1)
+	Format ScenarioImportRequestFormat `json:"format"`
+}
+
+// ScenarioImportRequestFormat The format of the imported data, a HAR file or a Postman collection (v2.1)
+type ScenarioImportRequestFormat string
+
+// ScenarioIssue defines model for ScenarioIssue.
+type ScenarioIssue struct {
+	// Column The column of the issue, 0 if the issue applies to the whole line
+	Column  int    `json:"column"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+
+	// Severity Warnings, e.g. variables which are not declared by the scenario but may be provided by an environment, do not prevent creating the scenario
+	Severity ScenarioIssueSeverity `json:"severity"`
+}
+
+// ScenarioIssueSeverity Warnings, e.g. variables which are not declared by the scenario but may be provided by an environment, do not prevent creating the scenario
+type ScenarioIssueSeverity string
+
 // ScenarioRunDetails defines model for ScenarioRunDetails.
 type ScenarioRunDetails struct {
-	Assertions   int              `json:"assertions"`
-	DurationInMs int              `json:"duration_in_ms"`
-	Name         string           `json:"name"`
-	Steps        []StepRunDetails `json:"steps"`
-	Success      bool             `json:"success"`
+	// AssertionResults The assertions on the scenario as a whole, such as its maximum duration
+	AssertionResults []AssertionResult `json:"assertion_results"`
+	Assertions       int               `json:"assertions"`
+	DurationInMs     int               `json:"duration_in_ms"`
+	Name             string            `json:"name"`
+	SetupSteps       []StepRunDetails  `json:"setup_steps"`
+	Steps            []StepRunDetails  `json:"steps"`
+
+	// SubScenarios The executions of a scenario with a matrix, one for every row of the matrix
+	SubScenarios  []ScenarioRunDetails `json:"sub_scenarios"`
+	Success       bool                 `json:"success"`
+	TeardownSteps []StepRunDetails     `json:"teardown_steps"`
+
+	// TimedOut Whether the scenario was aborted, because its timeout or the timeout of the run was exceeded
+	TimedOut bool `json:"timed_out"`
+}
+
+// ScenarioValidateRequest defines model for ScenarioValidateRequest.
+type ScenarioValidateRequest struct {
+	// Spec A base64 encoded string of the spec
+	Spec string `json:"spec"`
+
+	// SpecType The type of the spec, a scenario or a fragment of reusable steps
+	SpecType *ScenarioValidateRequestSpecType `json:"spec_type,omitempty"`
+}
+
+// ScenarioValidateRequestSpecType The type of the spec, a scenario or a fragment of reusable steps
+type ScenarioValidateRequestSpecType string
+
+// ScenarioValidation defines model for ScenarioValidation.
+type ScenarioValidation struct {
+	Issues []ScenarioIssue `json:"issues"`
+
+	// Valid Whether the spec has no issues other than warnings
+	Valid bool `json:"valid"`
+}
+
+// Secret defines model for Secret.
+type Secret struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// SecretArray defines model for SecretArray.
+type SecretArray = []Secret
+
+// SecretCreateRequest defines model for SecretCreateRequest.
+type SecretCreateRequest struct {
+	Name string `json:"name"`
+
+	// Value The plaintext value of the secret, which is stored encrypted and never returned
+	Value string `json:"value"`
+}
+
+// SecretUpdateRequest defines model for SecretUpdateRequest.
+type SecretUpdateRequest struct {
+	// Value The plaintext value of the secret, which is stored encrypted and never returned
+	Value string `json:"value"`
 }
 
 // StepRunDetails defines model for StepRunDetails.
 type StepRunDetails struct {
-	Assertions          int    `json:"assertions"`
-	DurationInMs        int    `json:"duration_in_ms"`
-	Name                string `json:"name"`
-	RequestDurationInMs int    `json:"request_duration_in_ms"`
-	Retries             int    `json:"retries"`
-	Success             bool   `json:"success"`
-	URL                 string `json:"url"`
+	AssertionResults    []AssertionResult `json:"assertion_results"`
+	Assertions          int               `json:"assertions"`
+	Attempts            []AttemptResult   `json:"attempts"`
+	DurationInMs        int               `json:"duration_in_ms"`
+	Name                string            `json:"name"`
+	RequestDurationInMs int               `json:"request_duration_in_ms"`
+
+	// Retries The number of retries used, excluding the first attempt
+	Retries int `json:"retries"`
+
+	// Skipped Whether the step was skipped, skipped steps are neither successful nor failed
+	Skipped bool   `json:"skipped"`
+	Success bool   `json:"success"`
+	URL     string `json:"url"`
 }
 
 // ListProjectsParams defines parameters for ListProjects.
@@ -132,6 +319,15 @@ type ListRunsForProjectParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// ListEnvironmentsForProjectParams defines parameters for ListEnvironmentsForProject.
+type ListEnvironmentsForProjectParams struct {
+	// Limit The number of environments to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of environments to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // ListScenariosForProjectParams defines parameters for ListScenariosForProject.
 type ListScenariosForProjectParams struct {
 	// Limit The number of scenarios to return
@@ -141,15 +337,42 @@ type ListScenariosForProjectParams struct {
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
+// ListSecretsForProjectParams defines parameters for ListSecretsForProject.
+type ListSecretsForProjectParams struct {
+	// Limit The number of secrets to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset The number of secrets to skip
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // CreateProjectJSONRequestBody defines body for CreateProject for application/json ContentType.
 type CreateProjectJSONRequestBody = Project
 
 // RunProjectJSONRequestBody defines body for RunProject for application/json ContentType.
 type RunProjectJSONRequestBody = ProjectRunRequest
 
+// CreateEnvironmentJSONRequestBody defines body for CreateEnvironment for application/json ContentType.
+type CreateEnvironmentJSONRequestBody = EnvironmentCreateRequest
+
+// UpdateEnvironmentJSONRequestBody defines body for UpdateEnvironment for application/json ContentType.
+type UpdateEnvironmentJSONRequestBody = EnvironmentUpdateRequest
+
 // CreateScenarioJSONRequestBody defines body for CreateScenario for application/json ContentType.
 type CreateScenarioJSONRequestBody = ScenarioCreateRequest
 
+// ImportScenariosJSONRequestBody defines body for ImportScenarios for application/json ContentType.
+type ImportScenariosJSONRequestBody = ScenarioImportRequest
+
+// CreateSecretJSONRequestBody defines body for CreateSecret for application/json ContentType.
+type CreateSecretJSONRequestBody = SecretCreateRequest
+
+// UpdateSecretJSONRequestBody defines body for UpdateSecret for application/json ContentType.
+type UpdateSecretJSONRequestBody = SecretUpdateRequest
+
+// ValidateScenarioJSONRequestBody defines body for ValidateScenario for application/json ContentType.
+type ValidateScenarioJSONRequestBody = ScenarioValidateRequest
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -239,6 +462,22 @@ type ClientInterface interface {
 	// ListRunsForProject request
 	ListRunsForProject(ctx context.Context, id uuid.UUID, params *ListRunsForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListEnvironmentsForProject request
+	ListEnvironmentsForProject(ctx context.Context, projectId uuid.UUID, params *ListEnvironmentsForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateEnvironmentWithBody request with any body
+	CreateEnvironmentWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateEnvironment(ctx context.Context, projectId uuid.UUID, body CreateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEnvironment request
+	DeleteEnvironment(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateEnvironmentWithBody request with any body
+	UpdateEnvironmentWithBody(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateEnvironment(ctx context.Context, projectId uuid.UUID, name string, body UpdateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListScenariosForProject request
 	ListScenariosForProject(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -246,6 +485,35 @@ type ClientInterface interface {
 	CreateScenarioWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	CreateScenario(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportScenariosWithBody request with any body
+	ImportScenariosWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ImportScenarios(ctx context.Context, projectId uuid.UUID, body ImportScenariosJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSecretsForProject request
+	ListSecretsForProject(ctx context.Context, projectId uuid.UUID, params *ListSecretsForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSecretWithBody request with any body
+	CreateSecretWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSecret(ctx context.Context, projectId uuid.UUID, body CreateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSecret request
+	DeleteSecret(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateSecretWithBody request with any body
+	UpdateSecretWithBody(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateSecret(ctx context.Context, projectId uuid.UUID, name string, body UpdateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScenarioSchema request
+	GetScenarioSchema(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ValidateScenarioWithBody request with any body
+	ValidateScenarioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ValidateScenario(ctx context.Context, body ValidateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) ListProjects(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -320,8 +588,8 @@ func (c *Client) ListRunsForProject(ctx context.Context, id uuid.UUID, params *L
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListScenariosForProject(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListScenariosForProjectRequest(c.Server, projectId, params)
+func (c *Client) ListEnvironmentsForProject(ctx context.Context, projectId uuid.UUID, params *ListEnvironmentsForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListEnvironmentsForProjectRequest(c.Server, projectId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -332,8 +600,8 @@ func (c *Client) ListScenariosForProject(ctx context.Context, projectId uuid.UUI
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateScenarioWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateScenarioRequestWithBody(c.Server, projectId, contentType, body)
+func (c *Client) CreateEnvironmentWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEnvironmentRequestWithBody(c.Server, projectId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -344,8 +612,8 @@ func (c *Client) CreateScenarioWithBody(ctx context.Context, projectId uuid.UUID
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateScenario(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateScenarioRequest(c.Server, projectId, body)
+func (c *Client) CreateEnvironment(ctx context.Context, projectId uuid.UUID, body CreateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateEnvironmentRequest(c.Server, projectId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -356,164 +624,368 @@ func (c *Client) CreateScenario(ctx context.Context, projectId uuid.UUID, body C
 	return c.Client.Do(req)
 }
 
-// NewListProjectsRequest generates requests for ListProjects
-func NewListProjectsRequest(server string, params *ListProjectsParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteEnvironment(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEnvironmentRequest(c.Server, projectId, name)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/projects")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) UpdateEnvironmentWithBody(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEnvironmentRequestWithBody(c.Server, projectId, name, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Limit != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		if params.Offset != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) UpdateEnvironment(ctx context.Context, projectId uuid.UUID, name string, body UpdateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateEnvironmentRequest(c.Server, projectId, name, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewCreateProjectRequest calls the generic CreateProject builder with application/json body
-func NewCreateProjectRequest(server string, body CreateProjectJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) ListScenariosForProject(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListScenariosForProjectRequest(c.Server, projectId, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateProjectRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewCreateProjectRequestWithBody generates requests for CreateProject with any type of body
-func NewCreateProjectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) CreateScenarioWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScenarioRequestWithBody(c.Server, projectId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/projects")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CreateScenario(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScenarioRequest(c.Server, projectId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewRunProjectRequest calls the generic RunProject builder with application/json body
-func NewRunProjectRequest(server string, body RunProjectJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) ImportScenariosWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportScenariosRequestWithBody(c.Server, projectId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewRunProjectRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewRunProjectRequestWithBody generates requests for RunProject with any type of body
-func NewRunProjectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) ImportScenarios(ctx context.Context, projectId uuid.UUID, body ImportScenariosJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportScenariosRequest(c.Server, projectId, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/v1/projects/run")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ListSecretsForProject(ctx context.Context, projectId uuid.UUID, params *ListSecretsForProjectParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSecretsForProjectRequest(c.Server, projectId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("POST", queryURL.String(), body)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewListRunsForProjectRequest generates requests for ListRunsForProject
-func NewListRunsForProjectRequest(server string, id uuid.UUID, params *ListRunsForProjectParams) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) CreateSecretWithBody(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSecretRequestWithBody(c.Server, projectId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSecret(ctx context.Context, projectId uuid.UUID, body CreateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSecretRequest(c.Server, projectId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSecret(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSecretRequest(c.Server, projectId, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSecretWithBody(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSecretRequestWithBody(c.Server, projectId, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateSecret(ctx context.Context, projectId uuid.UUID, name string, body UpdateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateSecretRequest(c.Server, projectId, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScenarioSchema(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScenarioSchemaRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ValidateScenarioWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewValidateScenarioRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ValidateScenario(ctx context.Context, body ValidateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewValidateScenarioRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListProjectsRequest generates requests for ListProjects
+func NewListProjectsRequest(server string, params *ListProjectsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateProjectRequest calls the generic CreateProject builder with application/json body
+func NewCreateProjectRequest(server string, body CreateProjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateProjectRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateProjectRequestWithBody generates requests for CreateProject with any type of body
+func NewCreateProjectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRunProjectRequest calls the generic RunProject builder with application/json body
+func NewRunProjectRequest(server string, body RunProjectJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRunProjectRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRunProjectRequestWithBody generates requests for RunProject with any type of body
+func NewRunProjectRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/run")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListRunsForProjectRequest generates requests for ListRunsForProject
+func NewListRunsForProjectRequest(server string, id uuid.UUID, params *ListRunsForProjectParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
 		return nil, err
 	}
 
@@ -573,8 +1045,8 @@ func NewListRunsForProjectRequest(server string, id uuid.UUID, params *ListRunsF
 	return req, nil
 }
 
-// NewListScenariosForProjectRequest generates requests for ListScenariosForProject
-func NewListScenariosForProjectRequest(server string, projectId uuid.UUID, params *ListScenariosForProjectParams) (*http.Request, error) {
+// NewListEnvironmentsForProjectRequest generates requests for ListEnvironmentsForProject
+func NewListEnvironmentsForProjectRequest(server string, projectId uuid.UUID, params *ListEnvironmentsForProjectParams) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -589,7 +1061,7 @@ func NewListScenariosForProjectRequest(server string, projectId uuid.UUID, param
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/projects/%s/scenarios", pathParam0)
+	operationPath := fmt.Sprintf("/v1/projects/%s/environments", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -645,19 +1117,19 @@ func NewListScenariosForProjectRequest(server string, projectId uuid.UUID, param
 	return req, nil
 }
 
-// NewCreateScenarioRequest calls the generic CreateScenario builder with application/json body
-func NewCreateScenarioRequest(server string, projectId uuid.UUID, body CreateScenarioJSONRequestBody) (*http.Request, error) {
+// NewCreateEnvironmentRequest calls the generic CreateEnvironment builder with application/json body
+func NewCreateEnvironmentRequest(server string, projectId uuid.UUID, body CreateEnvironmentJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewCreateScenarioRequestWithBody(server, projectId, "application/json", bodyReader)
+	return NewCreateEnvironmentRequestWithBody(server, projectId, "application/json", bodyReader)
 }
 
-// NewCreateScenarioRequestWithBody generates requests for CreateScenario with any type of body
-func NewCreateScenarioRequestWithBody(server string, projectId uuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
+// NewCreateEnvironmentRequestWithBody generates requests for CreateEnvironment with any type of body
+func NewCreateEnvironmentRequestWithBody(server string, projectId uuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -672,7 +1144,7 @@ func NewCreateScenarioRequestWithBody(server string, projectId uuid.UUID, conten
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/v1/projects/%s/scenarios", pathParam0)
+	operationPath := fmt.Sprintf("/v1/projects/%s/environments", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -692,306 +1164,1647 @@ func NewCreateScenarioRequestWithBody(server string, projectId uuid.UUID, conten
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
+// NewDeleteEnvironmentRequest generates requests for DeleteEnvironment
+func NewDeleteEnvironmentRequest(server string, projectId uuid.UUID, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/environments/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateEnvironmentRequest calls the generic UpdateEnvironment builder with application/json body
+func NewUpdateEnvironmentRequest(server string, projectId uuid.UUID, name string, body UpdateEnvironmentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateEnvironmentRequestWithBody(server, projectId, name, "application/json", bodyReader)
+}
+
+// NewUpdateEnvironmentRequestWithBody generates requests for UpdateEnvironment with any type of body
+func NewUpdateEnvironmentRequestWithBody(server string, projectId uuid.UUID, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/environments/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListScenariosForProjectRequest generates requests for ListScenariosForProject
+func NewListScenariosForProjectRequest(server string, projectId uuid.UUID, params *ListScenariosForProjectParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/scenarios", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateScenarioRequest calls the generic CreateScenario builder with application/json body
+func NewCreateScenarioRequest(server string, projectId uuid.UUID, body CreateScenarioJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateScenarioRequestWithBody(server, projectId, "application/json", bodyReader)
+}
+
+// NewCreateScenarioRequestWithBody generates requests for CreateScenario with any type of body
+func NewCreateScenarioRequestWithBody(server string, projectId uuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/scenarios", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewImportScenariosRequest calls the generic ImportScenarios builder with application/json body
+func NewImportScenariosRequest(server string, projectId uuid.UUID, body ImportScenariosJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewImportScenariosRequestWithBody(server, projectId, "application/json", bodyReader)
+}
+
+// NewImportScenariosRequestWithBody generates requests for ImportScenarios with any type of body
+func NewImportScenariosRequestWithBody(server string, projectId uuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/scenarios/import", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListSecretsForProjectRequest generates requests for ListSecretsForProject
+func NewListSecretsForProjectRequest(server string, projectId uuid.UUID, params *ListSecretsForProjectParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/secrets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSecretRequest calls the generic CreateSecret builder with application/json body
+func NewCreateSecretRequest(server string, projectId uuid.UUID, body CreateSecretJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSecretRequestWithBody(server, projectId, "application/json", bodyReader)
+}
+
+// NewCreateSecretRequestWithBody generates requests for CreateSecret with any type of body
+func NewCreateSecretRequestWithBody(server string, projectId uuid.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/secrets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteSecretRequest generates requests for DeleteSecret
+func NewDeleteSecretRequest(server string, projectId uuid.UUID, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/secrets/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateSecretRequest calls the generic UpdateSecret builder with application/json body
+func NewUpdateSecretRequest(server string, projectId uuid.UUID, name string, body UpdateSecretJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateSecretRequestWithBody(server, projectId, name, "application/json", bodyReader)
+}
+
+// NewUpdateSecretRequestWithBody generates requests for UpdateSecret with any type of body
+func NewUpdateSecretRequestWithBody(server string, projectId uuid.UUID, name string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/projects/%s/secrets/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetScenarioSchemaRequest generates requests for GetScenarioSchema
+func NewGetScenarioSchemaRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/scenarios/schema")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewValidateScenarioRequest calls the generic ValidateScenario builder with application/json body
+func NewValidateScenarioRequest(server string, body ValidateScenarioJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewValidateScenarioRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewValidateScenarioRequestWithBody generates requests for ValidateScenario with any type of body
+func NewValidateScenarioRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/scenarios/validate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
 // ClientWithResponses builds on ClientInterface to offer response payloads
 type ClientWithResponses struct {
 	ClientInterface
 }
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListProjectsWithResponse request
+	ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error)
+
+	// CreateProjectWithBodyWithResponse request with any body
+	CreateProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error)
+
+	CreateProjectWithResponse(ctx context.Context, body CreateProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error)
+
+	// RunProjectWithBodyWithResponse request with any body
+	RunProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunProjectResponse, error)
+
+	RunProjectWithResponse(ctx context.Context, body RunProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*RunProjectResponse, error)
+
+	// ListRunsForProjectWithResponse request
+	ListRunsForProjectWithResponse(ctx context.Context, id uuid.UUID, params *ListRunsForProjectParams, reqEditors ...RequestEditorFn) (*ListRunsForProjectResponse, error)
+
+	// ListEnvironmentsForProjectWithResponse request
+	ListEnvironmentsForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListEnvironmentsForProjectParams, reqEditors ...RequestEditorFn) (*ListEnvironmentsForProjectResponse, error)
+
+	// CreateEnvironmentWithBodyWithResponse request with any body
+	CreateEnvironmentWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEnvironmentResponse, error)
+
+	CreateEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, body CreateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEnvironmentResponse, error)
+
+	// DeleteEnvironmentWithResponse request
+	DeleteEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*DeleteEnvironmentResponse, error)
+
+	// UpdateEnvironmentWithBodyWithResponse request with any body
+	UpdateEnvironmentWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEnvironmentResponse, error)
+
+	UpdateEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, name string, body UpdateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEnvironmentResponse, error)
+
+	// ListScenariosForProjectWithResponse request
+	ListScenariosForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*ListScenariosForProjectResponse, error)
+
+	// CreateScenarioWithBodyWithResponse request with any body
+	CreateScenarioWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error)
+
+	CreateScenarioWithResponse(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error)
+
+	// ImportScenariosWithBodyWithResponse request with any body
+	ImportScenariosWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportScenariosResponse, error)
+
+	ImportScenariosWithResponse(ctx context.Context, projectId uuid.UUID, body ImportScenariosJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportScenariosResponse, error)
+
+	// ListSecretsForProjectWithResponse request
+	ListSecretsForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListSecretsForProjectParams, reqEditors ...RequestEditorFn) (*ListSecretsForProjectResponse, error)
+
+	// CreateSecretWithBodyWithResponse request with any body
+	CreateSecretWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSecretResponse, error)
+
+	CreateSecretWithResponse(ctx context.Context, projectId uuid.UUID, body CreateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSecretResponse, error)
+
+	// DeleteSecretWithResponse request
+	DeleteSecretWithResponse(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*DeleteSecretResponse, error)
+
+	// UpdateSecretWithBodyWithResponse request with any body
+	UpdateSecretWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSecretResponse, error)
+
+	UpdateSecretWithResponse(ctx context.Context, projectId uuid.UUID, name string, body UpdateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSecretResponse, error)
+
+	// GetScenarioSchemaWithResponse request
+	GetScenarioSchemaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetScenarioSchemaResponse, error)
+
+	// ValidateScenarioWithBodyWithResponse request with any body
+	ValidateScenarioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ValidateScenarioResponse, error)
+
+	ValidateScenarioWithResponse(ctx context.Context, body ValidateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*ValidateScenarioResponse, error)
+}
+
+type ListProjectsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProjectArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ListProjectsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListProjectsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Project
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RunProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProjectRunOutput
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r RunProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RunProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListRunsForProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ProjectRunOutputArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ListRunsForProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListRunsForProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListEnvironmentsForProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EnvironmentArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ListEnvironmentsForProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListEnvironmentsForProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateEnvironmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Environment
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateEnvironmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateEnvironmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteEnvironmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEnvironmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEnvironmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateEnvironmentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Environment
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateEnvironmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateEnvironmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListScenariosForProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScenarioArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ListScenariosForProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListScenariosForProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateScenarioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Scenario
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateScenarioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateScenarioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportScenariosResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ScenarioArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportScenariosResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportScenariosResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSecretsForProjectResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SecretArray
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSecretsForProjectResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSecretsForProjectResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSecretResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Secret
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSecretResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateSecretResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Secret
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateSecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateSecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScenarioSchemaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScenarioSchemaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScenarioSchemaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ValidateScenarioResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScenarioValidation
+	JSONDefault  *ErrMsg
+}
+
+// Status returns HTTPResponse.Status
+func (r ValidateScenarioResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ValidateScenarioResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListProjectsWithResponse request returning *ListProjectsResponse
+func (c *ClientWithResponses) ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error) {
+	rsp, err := c.ListProjects(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListProjectsResponse(rsp)
+}
+
+// CreateProjectWithBodyWithResponse request with arbitrary body returning *CreateProjectResponse
+func (c *ClientWithResponses) CreateProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error) {
+	rsp, err := c.CreateProjectWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateProjectWithResponse(ctx context.Context, body CreateProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error) {
+	rsp, err := c.CreateProject(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProjectResponse(rsp)
+}
+
+// RunProjectWithBodyWithResponse request with arbitrary body returning *RunProjectResponse
+func (c *ClientWithResponses) RunProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunProjectResponse, error) {
+	rsp, err := c.RunProjectWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRunProjectResponse(rsp)
+}
+
+func (c *ClientWithResponses) RunProjectWithResponse(ctx context.Context, body RunProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*RunProjectResponse, error) {
+	rsp, err := c.RunProject(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRunProjectResponse(rsp)
+}
+
+// ListRunsForProjectWithResponse request returning *ListRunsForProjectResponse
+func (c *ClientWithResponses) ListRunsForProjectWithResponse(ctx context.Context, id uuid.UUID, params *ListRunsForProjectParams, reqEditors ...RequestEditorFn) (*ListRunsForProjectResponse, error) {
+	rsp, err := c.ListRunsForProject(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListRunsForProjectResponse(rsp)
+}
+
+// ListEnvironmentsForProjectWithResponse request returning *ListEnvironmentsForProjectResponse
+func (c *ClientWithResponses) ListEnvironmentsForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListEnvironmentsForProjectParams, reqEditors ...RequestEditorFn) (*ListEnvironmentsForProjectResponse, error) {
+	rsp, err := c.ListEnvironmentsForProject(ctx, projectId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListEnvironmentsForProjectResponse(rsp)
+}
+
+// CreateEnvironmentWithBodyWithResponse request with arbitrary body returning *CreateEnvironmentResponse
+func (c *ClientWithResponses) CreateEnvironmentWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateEnvironmentResponse, error) {
+	rsp, err := c.CreateEnvironmentWithBody(ctx, projectId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEnvironmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, body CreateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateEnvironmentResponse, error) {
+	rsp, err := c.CreateEnvironment(ctx, projectId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateEnvironmentResponse(rsp)
+}
+
+// DeleteEnvironmentWithResponse request returning *DeleteEnvironmentResponse
+func (c *ClientWithResponses) DeleteEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*DeleteEnvironmentResponse, error) {
+	rsp, err := c.DeleteEnvironment(ctx, projectId, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEnvironmentResponse(rsp)
+}
+
+// UpdateEnvironmentWithBodyWithResponse request with arbitrary body returning *UpdateEnvironmentResponse
+func (c *ClientWithResponses) UpdateEnvironmentWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateEnvironmentResponse, error) {
+	rsp, err := c.UpdateEnvironmentWithBody(ctx, projectId, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEnvironmentResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateEnvironmentWithResponse(ctx context.Context, projectId uuid.UUID, name string, body UpdateEnvironmentJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateEnvironmentResponse, error) {
+	rsp, err := c.UpdateEnvironment(ctx, projectId, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateEnvironmentResponse(rsp)
+}
+
+// ListScenariosForProjectWithResponse request returning *ListScenariosForProjectResponse
+func (c *ClientWithResponses) ListScenariosForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*ListScenariosForProjectResponse, error) {
+	rsp, err := c.ListScenariosForProject(ctx, projectId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListScenariosForProjectResponse(rsp)
+}
+
+// CreateScenarioWithBodyWithResponse request with arbitrary body returning *CreateScenarioResponse
+func (c *ClientWithResponses) CreateScenarioWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error) {
+	rsp, err := c.CreateScenarioWithBody(ctx, projectId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScenarioResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateScenarioWithResponse(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error) {
+	rsp, err := c.CreateScenario(ctx, projectId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScenarioResponse(rsp)
+}
+
+// ImportScenariosWithBodyWithResponse request with arbitrary body returning *ImportScenariosResponse
+func (c *ClientWithResponses) ImportScenariosWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportScenariosResponse, error) {
+	rsp, err := c.ImportScenariosWithBody(ctx, projectId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportScenariosResponse(rsp)
+}
+
+func (c *ClientWithResponses) ImportScenariosWithResponse(ctx context.Context, projectId uuid.UUID, body ImportScenariosJSONRequestBody, reqEditors ...RequestEditorFn) (*ImportScenariosResponse, error) {
+	rsp, err := c.ImportScenarios(ctx, projectId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportScenariosResponse(rsp)
+}
+
+// ListSecretsForProjectWithResponse request returning *ListSecretsForProjectResponse
+func (c *ClientWithResponses) ListSecretsForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListSecretsForProjectParams, reqEditors ...RequestEditorFn) (*ListSecretsForProjectResponse, error) {
+	rsp, err := c.ListSecretsForProject(ctx, projectId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSecretsForProjectResponse(rsp)
+}
+
+// CreateSecretWithBodyWithResponse request with arbitrary body returning *CreateSecretResponse
+func (c *ClientWithResponses) CreateSecretWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSecretResponse, error) {
+	rsp, err := c.CreateSecretWithBody(ctx, projectId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSecretResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSecretWithResponse(ctx context.Context, projectId uuid.UUID, body CreateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSecretResponse, error) {
+	rsp, err := c.CreateSecret(ctx, projectId, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+	return ParseCreateSecretResponse(rsp)
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
-		}
-		c.Server = newBaseURL.String()
-		return nil
+// DeleteSecretWithResponse request returning *DeleteSecretResponse
+func (c *ClientWithResponses) DeleteSecretWithResponse(ctx context.Context, projectId uuid.UUID, name string, reqEditors ...RequestEditorFn) (*DeleteSecretResponse, error) {
+	rsp, err := c.DeleteSecret(ctx, projectId, name, reqEditors...)
+	if err != nil {
+		return nil, err
 	}
+	return ParseDeleteSecretResponse(rsp)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// ListProjectsWithResponse request
-	ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error)
+// UpdateSecretWithBodyWithResponse request with arbitrary body returning *UpdateSecretResponse
+func (c *ClientWithResponses) UpdateSecretWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, name string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateSecretResponse, error) {
+	rsp, err := c.UpdateSecretWithBody(ctx, projectId, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSecretResponse(rsp)
+}
 
-	// CreateProjectWithBodyWithResponse request with any body
-	CreateProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error)
+func (c *ClientWithResponses) UpdateSecretWithResponse(ctx context.Context, projectId uuid.UUID, name string, body UpdateSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateSecretResponse, error) {
+	rsp, err := c.UpdateSecret(ctx, projectId, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateSecretResponse(rsp)
+}
 
-	CreateProjectWithResponse(ctx context.Context, body CreateProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error)
+// GetScenarioSchemaWithResponse request returning *GetScenarioSchemaResponse
+func (c *ClientWithResponses) GetScenarioSchemaWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetScenarioSchemaResponse, error) {
+	rsp, err := c.GetScenarioSchema(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScenarioSchemaResponse(rsp)
+}
 
-	// RunProjectWithBodyWithResponse request with any body
-	RunProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunProjectResponse, error)
+// ValidateScenarioWithBodyWithResponse request with arbitrary body returning *ValidateScenarioResponse
+func (c *ClientWithResponses) ValidateScenarioWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ValidateScenarioResponse, error) {
+	rsp, err := c.ValidateScenarioWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseValidateScenarioResponse(rsp)
+}
 
-	RunProjectWithResponse(ctx context.Context, body RunProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*RunProjectResponse, error)
+func (c *ClientWithResponses) ValidateScenarioWithResponse(ctx context.Context, body ValidateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*ValidateScenarioResponse, error) {
+	rsp, err := c.ValidateScenario(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseValidateScenarioResponse(rsp)
+}
 
-	// ListRunsForProjectWithResponse request
-	ListRunsForProjectWithResponse(ctx context.Context, id uuid.UUID, params *ListRunsForProjectParams, reqEditors ...RequestEditorFn) (*ListRunsForProjectResponse, error)
+// ParseListProjectsResponse parses an HTTP response from a ListProjectsWithResponse call
+func ParseListProjectsResponse(rsp *http.Response) (*ListProjectsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-	// ListScenariosForProjectWithResponse request
-	ListScenariosForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*ListScenariosForProjectResponse, error)
+	response := &ListProjectsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
 
-	// CreateScenarioWithBodyWithResponse request with any body
-	CreateScenarioWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error)
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProjectArray
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-	CreateScenarioWithResponse(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-type ListProjectsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ProjectArray
-	JSONDefault  *ErrMsg
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ListProjectsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseCreateProjectResponse parses an HTTP response from a CreateProjectWithResponse call
+func ParseCreateProjectResponse(rsp *http.Response) (*CreateProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListProjectsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &CreateProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type CreateProjectResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *Project
-	JSONDefault  *ErrMsg
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Project
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r CreateProjectResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseRunProjectResponse parses an HTTP response from a RunProjectWithResponse call
+func ParseRunProjectResponse(rsp *http.Response) (*RunProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
+
+	response := &RunProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProjectRunOutput
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateProjectResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+// ParseListRunsForProjectResponse parses an HTTP response from a ListRunsForProjectWithResponse call
+func ParseListRunsForProjectResponse(rsp *http.Response) (*ListRunsForProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return 0
+
+	response := &ListRunsForProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ProjectRunOutputArray
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-type RunProjectResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ProjectRunOutput
-	JSONDefault  *ErrMsg
+// ParseListEnvironmentsForProjectResponse parses an HTTP response from a ListEnvironmentsForProjectWithResponse call
+func ParseListEnvironmentsForProjectResponse(rsp *http.Response) (*ListEnvironmentsForProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEnvironmentsForProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EnvironmentArray
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r RunProjectResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseCreateEnvironmentResponse parses an HTTP response from a CreateEnvironmentWithResponse call
+func ParseCreateEnvironmentResponse(rsp *http.Response) (*CreateEnvironmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateEnvironmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Environment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RunProjectResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+	return response, nil
 }
 
-type ListRunsForProjectResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ProjectRunOutputArray
-	JSONDefault  *ErrMsg
-}
+// ParseDeleteEnvironmentResponse parses an HTTP response from a DeleteEnvironmentWithResponse call
+func ParseDeleteEnvironmentResponse(rsp *http.Response) (*DeleteEnvironmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
 
-// Status returns HTTPResponse.Status
-func (r ListRunsForProjectResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+	response := &DeleteEnvironmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListRunsForProjectResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return 0
-}
 
-type ListScenariosForProjectResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *ScenarioArray
-	JSONDefault  *ErrMsg
+	return response, nil
 }
 
-// Status returns HTTPResponse.Status
-func (r ListScenariosForProjectResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
+// ParseUpdateEnvironmentResponse parses an HTTP response from a UpdateEnvironmentWithResponse call
+func ParseUpdateEnvironmentResponse(rsp *http.Response) (*UpdateEnvironmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
 	}
-	return http.StatusText(0)
-}
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ListScenariosForProjectResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
+	response := &UpdateEnvironmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return 0
-}
 
-type CreateScenarioResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON201      *Scenario
-	JSONDefault  *ErrMsg
-}
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Environment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
 
-// Status returns HTTPResponse.Status
-func (r CreateScenarioResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
-}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r CreateScenarioResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
 	}
-	return 0
+
+	return response, nil
 }
 
-// ListProjectsWithResponse request returning *ListProjectsResponse
-func (c *ClientWithResponses) ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error) {
-	rsp, err := c.ListProjects(ctx, params, reqEditors...)
+// ParseListScenariosForProjectResponse parses an HTTP response from a ListScenariosForProjectWithResponse call
+func ParseListScenariosForProjectResponse(rsp *http.Response) (*ListScenariosForProjectResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListProjectsResponse(rsp)
-}
 
-// CreateProjectWithBodyWithResponse request with arbitrary body returning *CreateProjectResponse
-func (c *ClientWithResponses) CreateProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error) {
-	rsp, err := c.CreateProjectWithBody(ctx, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ListScenariosForProjectResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseCreateProjectResponse(rsp)
-}
 
-func (c *ClientWithResponses) CreateProjectWithResponse(ctx context.Context, body CreateProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectResponse, error) {
-	rsp, err := c.CreateProject(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScenarioArray
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseCreateProjectResponse(rsp)
+
+	return response, nil
 }
 
-// RunProjectWithBodyWithResponse request with arbitrary body returning *RunProjectResponse
-func (c *ClientWithResponses) RunProjectWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunProjectResponse, error) {
-	rsp, err := c.RunProjectWithBody(ctx, contentType, body, reqEditors...)
+// ParseCreateScenarioResponse parses an HTTP response from a CreateScenarioWithResponse call
+func ParseCreateScenarioResponse(rsp *http.Response) (*CreateScenarioResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseRunProjectResponse(rsp)
-}
 
-func (c *ClientWithResponses) RunProjectWithResponse(ctx context.Context, body RunProjectJSONRequestBody, reqEditors ...RequestEditorFn) (*RunProjectResponse, error) {
-	rsp, err := c.RunProject(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &CreateScenarioResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseRunProjectResponse(rsp)
-}
 
-// ListRunsForProjectWithResponse request returning *ListRunsForProjectResponse
-func (c *ClientWithResponses) ListRunsForProjectWithResponse(ctx context.Context, id uuid.UUID, params *ListRunsForProjectParams, reqEditors ...RequestEditorFn) (*ListRunsForProjectResponse, error) {
-	rsp, err := c.ListRunsForProject(ctx, id, params, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Scenario
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseListRunsForProjectResponse(rsp)
+
+	return response, nil
 }
 
-// ListScenariosForProjectWithResponse request returning *ListScenariosForProjectResponse
-func (c *ClientWithResponses) ListScenariosForProjectWithResponse(ctx context.Context, projectId uuid.UUID, params *ListScenariosForProjectParams, reqEditors ...RequestEditorFn) (*ListScenariosForProjectResponse, error) {
-	rsp, err := c.ListScenariosForProject(ctx, projectId, params, reqEditors...)
+// ParseImportScenariosResponse parses an HTTP response from a ImportScenariosWithResponse call
+func ParseImportScenariosResponse(rsp *http.Response) (*ImportScenariosResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
-	return ParseListScenariosForProjectResponse(rsp)
-}
 
-// CreateScenarioWithBodyWithResponse request with arbitrary body returning *CreateScenarioResponse
-func (c *ClientWithResponses) CreateScenarioWithBodyWithResponse(ctx context.Context, projectId uuid.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error) {
-	rsp, err := c.CreateScenarioWithBody(ctx, projectId, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	response := &ImportScenariosResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return ParseCreateScenarioResponse(rsp)
-}
 
-func (c *ClientWithResponses) CreateScenarioWithResponse(ctx context.Context, projectId uuid.UUID, body CreateScenarioJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScenarioResponse, error) {
-	rsp, err := c.CreateScenario(ctx, projectId, body, reqEditors...)
-	if err != nil {
-		return nil, err
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ScenarioArray
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrMsg
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseCreateScenarioResponse(rsp)
+
+	return response, nil
 }
 
-// ParseListProjectsResponse parses an HTTP response from a ListProjectsWithResponse call
-func ParseListProjectsResponse(rsp *http.Response) (*ListProjectsResponse, error) {
+// ParseListSecretsForProjectResponse parses an HTTP response from a ListSecretsForProjectWithResponse call
+func ParseListSecretsForProjectResponse(rsp *http.Response) (*ListSecretsForProjectResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListProjectsResponse{
+	response := &ListSecretsForProjectResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ProjectArray
+		var dest SecretArray
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1009,22 +2822,22 @@ func ParseListProjectsResponse(rsp *http.Response) (*ListProjectsResponse, error
 	return response, nil
 }
 
-// ParseCreateProjectResponse parses an HTTP response from a CreateProjectWithResponse call
-func ParseCreateProjectResponse(rsp *http.Response) (*CreateProjectResponse, error) {
+// ParseCreateSecretResponse parses an HTTP response from a CreateSecretWithResponse call
+func ParseCreateSecretResponse(rsp *http.Response) (*CreateSecretResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateProjectResponse{
+	response := &CreateSecretResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Project
+		var dest Secret
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1042,27 +2855,20 @@ func ParseCreateProjectResponse(rsp *http.Response) (*CreateProjectResponse, err
 	return response, nil
 }
 
-// ParseRunProjectResponse parses an HTTP response from a RunProjectWithResponse call
-func ParseRunProjectResponse(rsp *http.Response) (*RunProjectResponse, error) {
+// ParseDeleteSecretResponse parses an HTTP response from a DeleteSecretWithResponse call
+func ParseDeleteSecretResponse(rsp *http.Response) (*DeleteSecretResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &RunProjectResponse{
+	response := &DeleteSecretResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ProjectRunOutput
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest ErrMsg
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1075,22 +2881,22 @@ func ParseRunProjectResponse(rsp *http.Response) (*RunProjectResponse, error) {
 	return response, nil
 }
 
-// ParseListRunsForProjectResponse parses an HTTP response from a ListRunsForProjectWithResponse call
-func ParseListRunsForProjectResponse(rsp *http.Response) (*ListRunsForProjectResponse, error) {
+// ParseUpdateSecretResponse parses an HTTP response from a UpdateSecretWithResponse call
+func ParseUpdateSecretResponse(rsp *http.Response) (*UpdateSecretResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListRunsForProjectResponse{
+	response := &UpdateSecretResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ProjectRunOutputArray
+		var dest Secret
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1108,22 +2914,22 @@ func ParseListRunsForProjectResponse(rsp *http.Response) (*ListRunsForProjectRes
 	return response, nil
 }
 
-// ParseListScenariosForProjectResponse parses an HTTP response from a ListScenariosForProjectWithResponse call
-func ParseListScenariosForProjectResponse(rsp *http.Response) (*ListScenariosForProjectResponse, error) {
+// ParseGetScenarioSchemaResponse parses an HTTP response from a GetScenarioSchemaWithResponse call
+func ParseGetScenarioSchemaResponse(rsp *http.Response) (*GetScenarioSchemaResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListScenariosForProjectResponse{
+	response := &GetScenarioSchemaResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ScenarioArray
+		var dest map[string]interface{}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1141,26 +2947,26 @@ func ParseListScenariosForProjectResponse(rsp *http.Response) (*ListScenariosFor
 	return response, nil
 }
 
-// ParseCreateScenarioResponse parses an HTTP response from a CreateScenarioWithResponse call
-func ParseCreateScenarioResponse(rsp *http.Response) (*CreateScenarioResponse, error) {
+// ParseValidateScenarioResponse parses an HTTP response from a ValidateScenarioWithResponse call
+func ParseValidateScenarioResponse(rsp *http.Response) (*ValidateScenarioResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateScenarioResponse{
+	response := &ValidateScenarioResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Scenario
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScenarioValidation
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest ErrMsg